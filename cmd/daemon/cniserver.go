@@ -88,16 +88,31 @@ func main() {
 		kubeinformers.WithTweakListOptions(func(listOption *v1.ListOptions) {
 			listOption.AllowWatchBookmarks = true
 		}))
+	// only the external gateway configmap is watched
+	cmInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(config.KubeClient, 0,
+		kubeinformers.WithTweakListOptions(func(listOption *v1.ListOptions) {
+			listOption.FieldSelector = "metadata.name=" + util.ExternalGatewayConfig
+			listOption.AllowWatchBookmarks = true
+		}), kubeinformers.WithNamespace(config.ExternalGatewayConfigNS))
+	// only the leases in the namespace of the daemon are watched
+	leaseInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(config.KubeClient, 0,
+		kubeinformers.WithTweakListOptions(func(listOption *v1.ListOptions) {
+			listOption.AllowWatchBookmarks = true
+		}), kubeinformers.WithNamespace(config.PodNamespace))
 	kubeovnInformerFactory := kubeovninformer.NewSharedInformerFactoryWithOptions(config.KubeOvnClient, 0,
 		kubeovninformer.WithTweakListOptions(func(listOption *v1.ListOptions) {
 			listOption.AllowWatchBookmarks = true
 		}))
-	ctl, err := daemon.NewController(config, stopCh, podInformerFactory, nodeInformerFactory, kubeovnInformerFactory)
+	ctl, err := daemon.NewController(config, stopCh, podInformerFactory, nodeInformerFactory, cmInformerFactory, leaseInformerFactory, kubeovnInformerFactory)
 	if err != nil {
 		util.LogFatalAndExit(err, "failed to create controller")
 	}
 	klog.Info("start daemon controller")
-	go ctl.Run(stopCh)
+	ctlDone := make(chan struct{})
+	go func() {
+		defer close(ctlDone)
+		ctl.Run(stopCh)
+	}()
 	go daemon.RunServer(config, ctl)
 
 	addrs := util.GetDefaultListenAddr()
@@ -187,6 +202,8 @@ func main() {
 	}
 
 	<-stopCh
+	// the controller may tear down the gateway before it returns
+	<-ctlDone
 }
 
 func mvCNIConf(configDir, configFile, confName string) error {
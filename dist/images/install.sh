@@ -2806,6 +2806,8 @@ spec:
                             type: string
                           dstIPs:
                             type: string
+                natOutgoingSnatIP:
+                  type: string
                 u2oInterconnection:
                   type: boolean
                 u2oInterconnectionIP:
@@ -3688,6 +3690,16 @@ rules:
     verbs:
       - get
       - update
+  - apiGroups:
+      - coordination.k8s.io
+    resources:
+      - leases
+    verbs:
+      - get
+      - list
+      - watch
+      - create
+      - update
   - apiGroups:
       - ""
     resources:
@@ -4914,6 +4926,7 @@ spec:
           - --enable-arp-detect-ip-conflict=$ENABLE_ARP_DETECT_IP_CONFLICT
           - --encap-checksum=true
           - --service-cluster-ip-range=$SVC_CIDR
+          - --node-switch-cidr=$JOIN_CIDR
           - --iface=${IFACE}
           - --dpdk-tunnel-iface=${DPDK_TUNNEL_IFACE}
           - --network-type=$TUNNEL_TYPE
@@ -11,6 +11,7 @@ package ovs
 
 import (
 	reflect "reflect"
+	time "time"
 
 	v1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	ovs "github.com/kubeovn/kube-ovn/pkg/ovs"
@@ -2201,6 +2202,34 @@ func (m *MockLogicalRouterStaticRoute) EXPECT() *MockLogicalRouterStaticRouteMoc
 	return m.recorder
 }
 
+// AddLogicalRouterBlackholeRoute mocks base method.
+func (m *MockLogicalRouterStaticRoute) AddLogicalRouterBlackholeRoute(lrName, routeTable, ipPrefix string, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLogicalRouterBlackholeRoute", lrName, routeTable, ipPrefix, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterBlackholeRoute indicates an expected call of AddLogicalRouterBlackholeRoute.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) AddLogicalRouterBlackholeRoute(lrName, routeTable, ipPrefix, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterBlackholeRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).AddLogicalRouterBlackholeRoute), lrName, routeTable, ipPrefix, externalIDs)
+}
+
+// AddLogicalRouterConnectedRoute mocks base method.
+func (m *MockLogicalRouterStaticRoute) AddLogicalRouterConnectedRoute(lrName, routeTable, ipPrefix, outputPort, nexthop string, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLogicalRouterConnectedRoute", lrName, routeTable, ipPrefix, outputPort, nexthop, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterConnectedRoute indicates an expected call of AddLogicalRouterConnectedRoute.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) AddLogicalRouterConnectedRoute(lrName, routeTable, ipPrefix, outputPort, nexthop, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterConnectedRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).AddLogicalRouterConnectedRoute), lrName, routeTable, ipPrefix, outputPort, nexthop, externalIDs)
+}
+
 // AddLogicalRouterStaticRoute mocks base method.
 func (m *MockLogicalRouterStaticRoute) AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix string, bfdID *string, externalIDs map[string]string, nexthops ...string) error {
 	m.ctrl.T.Helper()
@@ -2220,20 +2249,83 @@ func (mr *MockLogicalRouterStaticRouteMockRecorder) AddLogicalRouterStaticRoute(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).AddLogicalRouterStaticRoute), varargs...)
 }
 
-// BatchDeleteLogicalRouterStaticRoute mocks base method.
-func (m *MockLogicalRouterStaticRoute) BatchDeleteLogicalRouterStaticRoute(lrName string, staticRoutes []*ovnnb.LogicalRouterStaticRoute) error {
+// AddLogicalRouterStaticRouteAdditive mocks base method.
+func (m *MockLogicalRouterStaticRoute) AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix string, bfdID *string, externalIDs map[string]string, nexthops ...string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BatchDeleteLogicalRouterStaticRoute", lrName, staticRoutes)
+	varargs := []any{lrName, routeTable, policy, ipPrefix, bfdID, externalIDs}
+	for _, a := range nexthops {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddLogicalRouterStaticRouteAdditive", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterStaticRouteAdditive indicates an expected call of AddLogicalRouterStaticRouteAdditive.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix, bfdID, externalIDs any, nexthops ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{lrName, routeTable, policy, ipPrefix, bfdID, externalIDs}, nexthops...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRouteAdditive", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).AddLogicalRouterStaticRouteAdditive), varargs...)
+}
+
+// AddLogicalRouterStaticRouteWithBFDs mocks base method.
+func (m *MockLogicalRouterStaticRoute) AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix string, bfdIDs, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLogicalRouterStaticRouteWithBFDs", lrName, routeTable, policy, ipPrefix, bfdIDs, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterStaticRouteWithBFDs indicates an expected call of AddLogicalRouterStaticRouteWithBFDs.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix, bfdIDs, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRouteWithBFDs", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).AddLogicalRouterStaticRouteWithBFDs), lrName, routeTable, policy, ipPrefix, bfdIDs, externalIDs)
+}
+
+// ApplyDesiredStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) ApplyDesiredStaticRoutes(lrName string, desired []*ovnnb.LogicalRouterStaticRoute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDesiredStaticRoutes", lrName, desired)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// ApplyDesiredStaticRoutes indicates an expected call of ApplyDesiredStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ApplyDesiredStaticRoutes(lrName, desired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDesiredStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ApplyDesiredStaticRoutes), lrName, desired)
+}
+
+// BatchDeleteLogicalRouterStaticRoute mocks base method.
+func (m *MockLogicalRouterStaticRoute) BatchDeleteLogicalRouterStaticRoute(lrName string, staticRoutes []*ovnnb.LogicalRouterStaticRoute) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDeleteLogicalRouterStaticRoute", lrName, staticRoutes)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // BatchDeleteLogicalRouterStaticRoute indicates an expected call of BatchDeleteLogicalRouterStaticRoute.
 func (mr *MockLogicalRouterStaticRouteMockRecorder) BatchDeleteLogicalRouterStaticRoute(lrName, staticRoutes any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteLogicalRouterStaticRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).BatchDeleteLogicalRouterStaticRoute), lrName, staticRoutes)
 }
 
+// CheckRouteTableReachable mocks base method.
+func (m *MockLogicalRouterStaticRoute) CheckRouteTableReachable(lrName, routeTable string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRouteTableReachable", lrName, routeTable)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckRouteTableReachable indicates an expected call of CheckRouteTableReachable.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) CheckRouteTableReachable(lrName, routeTable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRouteTableReachable", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).CheckRouteTableReachable), lrName, routeTable)
+}
+
 // ClearLogicalRouterStaticRoute mocks base method.
 func (m *MockLogicalRouterStaticRoute) ClearLogicalRouterStaticRoute(lrName string) error {
 	m.ctrl.T.Helper()
@@ -2248,6 +2340,39 @@ func (mr *MockLogicalRouterStaticRouteMockRecorder) ClearLogicalRouterStaticRout
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLogicalRouterStaticRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ClearLogicalRouterStaticRoute), lrName)
 }
 
+// ClearLogicalRouterStaticRoutesByPrefix mocks base method.
+func (m *MockLogicalRouterStaticRoute) ClearLogicalRouterStaticRoutesByPrefix(lrName, routeTable, policy, ipPrefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearLogicalRouterStaticRoutesByPrefix", lrName, routeTable, policy, ipPrefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearLogicalRouterStaticRoutesByPrefix indicates an expected call of ClearLogicalRouterStaticRoutesByPrefix.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ClearLogicalRouterStaticRoutesByPrefix(lrName, routeTable, policy, ipPrefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLogicalRouterStaticRoutesByPrefix", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ClearLogicalRouterStaticRoutesByPrefix), lrName, routeTable, policy, ipPrefix)
+}
+
+// CreateLogicalRouterStaticRoute mocks base method.
+func (m *MockLogicalRouterStaticRoute) CreateLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string, bfdID *string, externalIDs map[string]string, options ...ovs.StaticRouteOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{lrName, routeTable, policy, ipPrefix, nexthop, bfdID, externalIDs}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateLogicalRouterStaticRoute", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLogicalRouterStaticRoute indicates an expected call of CreateLogicalRouterStaticRoute.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) CreateLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, bfdID, externalIDs any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{lrName, routeTable, policy, ipPrefix, nexthop, bfdID, externalIDs}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLogicalRouterStaticRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).CreateLogicalRouterStaticRoute), varargs...)
+}
+
 // DeleteLogicalRouterStaticRoute mocks base method.
 func (m *MockLogicalRouterStaticRoute) DeleteLogicalRouterStaticRoute(lrName string, routeTable, policy *string, ipPrefix, nextHop string) error {
 	m.ctrl.T.Helper()
@@ -2290,6 +2415,311 @@ func (mr *MockLogicalRouterStaticRouteMockRecorder) DeleteLogicalRouterStaticRou
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalRouterStaticRouteByUUID", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).DeleteLogicalRouterStaticRouteByUUID), lrName, uuid)
 }
 
+// DeleteLogicalRouterStaticRoutesByOptions mocks base method.
+func (m *MockLogicalRouterStaticRoute) DeleteLogicalRouterStaticRoutesByOptions(lrName string, options map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLogicalRouterStaticRoutesByOptions", lrName, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLogicalRouterStaticRoutesByOptions indicates an expected call of DeleteLogicalRouterStaticRoutesByOptions.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) DeleteLogicalRouterStaticRoutesByOptions(lrName, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalRouterStaticRoutesByOptions", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).DeleteLogicalRouterStaticRoutesByOptions), lrName, options)
+}
+
+// DeleteLogicalRouterStaticRoutesWhere mocks base method.
+func (m *MockLogicalRouterStaticRoute) DeleteLogicalRouterStaticRoutesWhere(lrName string, predicate func(*ovnnb.LogicalRouterStaticRoute) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLogicalRouterStaticRoutesWhere", lrName, predicate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLogicalRouterStaticRoutesWhere indicates an expected call of DeleteLogicalRouterStaticRoutesWhere.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) DeleteLogicalRouterStaticRoutesWhere(lrName, predicate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalRouterStaticRoutesWhere", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).DeleteLogicalRouterStaticRoutesWhere), lrName, predicate)
+}
+
+// DisableStaticRoute mocks base method.
+func (m *MockLogicalRouterStaticRoute) DisableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableStaticRoute", lrName, routeTable, policy, ipPrefix, nexthop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableStaticRoute indicates an expected call of DisableStaticRoute.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) DisableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableStaticRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).DisableStaticRoute), lrName, routeTable, policy, ipPrefix, nexthop)
+}
+
+// EnableBFDForECMPGroup mocks base method.
+func (m *MockLogicalRouterStaticRoute) EnableBFDForECMPGroup(lrName, routeTable, policy, ipPrefix string, nexthopBFD map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableBFDForECMPGroup", lrName, routeTable, policy, ipPrefix, nexthopBFD)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableBFDForECMPGroup indicates an expected call of EnableBFDForECMPGroup.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) EnableBFDForECMPGroup(lrName, routeTable, policy, ipPrefix, nexthopBFD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableBFDForECMPGroup", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).EnableBFDForECMPGroup), lrName, routeTable, policy, ipPrefix, nexthopBFD)
+}
+
+// EnableLogicalRouterCache mocks base method.
+func (m *MockLogicalRouterStaticRoute) EnableLogicalRouterCache(ttl time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnableLogicalRouterCache", ttl)
+}
+
+// EnableLogicalRouterCache indicates an expected call of EnableLogicalRouterCache.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) EnableLogicalRouterCache(ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableLogicalRouterCache", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).EnableLogicalRouterCache), ttl)
+}
+
+// EnableStaticRoute mocks base method.
+func (m *MockLogicalRouterStaticRoute) EnableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableStaticRoute", lrName, routeTable, policy, ipPrefix, nexthop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableStaticRoute indicates an expected call of EnableStaticRoute.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) EnableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableStaticRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).EnableStaticRoute), lrName, routeTable, policy, ipPrefix, nexthop)
+}
+
+// EnsureGatewayNatRoute mocks base method.
+func (m *MockLogicalRouterStaticRoute) EnsureGatewayNatRoute(lrName, subnetName, cidr, nexthop string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureGatewayNatRoute", lrName, subnetName, cidr, nexthop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureGatewayNatRoute indicates an expected call of EnsureGatewayNatRoute.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) EnsureGatewayNatRoute(lrName, subnetName, cidr, nexthop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureGatewayNatRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).EnsureGatewayNatRoute), lrName, subnetName, cidr, nexthop)
+}
+
+// ExportRouterRoutesDOT mocks base method.
+func (m *MockLogicalRouterStaticRoute) ExportRouterRoutesDOT(lrName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportRouterRoutesDOT", lrName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportRouterRoutesDOT indicates an expected call of ExportRouterRoutesDOT.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ExportRouterRoutesDOT(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRouterRoutesDOT", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ExportRouterRoutesDOT), lrName)
+}
+
+// FindConflictingStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) FindConflictingStaticRoutes(lrName string, proposed *ovnnb.LogicalRouterStaticRoute) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindConflictingStaticRoutes", lrName, proposed)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindConflictingStaticRoutes indicates an expected call of FindConflictingStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) FindConflictingStaticRoutes(lrName, proposed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConflictingStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).FindConflictingStaticRoutes), lrName, proposed)
+}
+
+// FindInconsistentBFDRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) FindInconsistentBFDRoutes(lrName string, repair bool) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInconsistentBFDRoutes", lrName, repair)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInconsistentBFDRoutes indicates an expected call of FindInconsistentBFDRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) FindInconsistentBFDRoutes(lrName, repair any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInconsistentBFDRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).FindInconsistentBFDRoutes), lrName, repair)
+}
+
+// FindShadowedStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) FindShadowedStaticRoutes(lrName, routeTable string) ([]ovs.ShadowedStaticRoutes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShadowedStaticRoutes", lrName, routeTable)
+	ret0, _ := ret[0].([]ovs.ShadowedStaticRoutes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShadowedStaticRoutes indicates an expected call of FindShadowedStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) FindShadowedStaticRoutes(lrName, routeTable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShadowedStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).FindShadowedStaticRoutes), lrName, routeTable)
+}
+
+// FindStaticRouteNatConflicts mocks base method.
+func (m *MockLogicalRouterStaticRoute) FindStaticRouteNatConflicts(lrName, tagKey string) ([]ovs.StaticRouteNatConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStaticRouteNatConflicts", lrName, tagKey)
+	ret0, _ := ret[0].([]ovs.StaticRouteNatConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStaticRouteNatConflicts indicates an expected call of FindStaticRouteNatConflicts.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) FindStaticRouteNatConflicts(lrName, tagKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStaticRouteNatConflicts", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).FindStaticRouteNatConflicts), lrName, tagKey)
+}
+
+// FormatLogicalRouterStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) FormatLogicalRouterStaticRoutes(lrName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FormatLogicalRouterStaticRoutes", lrName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FormatLogicalRouterStaticRoutes indicates an expected call of FormatLogicalRouterStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) FormatLogicalRouterStaticRoutes(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatLogicalRouterStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).FormatLogicalRouterStaticRoutes), lrName)
+}
+
+// GarbageCollectDanglingBFDRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) GarbageCollectDanglingBFDRoutes(lrName string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GarbageCollectDanglingBFDRoutes", lrName)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GarbageCollectDanglingBFDRoutes indicates an expected call of GarbageCollectDanglingBFDRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) GarbageCollectDanglingBFDRoutes(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GarbageCollectDanglingBFDRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).GarbageCollectDanglingBFDRoutes), lrName)
+}
+
+// GetECMPWidths mocks base method.
+func (m *MockLogicalRouterStaticRoute) GetECMPWidths(lrName, routeTable string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetECMPWidths", lrName, routeTable)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetECMPWidths indicates an expected call of GetECMPWidths.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) GetECMPWidths(lrName, routeTable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetECMPWidths", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).GetECMPWidths), lrName, routeTable)
+}
+
+// GetLogicalRouterStaticRoutesByNexthop mocks base method.
+func (m *MockLogicalRouterStaticRoute) GetLogicalRouterStaticRoutesByNexthop(lrName string) (map[string][]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogicalRouterStaticRoutesByNexthop", lrName)
+	ret0, _ := ret[0].(map[string][]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogicalRouterStaticRoutesByNexthop indicates an expected call of GetLogicalRouterStaticRoutesByNexthop.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) GetLogicalRouterStaticRoutesByNexthop(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogicalRouterStaticRoutesByNexthop", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).GetLogicalRouterStaticRoutesByNexthop), lrName)
+}
+
+// GetRouterRoutingSnapshot mocks base method.
+func (m *MockLogicalRouterStaticRoute) GetRouterRoutingSnapshot(lrName string) ([]ovs.StaticRouteSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRouterRoutingSnapshot", lrName)
+	ret0, _ := ret[0].([]ovs.StaticRouteSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRouterRoutingSnapshot indicates an expected call of GetRouterRoutingSnapshot.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) GetRouterRoutingSnapshot(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouterRoutingSnapshot", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).GetRouterRoutingSnapshot), lrName)
+}
+
+// ImportLogicalRouterStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) ImportLogicalRouterStaticRoutes(lrName string, routes []*ovnnb.LogicalRouterStaticRoute, strategy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLogicalRouterStaticRoutes", lrName, routes, strategy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportLogicalRouterStaticRoutes indicates an expected call of ImportLogicalRouterStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ImportLogicalRouterStaticRoutes(lrName, routes, strategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLogicalRouterStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ImportLogicalRouterStaticRoutes), lrName, routes, strategy)
+}
+
+// ListAllLogicalRouterStaticRoutesSorted mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListAllLogicalRouterStaticRoutesSorted(lrName string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllLogicalRouterStaticRoutesSorted", lrName)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllLogicalRouterStaticRoutesSorted indicates an expected call of ListAllLogicalRouterStaticRoutesSorted.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListAllLogicalRouterStaticRoutesSorted(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllLogicalRouterStaticRoutesSorted", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListAllLogicalRouterStaticRoutesSorted), lrName)
+}
+
+// ListAllStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListAllStaticRoutes() ([]ovs.RouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllStaticRoutes")
+	ret0, _ := ret[0].([]ovs.RouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllStaticRoutes indicates an expected call of ListAllStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListAllStaticRoutes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListAllStaticRoutes))
+}
+
+// ListLogicalRouterStaticRouteRefs mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRouteRefs(lrName string) ([]ovs.StaticRouteRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRouteRefs", lrName)
+	ret0, _ := ret[0].([]ovs.StaticRouteRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRouteRefs indicates an expected call of ListLogicalRouterStaticRouteRefs.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRouteRefs(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRouteRefs", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRouteRefs), lrName)
+}
+
 // ListLogicalRouterStaticRoutes mocks base method.
 func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutes(lrName string, routeTable, policy *string, ipPrefix string, externalIDs map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
 	m.ctrl.T.Helper()
@@ -2302,37 +2732,327 @@ func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutes(lrName stri
 // ListLogicalRouterStaticRoutes indicates an expected call of ListLogicalRouterStaticRoutes.
 func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutes(lrName, routeTable, policy, ipPrefix, externalIDs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutes), lrName, routeTable, policy, ipPrefix, externalIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutes), lrName, routeTable, policy, ipPrefix, externalIDs)
+}
+
+// ListLogicalRouterStaticRoutesByBFDState mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesByBFDState(lrName, state string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByBFDState", lrName, state)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByBFDState indicates an expected call of ListLogicalRouterStaticRoutesByBFDState.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesByBFDState(lrName, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByBFDState", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesByBFDState), lrName, state)
+}
+
+// ListLogicalRouterStaticRoutesByECMPHashMode mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesByECMPHashMode(lrName, mode string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByECMPHashMode", lrName, mode)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByECMPHashMode indicates an expected call of ListLogicalRouterStaticRoutesByECMPHashMode.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesByECMPHashMode(lrName, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByECMPHashMode", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesByECMPHashMode), lrName, mode)
+}
+
+// ListLogicalRouterStaticRoutesByExternalIDsAndOptions mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesByExternalIDsAndOptions(lrName string, externalIDs, options map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByExternalIDsAndOptions", lrName, externalIDs, options)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByExternalIDsAndOptions indicates an expected call of ListLogicalRouterStaticRoutesByExternalIDsAndOptions.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesByExternalIDsAndOptions(lrName, externalIDs, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByExternalIDsAndOptions", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesByExternalIDsAndOptions), lrName, externalIDs, options)
+}
+
+// ListLogicalRouterStaticRoutesByOption mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesByOption(lrName, routeTable, key, value string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByOption", lrName, routeTable, key, value)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByOption indicates an expected call of ListLogicalRouterStaticRoutesByOption.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesByOption(lrName, routeTable, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByOption", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesByOption), lrName, routeTable, key, value)
+}
+
+// ListLogicalRouterStaticRoutesByOutputPort mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesByOutputPort(lrName, outputPort string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByOutputPort", lrName, outputPort)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByOutputPort indicates an expected call of ListLogicalRouterStaticRoutesByOutputPort.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesByOutputPort(lrName, outputPort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByOutputPort", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesByOutputPort), lrName, outputPort)
+}
+
+// ListLogicalRouterStaticRoutesChangedSince mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesChangedSince(lrName string, version uint64) ([]*ovnnb.LogicalRouterStaticRoute, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesChangedSince", lrName, version)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLogicalRouterStaticRoutesChangedSince indicates an expected call of ListLogicalRouterStaticRoutesChangedSince.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesChangedSince(lrName, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesChangedSince", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesChangedSince), lrName, version)
+}
+
+// ListLogicalRouterStaticRoutesInPrefixRange mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesInPrefixRange(lrName, startCIDR, endCIDR string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesInPrefixRange", lrName, startCIDR, endCIDR)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesInPrefixRange indicates an expected call of ListLogicalRouterStaticRoutesInPrefixRange.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesInPrefixRange(lrName, startCIDR, endCIDR any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesInPrefixRange", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesInPrefixRange), lrName, startCIDR, endCIDR)
+}
+
+// ListLogicalRouterStaticRoutesOverlapping mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesOverlapping(lrName, cidr string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesOverlapping", lrName, cidr)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesOverlapping indicates an expected call of ListLogicalRouterStaticRoutesOverlapping.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesOverlapping(lrName, cidr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesOverlapping", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesOverlapping), lrName, cidr)
+}
+
+// ListUnmanagedStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) ListUnmanagedStaticRoutes(lrName, ownerKey string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnmanagedStaticRoutes", lrName, ownerKey)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnmanagedStaticRoutes indicates an expected call of ListUnmanagedStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ListUnmanagedStaticRoutes(lrName, ownerKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnmanagedStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListUnmanagedStaticRoutes), lrName, ownerKey)
+}
+
+// LogicalRouterStaticRouteExists mocks base method.
+func (m *MockLogicalRouterStaticRoute) LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogicalRouterStaticRouteExists", lrName, routeTable, policy, ipPrefix, nexthop)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogicalRouterStaticRouteExists indicates an expected call of LogicalRouterStaticRouteExists.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogicalRouterStaticRouteExists", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).LogicalRouterStaticRouteExists), lrName, routeTable, policy, ipPrefix, nexthop)
+}
+
+// MonitorStaticRouteVersions mocks base method.
+func (m *MockLogicalRouterStaticRoute) MonitorStaticRouteVersions() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MonitorStaticRouteVersions")
+}
+
+// MonitorStaticRouteVersions indicates an expected call of MonitorStaticRouteVersions.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) MonitorStaticRouteVersions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonitorStaticRouteVersions", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).MonitorStaticRouteVersions))
+}
+
+// PruneStaticRoutesByGeneration mocks base method.
+func (m *MockLogicalRouterStaticRoute) PruneStaticRoutesByGeneration(lrName, generationKey, currentGeneration string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneStaticRoutesByGeneration", lrName, generationKey, currentGeneration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PruneStaticRoutesByGeneration indicates an expected call of PruneStaticRoutesByGeneration.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) PruneStaticRoutesByGeneration(lrName, generationKey, currentGeneration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneStaticRoutesByGeneration", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).PruneStaticRoutesByGeneration), lrName, generationKey, currentGeneration)
+}
+
+// ReconcileRoutersStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) ReconcileRoutersStaticRoutes(specs map[string][]*ovnnb.LogicalRouterStaticRoute) (map[string]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileRoutersStaticRoutes", specs)
+	ret0, _ := ret[0].(map[string]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileRoutersStaticRoutes indicates an expected call of ReconcileRoutersStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ReconcileRoutersStaticRoutes(specs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileRoutersStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ReconcileRoutersStaticRoutes), specs)
+}
+
+// ReconcileStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) ReconcileStaticRoutes(lrName string, desired []*ovnnb.LogicalRouterStaticRoute, opts ovs.ReconcileOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileStaticRoutes", lrName, desired, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileStaticRoutes indicates an expected call of ReconcileStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ReconcileStaticRoutes(lrName, desired, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ReconcileStaticRoutes), lrName, desired, opts)
+}
+
+// RefreshHostnameRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) RefreshHostnameRoutes(lrName, hostnameKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshHostnameRoutes", lrName, hostnameKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshHostnameRoutes indicates an expected call of RefreshHostnameRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) RefreshHostnameRoutes(lrName, hostnameKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshHostnameRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).RefreshHostnameRoutes), lrName, hostnameKey)
+}
+
+// ReplaceGatewayNode mocks base method.
+func (m *MockLogicalRouterStaticRoute) ReplaceGatewayNode(lrName, oldIP, newIP string, newBFD map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceGatewayNode", lrName, oldIP, newIP, newBFD)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceGatewayNode indicates an expected call of ReplaceGatewayNode.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ReplaceGatewayNode(lrName, oldIP, newIP, newBFD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceGatewayNode", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ReplaceGatewayNode), lrName, oldIP, newIP, newBFD)
+}
+
+// ReportUnreachableRouteTables mocks base method.
+func (m *MockLogicalRouterStaticRoute) ReportUnreachableRouteTables(lrName string) ([]ovs.UnreachableRouteTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportUnreachableRouteTables", lrName)
+	ret0, _ := ret[0].([]ovs.UnreachableRouteTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportUnreachableRouteTables indicates an expected call of ReportUnreachableRouteTables.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ReportUnreachableRouteTables(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportUnreachableRouteTables", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ReportUnreachableRouteTables), lrName)
+}
+
+// SetECMPHashMode mocks base method.
+func (m *MockLogicalRouterStaticRoute) SetECMPHashMode(lrName, routeTable, policy, ipPrefix, mode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetECMPHashMode", lrName, routeTable, policy, ipPrefix, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetECMPHashMode indicates an expected call of SetECMPHashMode.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) SetECMPHashMode(lrName, routeTable, policy, ipPrefix, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetECMPHashMode", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).SetECMPHashMode), lrName, routeTable, policy, ipPrefix, mode)
+}
+
+// StaticRouteVersion mocks base method.
+func (m *MockLogicalRouterStaticRoute) StaticRouteVersion() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaticRouteVersion")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StaticRouteVersion indicates an expected call of StaticRouteVersion.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) StaticRouteVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaticRouteVersion", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).StaticRouteVersion))
+}
+
+// SummarizeStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) SummarizeStaticRoutes(lrName, routeTable, nexthop, aggregatePrefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeStaticRoutes", lrName, routeTable, nexthop, aggregatePrefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SummarizeStaticRoutes indicates an expected call of SummarizeStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) SummarizeStaticRoutes(lrName, routeTable, nexthop, aggregatePrefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).SummarizeStaticRoutes), lrName, routeTable, nexthop, aggregatePrefix)
 }
 
-// ListLogicalRouterStaticRoutesByOption mocks base method.
-func (m *MockLogicalRouterStaticRoute) ListLogicalRouterStaticRoutesByOption(lrName, routeTable, key, value string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+// SweepExpiredStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) SweepExpiredStaticRoutes(lrName, expiryKey string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByOption", lrName, routeTable, key, value)
-	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SweepExpiredStaticRoutes", lrName, expiryKey)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListLogicalRouterStaticRoutesByOption indicates an expected call of ListLogicalRouterStaticRoutesByOption.
-func (mr *MockLogicalRouterStaticRouteMockRecorder) ListLogicalRouterStaticRoutesByOption(lrName, routeTable, key, value any) *gomock.Call {
+// SweepExpiredStaticRoutes indicates an expected call of SweepExpiredStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) SweepExpiredStaticRoutes(lrName, expiryKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByOption", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ListLogicalRouterStaticRoutesByOption), lrName, routeTable, key, value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpiredStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).SweepExpiredStaticRoutes), lrName, expiryKey)
 }
 
-// LogicalRouterStaticRouteExists mocks base method.
-func (m *MockLogicalRouterStaticRoute) LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop string) (bool, error) {
+// TransactRoutersStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) TransactRoutersStaticRoutes(changes map[string]ovs.RouterStaticRouteChanges) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LogicalRouterStaticRouteExists", lrName, routeTable, policy, ipPrefix, nexthop)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "TransactRoutersStaticRoutes", changes)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// LogicalRouterStaticRouteExists indicates an expected call of LogicalRouterStaticRouteExists.
-func (mr *MockLogicalRouterStaticRouteMockRecorder) LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop any) *gomock.Call {
+// TransactRoutersStaticRoutes indicates an expected call of TransactRoutersStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) TransactRoutersStaticRoutes(changes any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogicalRouterStaticRouteExists", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).LogicalRouterStaticRouteExists), lrName, routeTable, policy, ipPrefix, nexthop)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactRoutersStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).TransactRoutersStaticRoutes), changes)
 }
 
 // UpdateLogicalRouterStaticRoute mocks base method.
@@ -2354,6 +3074,54 @@ func (mr *MockLogicalRouterStaticRouteMockRecorder) UpdateLogicalRouterStaticRou
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogicalRouterStaticRoute", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).UpdateLogicalRouterStaticRoute), varargs...)
 }
 
+// UpdateLogicalRouterStaticRouteForceRecreate mocks base method.
+func (m *MockLogicalRouterStaticRoute) UpdateLogicalRouterStaticRouteForceRecreate(lrName string, route *ovnnb.LogicalRouterStaticRoute, fields ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{lrName, route}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateLogicalRouterStaticRouteForceRecreate", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLogicalRouterStaticRouteForceRecreate indicates an expected call of UpdateLogicalRouterStaticRouteForceRecreate.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) UpdateLogicalRouterStaticRouteForceRecreate(lrName, route any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{lrName, route}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogicalRouterStaticRouteForceRecreate", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).UpdateLogicalRouterStaticRouteForceRecreate), varargs...)
+}
+
+// ValidateStaticRoutesBatch mocks base method.
+func (m *MockLogicalRouterStaticRoute) ValidateStaticRoutesBatch(lrName string, routes []*ovnnb.LogicalRouterStaticRoute) (map[int]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateStaticRoutesBatch", lrName, routes)
+	ret0, _ := ret[0].(map[int]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateStaticRoutesBatch indicates an expected call of ValidateStaticRoutesBatch.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) ValidateStaticRoutesBatch(lrName, routes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateStaticRoutesBatch", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).ValidateStaticRoutesBatch), lrName, routes)
+}
+
+// WalkAllStaticRoutes mocks base method.
+func (m *MockLogicalRouterStaticRoute) WalkAllStaticRoutes(fn func(ovs.RouterStaticRoute) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalkAllStaticRoutes", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalkAllStaticRoutes indicates an expected call of WalkAllStaticRoutes.
+func (mr *MockLogicalRouterStaticRouteMockRecorder) WalkAllStaticRoutes(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkAllStaticRoutes", reflect.TypeOf((*MockLogicalRouterStaticRoute)(nil).WalkAllStaticRoutes), fn)
+}
+
 // MockLogicalRouterPolicy is a mock of LogicalRouterPolicy interface.
 type MockLogicalRouterPolicy struct {
 	ctrl     *gomock.Controller
@@ -2841,6 +3609,34 @@ func (mr *MockNbClientMockRecorder) AddLoadBalancerHealthCheck(lbName, vip, exte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoadBalancerHealthCheck", reflect.TypeOf((*MockNbClient)(nil).AddLoadBalancerHealthCheck), lbName, vip, externals)
 }
 
+// AddLogicalRouterBlackholeRoute mocks base method.
+func (m *MockNbClient) AddLogicalRouterBlackholeRoute(lrName, routeTable, ipPrefix string, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLogicalRouterBlackholeRoute", lrName, routeTable, ipPrefix, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterBlackholeRoute indicates an expected call of AddLogicalRouterBlackholeRoute.
+func (mr *MockNbClientMockRecorder) AddLogicalRouterBlackholeRoute(lrName, routeTable, ipPrefix, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterBlackholeRoute", reflect.TypeOf((*MockNbClient)(nil).AddLogicalRouterBlackholeRoute), lrName, routeTable, ipPrefix, externalIDs)
+}
+
+// AddLogicalRouterConnectedRoute mocks base method.
+func (m *MockNbClient) AddLogicalRouterConnectedRoute(lrName, routeTable, ipPrefix, outputPort, nexthop string, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLogicalRouterConnectedRoute", lrName, routeTable, ipPrefix, outputPort, nexthop, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterConnectedRoute indicates an expected call of AddLogicalRouterConnectedRoute.
+func (mr *MockNbClientMockRecorder) AddLogicalRouterConnectedRoute(lrName, routeTable, ipPrefix, outputPort, nexthop, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterConnectedRoute", reflect.TypeOf((*MockNbClient)(nil).AddLogicalRouterConnectedRoute), lrName, routeTable, ipPrefix, outputPort, nexthop, externalIDs)
+}
+
 // AddLogicalRouterPolicy mocks base method.
 func (m *MockNbClient) AddLogicalRouterPolicy(lrName string, priority int, match, action string, nextHops, bfdSessions []string, externalIDs map[string]string) error {
 	m.ctrl.T.Helper()
@@ -2874,6 +3670,39 @@ func (mr *MockNbClientMockRecorder) AddLogicalRouterStaticRoute(lrName, routeTab
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRoute", reflect.TypeOf((*MockNbClient)(nil).AddLogicalRouterStaticRoute), varargs...)
 }
 
+// AddLogicalRouterStaticRouteAdditive mocks base method.
+func (m *MockNbClient) AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix string, bfdID *string, externalIDs map[string]string, nexthops ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{lrName, routeTable, policy, ipPrefix, bfdID, externalIDs}
+	for _, a := range nexthops {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddLogicalRouterStaticRouteAdditive", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterStaticRouteAdditive indicates an expected call of AddLogicalRouterStaticRouteAdditive.
+func (mr *MockNbClientMockRecorder) AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix, bfdID, externalIDs any, nexthops ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{lrName, routeTable, policy, ipPrefix, bfdID, externalIDs}, nexthops...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRouteAdditive", reflect.TypeOf((*MockNbClient)(nil).AddLogicalRouterStaticRouteAdditive), varargs...)
+}
+
+// AddLogicalRouterStaticRouteWithBFDs mocks base method.
+func (m *MockNbClient) AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix string, bfdIDs, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLogicalRouterStaticRouteWithBFDs", lrName, routeTable, policy, ipPrefix, bfdIDs, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLogicalRouterStaticRouteWithBFDs indicates an expected call of AddLogicalRouterStaticRouteWithBFDs.
+func (mr *MockNbClientMockRecorder) AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix, bfdIDs, externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLogicalRouterStaticRouteWithBFDs", reflect.TypeOf((*MockNbClient)(nil).AddLogicalRouterStaticRouteWithBFDs), lrName, routeTable, policy, ipPrefix, bfdIDs, externalIDs)
+}
+
 // AddNat mocks base method.
 func (m *MockNbClient) AddNat(lrName, natType, externalIP, logicalIP, logicalMac, port string, options map[string]string) error {
 	m.ctrl.T.Helper()
@@ -2907,6 +3736,20 @@ func (mr *MockNbClientMockRecorder) AddressSetUpdateAddress(asName any, addresse
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddressSetUpdateAddress", reflect.TypeOf((*MockNbClient)(nil).AddressSetUpdateAddress), varargs...)
 }
 
+// ApplyDesiredStaticRoutes mocks base method.
+func (m *MockNbClient) ApplyDesiredStaticRoutes(lrName string, desired []*ovnnb.LogicalRouterStaticRoute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDesiredStaticRoutes", lrName, desired)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyDesiredStaticRoutes indicates an expected call of ApplyDesiredStaticRoutes.
+func (mr *MockNbClientMockRecorder) ApplyDesiredStaticRoutes(lrName, desired any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDesiredStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).ApplyDesiredStaticRoutes), lrName, desired)
+}
+
 // BatchAddLogicalRouterPolicy mocks base method.
 func (m *MockNbClient) BatchAddLogicalRouterPolicy(lrName string, policies ...*ovnnb.LogicalRouterPolicy) error {
 	m.ctrl.T.Helper()
@@ -2974,11 +3817,12 @@ func (mr *MockNbClientMockRecorder) BatchDeleteLogicalRouterPolicyByUUID(lrName
 }
 
 // BatchDeleteLogicalRouterStaticRoute mocks base method.
-func (m *MockNbClient) BatchDeleteLogicalRouterStaticRoute(lrName string, staticRoutes []*ovnnb.LogicalRouterStaticRoute) error {
+func (m *MockNbClient) BatchDeleteLogicalRouterStaticRoute(lrName string, staticRoutes []*ovnnb.LogicalRouterStaticRoute) ([]*ovnnb.LogicalRouterStaticRoute, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "BatchDeleteLogicalRouterStaticRoute", lrName, staticRoutes)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BatchDeleteLogicalRouterStaticRoute indicates an expected call of BatchDeleteLogicalRouterStaticRoute.
@@ -2987,6 +3831,21 @@ func (mr *MockNbClientMockRecorder) BatchDeleteLogicalRouterStaticRoute(lrName,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteLogicalRouterStaticRoute", reflect.TypeOf((*MockNbClient)(nil).BatchDeleteLogicalRouterStaticRoute), lrName, staticRoutes)
 }
 
+// CheckRouteTableReachable mocks base method.
+func (m *MockNbClient) CheckRouteTableReachable(lrName, routeTable string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRouteTableReachable", lrName, routeTable)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckRouteTableReachable indicates an expected call of CheckRouteTableReachable.
+func (mr *MockNbClientMockRecorder) CheckRouteTableReachable(lrName, routeTable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRouteTableReachable", reflect.TypeOf((*MockNbClient)(nil).CheckRouteTableReachable), lrName, routeTable)
+}
+
 // CleanLogicalSwitchPortMigrateOptions mocks base method.
 func (m *MockNbClient) CleanLogicalSwitchPortMigrateOptions(lspName string) error {
 	m.ctrl.T.Helper()
@@ -3029,6 +3888,20 @@ func (mr *MockNbClientMockRecorder) ClearLogicalRouterStaticRoute(lrName any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLogicalRouterStaticRoute", reflect.TypeOf((*MockNbClient)(nil).ClearLogicalRouterStaticRoute), lrName)
 }
 
+// ClearLogicalRouterStaticRoutesByPrefix mocks base method.
+func (m *MockNbClient) ClearLogicalRouterStaticRoutesByPrefix(lrName, routeTable, policy, ipPrefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearLogicalRouterStaticRoutesByPrefix", lrName, routeTable, policy, ipPrefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearLogicalRouterStaticRoutesByPrefix indicates an expected call of ClearLogicalRouterStaticRoutesByPrefix.
+func (mr *MockNbClientMockRecorder) ClearLogicalRouterStaticRoutesByPrefix(lrName, routeTable, policy, ipPrefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearLogicalRouterStaticRoutesByPrefix", reflect.TypeOf((*MockNbClient)(nil).ClearLogicalRouterStaticRoutesByPrefix), lrName, routeTable, policy, ipPrefix)
+}
+
 // CreateAddressSet mocks base method.
 func (m *MockNbClient) CreateAddressSet(asName string, externalIDs map[string]string) error {
 	m.ctrl.T.Helper()
@@ -3222,6 +4095,25 @@ func (mr *MockNbClientMockRecorder) CreateLogicalRouterPort(lrName, lrpName, mac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLogicalRouterPort", reflect.TypeOf((*MockNbClient)(nil).CreateLogicalRouterPort), lrName, lrpName, mac, networks)
 }
 
+// CreateLogicalRouterStaticRoute mocks base method.
+func (m *MockNbClient) CreateLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string, bfdID *string, externalIDs map[string]string, options ...ovs.StaticRouteOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{lrName, routeTable, policy, ipPrefix, nexthop, bfdID, externalIDs}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateLogicalRouterStaticRoute", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLogicalRouterStaticRoute indicates an expected call of CreateLogicalRouterStaticRoute.
+func (mr *MockNbClientMockRecorder) CreateLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, bfdID, externalIDs any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{lrName, routeTable, policy, ipPrefix, nexthop, bfdID, externalIDs}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLogicalRouterStaticRoute", reflect.TypeOf((*MockNbClient)(nil).CreateLogicalRouterStaticRoute), varargs...)
+}
+
 // CreateLogicalSwitch mocks base method.
 func (m *MockNbClient) CreateLogicalSwitch(lsName, lrName, cidrBlock, gateway, gatewayMAC string, needRouter, randomAllocateGW bool) error {
 	m.ctrl.T.Helper()
@@ -3684,6 +4576,34 @@ func (mr *MockNbClientMockRecorder) DeleteLogicalRouterStaticRouteByUUID(lrName,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalRouterStaticRouteByUUID", reflect.TypeOf((*MockNbClient)(nil).DeleteLogicalRouterStaticRouteByUUID), lrName, uuid)
 }
 
+// DeleteLogicalRouterStaticRoutesByOptions mocks base method.
+func (m *MockNbClient) DeleteLogicalRouterStaticRoutesByOptions(lrName string, options map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLogicalRouterStaticRoutesByOptions", lrName, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLogicalRouterStaticRoutesByOptions indicates an expected call of DeleteLogicalRouterStaticRoutesByOptions.
+func (mr *MockNbClientMockRecorder) DeleteLogicalRouterStaticRoutesByOptions(lrName, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalRouterStaticRoutesByOptions", reflect.TypeOf((*MockNbClient)(nil).DeleteLogicalRouterStaticRoutesByOptions), lrName, options)
+}
+
+// DeleteLogicalRouterStaticRoutesWhere mocks base method.
+func (m *MockNbClient) DeleteLogicalRouterStaticRoutesWhere(lrName string, predicate func(*ovnnb.LogicalRouterStaticRoute) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLogicalRouterStaticRoutesWhere", lrName, predicate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLogicalRouterStaticRoutesWhere indicates an expected call of DeleteLogicalRouterStaticRoutesWhere.
+func (mr *MockNbClientMockRecorder) DeleteLogicalRouterStaticRoutesWhere(lrName, predicate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalRouterStaticRoutesWhere", reflect.TypeOf((*MockNbClient)(nil).DeleteLogicalRouterStaticRoutesWhere), lrName, predicate)
+}
+
 // DeleteLogicalSwitch mocks base method.
 func (m *MockNbClient) DeleteLogicalSwitch(lsName string) error {
 	m.ctrl.T.Helper()
@@ -3786,6 +4706,46 @@ func (mr *MockNbClientMockRecorder) DeleteSecurityGroup(sgName any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockNbClient)(nil).DeleteSecurityGroup), sgName)
 }
 
+// DisableStaticRoute mocks base method.
+func (m *MockNbClient) DisableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableStaticRoute", lrName, routeTable, policy, ipPrefix, nexthop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableStaticRoute indicates an expected call of DisableStaticRoute.
+func (mr *MockNbClientMockRecorder) DisableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableStaticRoute", reflect.TypeOf((*MockNbClient)(nil).DisableStaticRoute), lrName, routeTable, policy, ipPrefix, nexthop)
+}
+
+// EnableBFDForECMPGroup mocks base method.
+func (m *MockNbClient) EnableBFDForECMPGroup(lrName, routeTable, policy, ipPrefix string, nexthopBFD map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableBFDForECMPGroup", lrName, routeTable, policy, ipPrefix, nexthopBFD)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableBFDForECMPGroup indicates an expected call of EnableBFDForECMPGroup.
+func (mr *MockNbClientMockRecorder) EnableBFDForECMPGroup(lrName, routeTable, policy, ipPrefix, nexthopBFD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableBFDForECMPGroup", reflect.TypeOf((*MockNbClient)(nil).EnableBFDForECMPGroup), lrName, routeTable, policy, ipPrefix, nexthopBFD)
+}
+
+// EnableLogicalRouterCache mocks base method.
+func (m *MockNbClient) EnableLogicalRouterCache(ttl time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnableLogicalRouterCache", ttl)
+}
+
+// EnableLogicalRouterCache indicates an expected call of EnableLogicalRouterCache.
+func (mr *MockNbClientMockRecorder) EnableLogicalRouterCache(ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableLogicalRouterCache", reflect.TypeOf((*MockNbClient)(nil).EnableLogicalRouterCache), ttl)
+}
+
 // EnablePortLayer2forward mocks base method.
 func (m *MockNbClient) EnablePortLayer2forward(lspName string) error {
 	m.ctrl.T.Helper()
@@ -3794,25 +4754,173 @@ func (m *MockNbClient) EnablePortLayer2forward(lspName string) error {
 	return ret0
 }
 
-// EnablePortLayer2forward indicates an expected call of EnablePortLayer2forward.
-func (mr *MockNbClientMockRecorder) EnablePortLayer2forward(lspName any) *gomock.Call {
+// EnablePortLayer2forward indicates an expected call of EnablePortLayer2forward.
+func (mr *MockNbClientMockRecorder) EnablePortLayer2forward(lspName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePortLayer2forward", reflect.TypeOf((*MockNbClient)(nil).EnablePortLayer2forward), lspName)
+}
+
+// EnableStaticRoute mocks base method.
+func (m *MockNbClient) EnableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableStaticRoute", lrName, routeTable, policy, ipPrefix, nexthop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableStaticRoute indicates an expected call of EnableStaticRoute.
+func (mr *MockNbClientMockRecorder) EnableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableStaticRoute", reflect.TypeOf((*MockNbClient)(nil).EnableStaticRoute), lrName, routeTable, policy, ipPrefix, nexthop)
+}
+
+// EnsureGatewayNatRoute mocks base method.
+func (m *MockNbClient) EnsureGatewayNatRoute(lrName, subnetName, cidr, nexthop string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureGatewayNatRoute", lrName, subnetName, cidr, nexthop)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureGatewayNatRoute indicates an expected call of EnsureGatewayNatRoute.
+func (mr *MockNbClientMockRecorder) EnsureGatewayNatRoute(lrName, subnetName, cidr, nexthop any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureGatewayNatRoute", reflect.TypeOf((*MockNbClient)(nil).EnsureGatewayNatRoute), lrName, subnetName, cidr, nexthop)
+}
+
+// ExportRouterRoutesDOT mocks base method.
+func (m *MockNbClient) ExportRouterRoutesDOT(lrName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportRouterRoutesDOT", lrName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportRouterRoutesDOT indicates an expected call of ExportRouterRoutesDOT.
+func (mr *MockNbClientMockRecorder) ExportRouterRoutesDOT(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRouterRoutesDOT", reflect.TypeOf((*MockNbClient)(nil).ExportRouterRoutesDOT), lrName)
+}
+
+// FindBFD mocks base method.
+func (m *MockNbClient) FindBFD(externalIDs map[string]string) ([]ovnnb.BFD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBFD", externalIDs)
+	ret0, _ := ret[0].([]ovnnb.BFD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBFD indicates an expected call of FindBFD.
+func (mr *MockNbClientMockRecorder) FindBFD(externalIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBFD", reflect.TypeOf((*MockNbClient)(nil).FindBFD), externalIDs)
+}
+
+// FindConflictingStaticRoutes mocks base method.
+func (m *MockNbClient) FindConflictingStaticRoutes(lrName string, proposed *ovnnb.LogicalRouterStaticRoute) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindConflictingStaticRoutes", lrName, proposed)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindConflictingStaticRoutes indicates an expected call of FindConflictingStaticRoutes.
+func (mr *MockNbClientMockRecorder) FindConflictingStaticRoutes(lrName, proposed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConflictingStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).FindConflictingStaticRoutes), lrName, proposed)
+}
+
+// FindInconsistentBFDRoutes mocks base method.
+func (m *MockNbClient) FindInconsistentBFDRoutes(lrName string, repair bool) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInconsistentBFDRoutes", lrName, repair)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInconsistentBFDRoutes indicates an expected call of FindInconsistentBFDRoutes.
+func (mr *MockNbClientMockRecorder) FindInconsistentBFDRoutes(lrName, repair any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInconsistentBFDRoutes", reflect.TypeOf((*MockNbClient)(nil).FindInconsistentBFDRoutes), lrName, repair)
+}
+
+// FindShadowedStaticRoutes mocks base method.
+func (m *MockNbClient) FindShadowedStaticRoutes(lrName, routeTable string) ([]ovs.ShadowedStaticRoutes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShadowedStaticRoutes", lrName, routeTable)
+	ret0, _ := ret[0].([]ovs.ShadowedStaticRoutes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShadowedStaticRoutes indicates an expected call of FindShadowedStaticRoutes.
+func (mr *MockNbClientMockRecorder) FindShadowedStaticRoutes(lrName, routeTable any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShadowedStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).FindShadowedStaticRoutes), lrName, routeTable)
+}
+
+// FindStaticRouteNatConflicts mocks base method.
+func (m *MockNbClient) FindStaticRouteNatConflicts(lrName, tagKey string) ([]ovs.StaticRouteNatConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStaticRouteNatConflicts", lrName, tagKey)
+	ret0, _ := ret[0].([]ovs.StaticRouteNatConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStaticRouteNatConflicts indicates an expected call of FindStaticRouteNatConflicts.
+func (mr *MockNbClientMockRecorder) FindStaticRouteNatConflicts(lrName, tagKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStaticRouteNatConflicts", reflect.TypeOf((*MockNbClient)(nil).FindStaticRouteNatConflicts), lrName, tagKey)
+}
+
+// FormatLogicalRouterStaticRoutes mocks base method.
+func (m *MockNbClient) FormatLogicalRouterStaticRoutes(lrName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FormatLogicalRouterStaticRoutes", lrName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FormatLogicalRouterStaticRoutes indicates an expected call of FormatLogicalRouterStaticRoutes.
+func (mr *MockNbClientMockRecorder) FormatLogicalRouterStaticRoutes(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatLogicalRouterStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).FormatLogicalRouterStaticRoutes), lrName)
+}
+
+// GarbageCollectDanglingBFDRoutes mocks base method.
+func (m *MockNbClient) GarbageCollectDanglingBFDRoutes(lrName string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GarbageCollectDanglingBFDRoutes", lrName)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GarbageCollectDanglingBFDRoutes indicates an expected call of GarbageCollectDanglingBFDRoutes.
+func (mr *MockNbClientMockRecorder) GarbageCollectDanglingBFDRoutes(lrName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePortLayer2forward", reflect.TypeOf((*MockNbClient)(nil).EnablePortLayer2forward), lspName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GarbageCollectDanglingBFDRoutes", reflect.TypeOf((*MockNbClient)(nil).GarbageCollectDanglingBFDRoutes), lrName)
 }
 
-// FindBFD mocks base method.
-func (m *MockNbClient) FindBFD(externalIDs map[string]string) ([]ovnnb.BFD, error) {
+// GetECMPWidths mocks base method.
+func (m *MockNbClient) GetECMPWidths(lrName, routeTable string) (map[string]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindBFD", externalIDs)
-	ret0, _ := ret[0].([]ovnnb.BFD)
+	ret := m.ctrl.Call(m, "GetECMPWidths", lrName, routeTable)
+	ret0, _ := ret[0].(map[string]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindBFD indicates an expected call of FindBFD.
-func (mr *MockNbClientMockRecorder) FindBFD(externalIDs any) *gomock.Call {
+// GetECMPWidths indicates an expected call of GetECMPWidths.
+func (mr *MockNbClientMockRecorder) GetECMPWidths(lrName, routeTable any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBFD", reflect.TypeOf((*MockNbClient)(nil).FindBFD), externalIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetECMPWidths", reflect.TypeOf((*MockNbClient)(nil).GetECMPWidths), lrName, routeTable)
 }
 
 // GetEntityInfo mocks base method.
@@ -3950,6 +5058,21 @@ func (mr *MockNbClientMockRecorder) GetLogicalRouterPortByUUID(uuid any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogicalRouterPortByUUID", reflect.TypeOf((*MockNbClient)(nil).GetLogicalRouterPortByUUID), uuid)
 }
 
+// GetLogicalRouterStaticRoutesByNexthop mocks base method.
+func (m *MockNbClient) GetLogicalRouterStaticRoutesByNexthop(lrName string) (map[string][]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogicalRouterStaticRoutesByNexthop", lrName)
+	ret0, _ := ret[0].(map[string][]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogicalRouterStaticRoutesByNexthop indicates an expected call of GetLogicalRouterStaticRoutesByNexthop.
+func (mr *MockNbClientMockRecorder) GetLogicalRouterStaticRoutesByNexthop(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogicalRouterStaticRoutesByNexthop", reflect.TypeOf((*MockNbClient)(nil).GetLogicalRouterStaticRoutesByNexthop), lrName)
+}
+
 // GetLogicalSwitchPort mocks base method.
 func (m *MockNbClient) GetLogicalSwitchPort(lspName string, ignoreNotFound bool) (*ovnnb.LogicalSwitchPort, error) {
 	m.ctrl.T.Helper()
@@ -4010,6 +5133,35 @@ func (mr *MockNbClientMockRecorder) GetPortGroup(pgName, ignoreNotFound any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortGroup", reflect.TypeOf((*MockNbClient)(nil).GetPortGroup), pgName, ignoreNotFound)
 }
 
+// GetRouterRoutingSnapshot mocks base method.
+func (m *MockNbClient) GetRouterRoutingSnapshot(lrName string) ([]ovs.StaticRouteSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRouterRoutingSnapshot", lrName)
+	ret0, _ := ret[0].([]ovs.StaticRouteSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRouterRoutingSnapshot indicates an expected call of GetRouterRoutingSnapshot.
+func (mr *MockNbClientMockRecorder) GetRouterRoutingSnapshot(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRouterRoutingSnapshot", reflect.TypeOf((*MockNbClient)(nil).GetRouterRoutingSnapshot), lrName)
+}
+
+// ImportLogicalRouterStaticRoutes mocks base method.
+func (m *MockNbClient) ImportLogicalRouterStaticRoutes(lrName string, routes []*ovnnb.LogicalRouterStaticRoute, strategy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLogicalRouterStaticRoutes", lrName, routes, strategy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportLogicalRouterStaticRoutes indicates an expected call of ImportLogicalRouterStaticRoutes.
+func (mr *MockNbClientMockRecorder) ImportLogicalRouterStaticRoutes(lrName, routes, strategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLogicalRouterStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).ImportLogicalRouterStaticRoutes), lrName, routes, strategy)
+}
+
 // ListAddressSets mocks base method.
 func (m *MockNbClient) ListAddressSets(externalIDs map[string]string) ([]ovnnb.AddressSet, error) {
 	m.ctrl.T.Helper()
@@ -4025,6 +5177,36 @@ func (mr *MockNbClientMockRecorder) ListAddressSets(externalIDs any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAddressSets", reflect.TypeOf((*MockNbClient)(nil).ListAddressSets), externalIDs)
 }
 
+// ListAllLogicalRouterStaticRoutesSorted mocks base method.
+func (m *MockNbClient) ListAllLogicalRouterStaticRoutesSorted(lrName string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllLogicalRouterStaticRoutesSorted", lrName)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllLogicalRouterStaticRoutesSorted indicates an expected call of ListAllLogicalRouterStaticRoutesSorted.
+func (mr *MockNbClientMockRecorder) ListAllLogicalRouterStaticRoutesSorted(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllLogicalRouterStaticRoutesSorted", reflect.TypeOf((*MockNbClient)(nil).ListAllLogicalRouterStaticRoutesSorted), lrName)
+}
+
+// ListAllStaticRoutes mocks base method.
+func (m *MockNbClient) ListAllStaticRoutes() ([]ovs.RouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllStaticRoutes")
+	ret0, _ := ret[0].([]ovs.RouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllStaticRoutes indicates an expected call of ListAllStaticRoutes.
+func (mr *MockNbClientMockRecorder) ListAllStaticRoutes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).ListAllStaticRoutes))
+}
+
 // ListBFDs mocks base method.
 func (m *MockNbClient) ListBFDs(lrpName, dstIP string) ([]ovnnb.BFD, error) {
 	m.ctrl.T.Helper()
@@ -4175,6 +5357,21 @@ func (mr *MockNbClientMockRecorder) ListLogicalRouterPorts(externalIDs, filter a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterPorts", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterPorts), externalIDs, filter)
 }
 
+// ListLogicalRouterStaticRouteRefs mocks base method.
+func (m *MockNbClient) ListLogicalRouterStaticRouteRefs(lrName string) ([]ovs.StaticRouteRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRouteRefs", lrName)
+	ret0, _ := ret[0].([]ovs.StaticRouteRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRouteRefs indicates an expected call of ListLogicalRouterStaticRouteRefs.
+func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRouteRefs(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRouteRefs", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRouteRefs), lrName)
+}
+
 // ListLogicalRouterStaticRoutes mocks base method.
 func (m *MockNbClient) ListLogicalRouterStaticRoutes(lrName string, routeTable, policy *string, ipPrefix string, externalIDs map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
 	m.ctrl.T.Helper()
@@ -4190,6 +5387,51 @@ func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutes(lrName, routeT
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutes), lrName, routeTable, policy, ipPrefix, externalIDs)
 }
 
+// ListLogicalRouterStaticRoutesByBFDState mocks base method.
+func (m *MockNbClient) ListLogicalRouterStaticRoutesByBFDState(lrName, state string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByBFDState", lrName, state)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByBFDState indicates an expected call of ListLogicalRouterStaticRoutesByBFDState.
+func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutesByBFDState(lrName, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByBFDState", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutesByBFDState), lrName, state)
+}
+
+// ListLogicalRouterStaticRoutesByECMPHashMode mocks base method.
+func (m *MockNbClient) ListLogicalRouterStaticRoutesByECMPHashMode(lrName, mode string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByECMPHashMode", lrName, mode)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByECMPHashMode indicates an expected call of ListLogicalRouterStaticRoutesByECMPHashMode.
+func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutesByECMPHashMode(lrName, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByECMPHashMode", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutesByECMPHashMode), lrName, mode)
+}
+
+// ListLogicalRouterStaticRoutesByExternalIDsAndOptions mocks base method.
+func (m *MockNbClient) ListLogicalRouterStaticRoutesByExternalIDsAndOptions(lrName string, externalIDs, options map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByExternalIDsAndOptions", lrName, externalIDs, options)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByExternalIDsAndOptions indicates an expected call of ListLogicalRouterStaticRoutesByExternalIDsAndOptions.
+func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutesByExternalIDsAndOptions(lrName, externalIDs, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByExternalIDsAndOptions", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutesByExternalIDsAndOptions), lrName, externalIDs, options)
+}
+
 // ListLogicalRouterStaticRoutesByOption mocks base method.
 func (m *MockNbClient) ListLogicalRouterStaticRoutesByOption(lrName, routeTable, key, value string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
 	m.ctrl.T.Helper()
@@ -4205,6 +5447,67 @@ func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutesByOption(lrName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByOption", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutesByOption), lrName, routeTable, key, value)
 }
 
+// ListLogicalRouterStaticRoutesByOutputPort mocks base method.
+func (m *MockNbClient) ListLogicalRouterStaticRoutesByOutputPort(lrName, outputPort string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesByOutputPort", lrName, outputPort)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesByOutputPort indicates an expected call of ListLogicalRouterStaticRoutesByOutputPort.
+func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutesByOutputPort(lrName, outputPort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesByOutputPort", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutesByOutputPort), lrName, outputPort)
+}
+
+// ListLogicalRouterStaticRoutesChangedSince mocks base method.
+func (m *MockNbClient) ListLogicalRouterStaticRoutesChangedSince(lrName string, version uint64) ([]*ovnnb.LogicalRouterStaticRoute, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesChangedSince", lrName, version)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLogicalRouterStaticRoutesChangedSince indicates an expected call of ListLogicalRouterStaticRoutesChangedSince.
+func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutesChangedSince(lrName, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesChangedSince", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutesChangedSince), lrName, version)
+}
+
+// ListLogicalRouterStaticRoutesInPrefixRange mocks base method.
+func (m *MockNbClient) ListLogicalRouterStaticRoutesInPrefixRange(lrName, startCIDR, endCIDR string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesInPrefixRange", lrName, startCIDR, endCIDR)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesInPrefixRange indicates an expected call of ListLogicalRouterStaticRoutesInPrefixRange.
+func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutesInPrefixRange(lrName, startCIDR, endCIDR any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesInPrefixRange", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutesInPrefixRange), lrName, startCIDR, endCIDR)
+}
+
+// ListLogicalRouterStaticRoutesOverlapping mocks base method.
+func (m *MockNbClient) ListLogicalRouterStaticRoutesOverlapping(lrName, cidr string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogicalRouterStaticRoutesOverlapping", lrName, cidr)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogicalRouterStaticRoutesOverlapping indicates an expected call of ListLogicalRouterStaticRoutesOverlapping.
+func (mr *MockNbClientMockRecorder) ListLogicalRouterStaticRoutesOverlapping(lrName, cidr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogicalRouterStaticRoutesOverlapping", reflect.TypeOf((*MockNbClient)(nil).ListLogicalRouterStaticRoutesOverlapping), lrName, cidr)
+}
+
 // ListLogicalSwitch mocks base method.
 func (m *MockNbClient) ListLogicalSwitch(needVendorFilter bool, filter func(*ovnnb.LogicalSwitch) bool) ([]ovnnb.LogicalSwitch, error) {
 	m.ctrl.T.Helper()
@@ -4310,6 +5613,21 @@ func (mr *MockNbClientMockRecorder) ListPortGroups(externalIDs any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPortGroups", reflect.TypeOf((*MockNbClient)(nil).ListPortGroups), externalIDs)
 }
 
+// ListUnmanagedStaticRoutes mocks base method.
+func (m *MockNbClient) ListUnmanagedStaticRoutes(lrName, ownerKey string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnmanagedStaticRoutes", lrName, ownerKey)
+	ret0, _ := ret[0].([]*ovnnb.LogicalRouterStaticRoute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnmanagedStaticRoutes indicates an expected call of ListUnmanagedStaticRoutes.
+func (mr *MockNbClientMockRecorder) ListUnmanagedStaticRoutes(lrName, ownerKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnmanagedStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).ListUnmanagedStaticRoutes), lrName, ownerKey)
+}
+
 // ListUpBFDs mocks base method.
 func (m *MockNbClient) ListUpBFDs(dstIP string) ([]ovnnb.BFD, error) {
 	m.ctrl.T.Helper()
@@ -4597,6 +5915,18 @@ func (mr *MockNbClientMockRecorder) MonitorBFD() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonitorBFD", reflect.TypeOf((*MockNbClient)(nil).MonitorBFD))
 }
 
+// MonitorStaticRouteVersions mocks base method.
+func (m *MockNbClient) MonitorStaticRouteVersions() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MonitorStaticRouteVersions")
+}
+
+// MonitorStaticRouteVersions indicates an expected call of MonitorStaticRouteVersions.
+func (mr *MockNbClientMockRecorder) MonitorStaticRouteVersions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonitorStaticRouteVersions", reflect.TypeOf((*MockNbClient)(nil).MonitorStaticRouteVersions))
+}
+
 // NatExists mocks base method.
 func (m *MockNbClient) NatExists(lrName, natType, externalIP, logicalIP string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -4679,6 +6009,63 @@ func (mr *MockNbClientMockRecorder) PortGroupSetPorts(pgName, ports any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PortGroupSetPorts", reflect.TypeOf((*MockNbClient)(nil).PortGroupSetPorts), pgName, ports)
 }
 
+// PruneStaticRoutesByGeneration mocks base method.
+func (m *MockNbClient) PruneStaticRoutesByGeneration(lrName, generationKey, currentGeneration string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneStaticRoutesByGeneration", lrName, generationKey, currentGeneration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PruneStaticRoutesByGeneration indicates an expected call of PruneStaticRoutesByGeneration.
+func (mr *MockNbClientMockRecorder) PruneStaticRoutesByGeneration(lrName, generationKey, currentGeneration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneStaticRoutesByGeneration", reflect.TypeOf((*MockNbClient)(nil).PruneStaticRoutesByGeneration), lrName, generationKey, currentGeneration)
+}
+
+// ReconcileRoutersStaticRoutes mocks base method.
+func (m *MockNbClient) ReconcileRoutersStaticRoutes(specs map[string][]*ovnnb.LogicalRouterStaticRoute) (map[string]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileRoutersStaticRoutes", specs)
+	ret0, _ := ret[0].(map[string]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileRoutersStaticRoutes indicates an expected call of ReconcileRoutersStaticRoutes.
+func (mr *MockNbClientMockRecorder) ReconcileRoutersStaticRoutes(specs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileRoutersStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).ReconcileRoutersStaticRoutes), specs)
+}
+
+// ReconcileStaticRoutes mocks base method.
+func (m *MockNbClient) ReconcileStaticRoutes(lrName string, desired []*ovnnb.LogicalRouterStaticRoute, opts ovs.ReconcileOpts) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileStaticRoutes", lrName, desired, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileStaticRoutes indicates an expected call of ReconcileStaticRoutes.
+func (mr *MockNbClientMockRecorder) ReconcileStaticRoutes(lrName, desired, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).ReconcileStaticRoutes), lrName, desired, opts)
+}
+
+// RefreshHostnameRoutes mocks base method.
+func (m *MockNbClient) RefreshHostnameRoutes(lrName, hostnameKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshHostnameRoutes", lrName, hostnameKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshHostnameRoutes indicates an expected call of RefreshHostnameRoutes.
+func (mr *MockNbClientMockRecorder) RefreshHostnameRoutes(lrName, hostnameKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshHostnameRoutes", reflect.TypeOf((*MockNbClient)(nil).RefreshHostnameRoutes), lrName, hostnameKey)
+}
+
 // RemoveLogicalPatchPort mocks base method.
 func (m *MockNbClient) RemoveLogicalPatchPort(lspName, lrpName string) error {
 	m.ctrl.T.Helper()
@@ -4693,6 +6080,35 @@ func (mr *MockNbClientMockRecorder) RemoveLogicalPatchPort(lspName, lrpName any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLogicalPatchPort", reflect.TypeOf((*MockNbClient)(nil).RemoveLogicalPatchPort), lspName, lrpName)
 }
 
+// ReplaceGatewayNode mocks base method.
+func (m *MockNbClient) ReplaceGatewayNode(lrName, oldIP, newIP string, newBFD map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceGatewayNode", lrName, oldIP, newIP, newBFD)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceGatewayNode indicates an expected call of ReplaceGatewayNode.
+func (mr *MockNbClientMockRecorder) ReplaceGatewayNode(lrName, oldIP, newIP, newBFD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceGatewayNode", reflect.TypeOf((*MockNbClient)(nil).ReplaceGatewayNode), lrName, oldIP, newIP, newBFD)
+}
+
+// ReportUnreachableRouteTables mocks base method.
+func (m *MockNbClient) ReportUnreachableRouteTables(lrName string) ([]ovs.UnreachableRouteTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportUnreachableRouteTables", lrName)
+	ret0, _ := ret[0].([]ovs.UnreachableRouteTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportUnreachableRouteTables indicates an expected call of ReportUnreachableRouteTables.
+func (mr *MockNbClientMockRecorder) ReportUnreachableRouteTables(lrName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportUnreachableRouteTables", reflect.TypeOf((*MockNbClient)(nil).ReportUnreachableRouteTables), lrName)
+}
+
 // ResetLogicalSwitchPortMigrateOptions mocks base method.
 func (m *MockNbClient) ResetLogicalSwitchPortMigrateOptions(lspName, srcNodeName, targetNodeName string, migratedFail bool) error {
 	m.ctrl.T.Helper()
@@ -4750,6 +6166,20 @@ func (mr *MockNbClientMockRecorder) SetAzName(azName any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAzName", reflect.TypeOf((*MockNbClient)(nil).SetAzName), azName)
 }
 
+// SetECMPHashMode mocks base method.
+func (m *MockNbClient) SetECMPHashMode(lrName, routeTable, policy, ipPrefix, mode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetECMPHashMode", lrName, routeTable, policy, ipPrefix, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetECMPHashMode indicates an expected call of SetECMPHashMode.
+func (mr *MockNbClientMockRecorder) SetECMPHashMode(lrName, routeTable, policy, ipPrefix, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetECMPHashMode", reflect.TypeOf((*MockNbClient)(nil).SetECMPHashMode), lrName, routeTable, policy, ipPrefix, mode)
+}
+
 // SetICAutoRoute mocks base method.
 func (m *MockNbClient) SetICAutoRoute(enable bool, blackList []string) error {
 	m.ctrl.T.Helper()
@@ -5021,6 +6451,49 @@ func (mr *MockNbClientMockRecorder) SetVirtualLogicalSwitchPortVirtualParents(ls
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVirtualLogicalSwitchPortVirtualParents", reflect.TypeOf((*MockNbClient)(nil).SetVirtualLogicalSwitchPortVirtualParents), lsName, parents)
 }
 
+// StaticRouteVersion mocks base method.
+func (m *MockNbClient) StaticRouteVersion() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaticRouteVersion")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StaticRouteVersion indicates an expected call of StaticRouteVersion.
+func (mr *MockNbClientMockRecorder) StaticRouteVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaticRouteVersion", reflect.TypeOf((*MockNbClient)(nil).StaticRouteVersion))
+}
+
+// SummarizeStaticRoutes mocks base method.
+func (m *MockNbClient) SummarizeStaticRoutes(lrName, routeTable, nexthop, aggregatePrefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeStaticRoutes", lrName, routeTable, nexthop, aggregatePrefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SummarizeStaticRoutes indicates an expected call of SummarizeStaticRoutes.
+func (mr *MockNbClientMockRecorder) SummarizeStaticRoutes(lrName, routeTable, nexthop, aggregatePrefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).SummarizeStaticRoutes), lrName, routeTable, nexthop, aggregatePrefix)
+}
+
+// SweepExpiredStaticRoutes mocks base method.
+func (m *MockNbClient) SweepExpiredStaticRoutes(lrName, expiryKey string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepExpiredStaticRoutes", lrName, expiryKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SweepExpiredStaticRoutes indicates an expected call of SweepExpiredStaticRoutes.
+func (mr *MockNbClientMockRecorder) SweepExpiredStaticRoutes(lrName, expiryKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpiredStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).SweepExpiredStaticRoutes), lrName, expiryKey)
+}
+
 // Transact mocks base method.
 func (m *MockNbClient) Transact(method string, operations []ovsdb.Operation) error {
 	m.ctrl.T.Helper()
@@ -5035,6 +6508,20 @@ func (mr *MockNbClientMockRecorder) Transact(method, operations any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transact", reflect.TypeOf((*MockNbClient)(nil).Transact), method, operations)
 }
 
+// TransactRoutersStaticRoutes mocks base method.
+func (m *MockNbClient) TransactRoutersStaticRoutes(changes map[string]ovs.RouterStaticRouteChanges) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactRoutersStaticRoutes", changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransactRoutersStaticRoutes indicates an expected call of TransactRoutersStaticRoutes.
+func (mr *MockNbClientMockRecorder) TransactRoutersStaticRoutes(changes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactRoutersStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).TransactRoutersStaticRoutes), changes)
+}
+
 // UpdateAnpRuleACLOps mocks base method.
 func (m *MockNbClient) UpdateAnpRuleACLOps(pgName, asName, protocol, aclName string, priority int, aclAction ovnnb.ACLAction, logACLActions []ovnnb.ACLAction, rulePorts []v1alpha1.AdminNetworkPolicyPort, isIngress, isBanp bool) ([]ovsdb.Operation, error) {
 	m.ctrl.T.Helper()
@@ -5246,6 +6733,25 @@ func (mr *MockNbClientMockRecorder) UpdateLogicalRouterStaticRoute(route any, fi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogicalRouterStaticRoute", reflect.TypeOf((*MockNbClient)(nil).UpdateLogicalRouterStaticRoute), varargs...)
 }
 
+// UpdateLogicalRouterStaticRouteForceRecreate mocks base method.
+func (m *MockNbClient) UpdateLogicalRouterStaticRouteForceRecreate(lrName string, route *ovnnb.LogicalRouterStaticRoute, fields ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{lrName, route}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateLogicalRouterStaticRouteForceRecreate", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLogicalRouterStaticRouteForceRecreate indicates an expected call of UpdateLogicalRouterStaticRouteForceRecreate.
+func (mr *MockNbClientMockRecorder) UpdateLogicalRouterStaticRouteForceRecreate(lrName, route any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{lrName, route}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogicalRouterStaticRouteForceRecreate", reflect.TypeOf((*MockNbClient)(nil).UpdateLogicalRouterStaticRouteForceRecreate), varargs...)
+}
+
 // UpdateLogicalSwitchACL mocks base method.
 func (m *MockNbClient) UpdateLogicalSwitchACL(lsName, cidrBlock string, subnetAcls []v1.ACL, allowEWTraffic bool) error {
 	m.ctrl.T.Helper()
@@ -5307,6 +6813,35 @@ func (mr *MockNbClientMockRecorder) UpdateSnat(lrName, externalIP, logicalIP any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnat", reflect.TypeOf((*MockNbClient)(nil).UpdateSnat), lrName, externalIP, logicalIP)
 }
 
+// ValidateStaticRoutesBatch mocks base method.
+func (m *MockNbClient) ValidateStaticRoutesBatch(lrName string, routes []*ovnnb.LogicalRouterStaticRoute) (map[int]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateStaticRoutesBatch", lrName, routes)
+	ret0, _ := ret[0].(map[int]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateStaticRoutesBatch indicates an expected call of ValidateStaticRoutesBatch.
+func (mr *MockNbClientMockRecorder) ValidateStaticRoutesBatch(lrName, routes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateStaticRoutesBatch", reflect.TypeOf((*MockNbClient)(nil).ValidateStaticRoutesBatch), lrName, routes)
+}
+
+// WalkAllStaticRoutes mocks base method.
+func (m *MockNbClient) WalkAllStaticRoutes(fn func(ovs.RouterStaticRoute) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalkAllStaticRoutes", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalkAllStaticRoutes indicates an expected call of WalkAllStaticRoutes.
+func (mr *MockNbClientMockRecorder) WalkAllStaticRoutes(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkAllStaticRoutes", reflect.TypeOf((*MockNbClient)(nil).WalkAllStaticRoutes), fn)
+}
+
 // MockSbClient is a mock of SbClient interface.
 type MockSbClient struct {
 	ctrl     *gomock.Controller
@@ -79,6 +79,7 @@ type SubnetSpec struct {
 	AllowEWTraffic bool  `json:"allowEWTraffic,omitempty"`
 
 	NatOutgoingPolicyRules []NatOutgoingPolicyRule `json:"natOutgoingPolicyRules,omitempty"`
+	NatOutgoingSnatIP      string                  `json:"natOutgoingSnatIP,omitempty"`
 
 	U2OInterconnectionIP    string `json:"u2oInterconnectionIP,omitempty"`
 	U2OInterconnection      bool   `json:"u2oInterconnection,omitempty"`
@@ -111,6 +111,9 @@ type Configuration struct {
 	BfdMinRx      int
 	BfdDetectMult int
 
+	StaticRouteWarnThreshold      int
+	MaxStaticRoutesPerTransaction int
+
 	NodeLocalDNSIPs []string
 
 	// used to set vpc-egress-gateway image
@@ -195,6 +198,9 @@ func ParseFlags() (*Configuration, error) {
 		argBfdMinRx      = pflag.Int("bfd-min-rx", 100, "This is the minimum interval, in milliseconds, between received BFD Control packets")
 		argBfdDetectMult = pflag.Int("detect-mult", 3, "The negotiated transmit interval, multiplied by this value, provides the Detection Time for the receiving system in Asynchronous mode.")
 
+		argStaticRouteWarnThreshold      = pflag.Int("static-route-warn-threshold", 0, "Warn when the static route count of a logical router exceeds this value, default 0 means disabled")
+		argMaxStaticRoutesPerTransaction = pflag.Int("max-static-routes-per-transaction", 0, "The max number of static routes created or deleted in one ovn nb transaction, default 0 means unlimited")
+
 		argImage = pflag.String("image", "", "The image for vpc-egress-gateway")
 	)
 
@@ -280,6 +286,8 @@ func ParseFlags() (*Configuration, error) {
 		BfdMinTx:                       *argBfdMinTx,
 		BfdMinRx:                       *argBfdMinRx,
 		BfdDetectMult:                  *argBfdDetectMult,
+		StaticRouteWarnThreshold:       *argStaticRouteWarnThreshold,
+		MaxStaticRoutesPerTransaction:  *argMaxStaticRoutesPerTransaction,
 		EnableANP:                      *argEnableANP,
 		Image:                          *argImage,
 	}
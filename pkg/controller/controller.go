@@ -561,15 +561,21 @@ func Run(ctx context.Context, config *Configuration) {
 		anpInformerFactory:     anpInformerFactory,
 	}
 
-	if controller.OVNNbClient, err = ovs.NewOvnNbClient(
+	ovnNbClient, err := ovs.NewOvnNbClient(
 		config.OvnNbAddr,
 		config.OvnTimeout,
 		config.OvsDbConnectTimeout,
 		config.OvsDbInactivityTimeout,
 		config.OvsDbConnectMaxRetry,
-	); err != nil {
+	)
+	if err != nil {
 		util.LogFatalAndExit(err, "failed to create ovn nb client")
 	}
+	if config.StaticRouteWarnThreshold > 0 {
+		ovnNbClient.StaticRouteCountWarner = ovs.NewStaticRouteCountWarner(config.StaticRouteWarnThreshold, 10*time.Minute)
+	}
+	ovnNbClient.MaxStaticRoutesPerTransaction = config.MaxStaticRoutesPerTransaction
+	controller.OVNNbClient = ovnNbClient
 	if controller.OVNSbClient, err = ovs.NewOvnSbClient(
 		config.OvnSbAddr,
 		config.OvnTimeout,
@@ -922,10 +922,14 @@ func (c *Controller) batchDeleteStaticRouteFromVpc(name string, staticRoutes []*
 		}
 		delRoutes = append(delRoutes, newRoute)
 	}
-	if err = c.OVNNbClient.BatchDeleteLogicalRouterStaticRoute(name, delRoutes); err != nil {
+	notFound, err := c.OVNNbClient.BatchDeleteLogicalRouterStaticRoute(name, delRoutes)
+	if err != nil {
 		klog.Errorf("batch del vpc %s static route %d failed, %v", name, routeCount, err)
 		return err
 	}
+	if len(notFound) != 0 {
+		klog.V(3).Infof("%d static routes of vpc %s are already deleted", len(notFound), name)
+	}
 	klog.V(3).Infof("take to %v batch delete static route from vpc %s static routes %d", time.Since(start), name, len(delRoutes))
 
 	cachedVpc, err = c.vpcsLister.Get(name)
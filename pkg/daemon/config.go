@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -50,11 +52,13 @@ type Configuration struct {
 	KubeClient                kubernetes.Interface
 	KubeOvnClient             clientset.Interface
 	NodeName                  string
+	PodNamespace              string
 	NodeIPv4                  string
 	NodeIPv6                  string
 	ServiceClusterIPRange     string
 	ClusterRouter             string
 	NodeSwitch                string
+	NodeSwitchCIDR            string
 	EncapChecksum             bool
 	EnablePprof               bool
 	MacLearningFallback       bool
@@ -73,8 +77,22 @@ type Configuration struct {
 	TCPConnCheckPort          int32
 	UDPConnCheckPort          int32
 	EnableTProxy              bool
+	EnableETPLocalNoMasq      bool
 	OVSVsctlConcurrency       int32
 	SetVxlanTxOff             bool
+	NatOutgoingPorts          string
+	NatGatewayNodeSelector    string
+	IptablesJumpPosition      int
+	NatPreserveDSCP           string
+	GatewayRetryInterval      time.Duration
+	IPSetMinApplyInterval     time.Duration
+	TeardownGatewayOnExit     bool
+	// destination port matches of the nat outgoing rules parsed from NatOutgoingPorts
+	NatOutgoingPortMatches [][]string
+	// label selector of the nodes installing the nat outgoing rules parsed from NatGatewayNodeSelector
+	NatGatewaySelector labels.Selector
+	// dscp values preserved for the nat outgoing traffic parsed from NatPreserveDSCP
+	NatPreserveDSCPValues []uint8
 }
 
 // ParseFlags will parse cmd args then init kubeClient and configuration
@@ -99,6 +117,7 @@ func ParseFlags() *Configuration {
 		argServiceClusterIPRange = pflag.String("service-cluster-ip-range", "10.96.0.0/12", "The kubernetes service cluster ip range")
 		argClusterRouter         = pflag.String("cluster-router", util.DefaultVpc, "The router name for cluster router")
 		argNodeSwitch            = pflag.String("node-switch", "join", "The name of node gateway switch which help node to access pod network")
+		argNodeSwitchCIDR        = pflag.String("node-switch-cidr", "100.64.0.0/16", "The cidr for node switch")
 		argEncapChecksum         = pflag.Bool("encap-checksum", true, "Enable checksum")
 		argEnablePprof           = pflag.Bool("enable-pprof", false, "Enable pprof")
 		argPprofPort             = pflag.Int32("pprof-port", 10665, "The port to get profiling data")
@@ -117,9 +136,17 @@ func ParseFlags() *Configuration {
 		argTCPConnectivityCheckPort  = pflag.Int32("tcp-conn-check-port", 8100, "TCP connectivity Check Port")
 		argUDPConnectivityCheckPort  = pflag.Int32("udp-conn-check-port", 8101, "UDP connectivity Check Port")
 		argEnableTProxy              = pflag.Bool("enable-tproxy", false, "enable tproxy for vpc pod liveness or readiness probe")
+		argEnableETPLocalNoMasq      = pflag.Bool("enable-etp-local-no-masq", false, "Do not masquerade external traffic to local endpoints of services with external traffic policy set to local")
 		argOVSVsctlConcurrency       = pflag.Int32("ovs-vsctl-concurrency", 100, "concurrency limit of ovs-vsctl")
 		argEnableOVNIPSec            = pflag.Bool("enable-ovn-ipsec", false, "Whether to enable ovn ipsec")
 		argSetVxlanTxOff             = pflag.Bool("set-vxlan-tx-off", false, "Whether to set vxlan_sys_4789 tx off")
+		argNatOutgoingPorts          = pflag.String("nat-outgoing-ports", "", "Only masquerade nat outgoing traffic to the destination ports, e.g. tcp:80,443,8000-9000;udp:53, traffic to other ports is not masqueraded. All the nat outgoing traffic is masqueraded if not specified")
+		argNatGatewayNodeSelector    = pflag.String("nat-gateway-node-selector", "", "Only install the nat outgoing rules on the nodes matching the label selector, e.g. kube-ovn/role=gateway. The rules are installed on all the nodes if not specified")
+		argNatPreserveDSCP           = pflag.String("nat-preserve-dscp", "", "Preserve the DSCP values, e.g. 46,34, of the nat outgoing traffic by restoring them from the connection marks. The values can also be specified per subnet by the annotation "+util.NatPreserveDSCPAnnotation+". No DSCP value is preserved if not specified")
+		argIptablesJumpPosition      = pflag.Int("iptables-jump-position", 1, "The position of the builtin chains, e.g. POSTROUTING, to insert the jump rules to the kube-ovn chains at. The jump rules are appended if it is 0 or exceeds the rule count of the chain")
+		argIPSetMinApplyInterval     = pflag.Duration("ipset-min-apply-interval", 0, "The minimum interval between applying the gateway ipset updates, the changes within the interval are applied together at the end of it. The updates are applied on every change if it is 0")
+		argTeardownGatewayOnExit     = pflag.Bool("teardown-gateway-on-exit", false, "Whether to remove the gateway policy routes, iptables rules, ipsets and external gateway bridge of the node when the daemon exits, e.g. before uninstalling kube-ovn")
+		argGatewayRetryInterval      = pflag.Duration("gateway-retry-interval", 0, "The initial interval to retry the failed steps of the gateway reconciliation with exponential backoff, only the failed steps are retried. The failed steps are left to the next reconciliation round by default")
 	)
 
 	// mute info log for ipset lib
@@ -165,6 +192,7 @@ func ParseFlags() *Configuration {
 		ServiceClusterIPRange:     *argServiceClusterIPRange,
 		ClusterRouter:             *argClusterRouter,
 		NodeSwitch:                *argNodeSwitch,
+		NodeSwitchCIDR:            *argNodeSwitchCIDR,
 		EncapChecksum:             *argEncapChecksum,
 		NetworkType:               *argsNetworkType,
 		DefaultProviderName:       *argsDefaultProviderName,
@@ -179,8 +207,20 @@ func ParseFlags() *Configuration {
 		TCPConnCheckPort:          *argTCPConnectivityCheckPort,
 		UDPConnCheckPort:          *argUDPConnectivityCheckPort,
 		EnableTProxy:              *argEnableTProxy,
+		EnableETPLocalNoMasq:      *argEnableETPLocalNoMasq,
 		OVSVsctlConcurrency:       *argOVSVsctlConcurrency,
 		SetVxlanTxOff:             *argSetVxlanTxOff,
+		NatOutgoingPorts:          *argNatOutgoingPorts,
+		NatGatewayNodeSelector:    *argNatGatewayNodeSelector,
+		IptablesJumpPosition:      *argIptablesJumpPosition,
+		NatPreserveDSCP:           *argNatPreserveDSCP,
+		GatewayRetryInterval:      *argGatewayRetryInterval,
+		IPSetMinApplyInterval:     *argIPSetMinApplyInterval,
+		TeardownGatewayOnExit:     *argTeardownGatewayOnExit,
+		PodNamespace:              os.Getenv("POD_NAMESPACE"),
+	}
+	if config.PodNamespace == "" {
+		config.PodNamespace = metav1.NamespaceSystem
 	}
 	return config
 }
@@ -198,6 +238,27 @@ func (config *Configuration) Init(nicBridgeMappings map[string]string) error {
 		}
 	}
 
+	if config.IptablesJumpPosition < 0 {
+		return fmt.Errorf("invalid iptables jump position %d", config.IptablesJumpPosition)
+	}
+
+	portMatches, err := parseNatOutgoingPorts(config.NatOutgoingPorts)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	config.NatOutgoingPortMatches = portMatches
+
+	if config.NatPreserveDSCPValues, err = parseDSCPValues(config.NatPreserveDSCP); err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	if config.NatGatewaySelector, err = labels.Parse(config.NatGatewayNodeSelector); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to parse nat gateway node selector %q: %w", config.NatGatewayNodeSelector, err)
+	}
+
 	if err := config.initKubeClient(); err != nil {
 		klog.Error(err)
 		return err
@@ -211,6 +272,68 @@ func (config *Configuration) Init(nicBridgeMappings map[string]string) error {
 	return nil
 }
 
+// parseNatOutgoingPorts parses the destination ports in format of "tcp:80,443,8000-9000;udp:53"
+// to the iptables multiport matches of the protocols
+func parseNatOutgoingPorts(ports string) ([][]string, error) {
+	var matches [][]string
+	for _, item := range strings.Split(ports, ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		protocol, portList, ok := strings.Cut(item, ":")
+		protocol = strings.ToLower(strings.TrimSpace(protocol))
+		if !ok || !slices.Contains([]string{"tcp", "udp", "sctp"}, protocol) {
+			return nil, fmt.Errorf("invalid nat outgoing ports %q: protocol must be one of tcp, udp and sctp", item)
+		}
+
+		var dports []string
+		var count int
+		for _, port := range strings.Split(portList, ",") {
+			port = strings.TrimSpace(port)
+			start, end, isRange := strings.Cut(port, "-")
+			if !isRange {
+				end = start
+			}
+			startPort, err1 := strconv.ParseUint(start, 10, 16)
+			endPort, err2 := strconv.ParseUint(end, 10, 16)
+			if err1 != nil || err2 != nil || startPort == 0 || startPort > endPort {
+				return nil, fmt.Errorf("invalid nat outgoing port %q of protocol %s", port, protocol)
+			}
+			if isRange {
+				// a port range is counted as two ports by multiport
+				count += 2
+				dports = append(dports, start+":"+end)
+			} else {
+				count++
+				dports = append(dports, start)
+			}
+		}
+		if count > 15 {
+			return nil, fmt.Errorf("too many nat outgoing ports of protocol %s, at most 15 ports are supported and a port range counts as two", protocol)
+		}
+		matches = append(matches, []string{"-p", protocol, "-m", "multiport", "--dports", strings.Join(dports, ",")})
+	}
+	return matches, nil
+}
+
+// parseDSCPValues parses the comma separated dscp values in range [1, 63] to the sorted unique values,
+// dscp 0 is the default class and can not be distinguished from the unmarked connections
+func parseDSCPValues(s string) ([]uint8, error) {
+	var values []uint8
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		value, err := strconv.ParseUint(item, 10, 8)
+		if err != nil || value == 0 || value > 63 {
+			return nil, fmt.Errorf("invalid dscp value %q, it must be in range [1, 63]", item)
+		}
+		values = append(values, uint8(value))
+	}
+	slices.Sort(values)
+	return slices.Compact(values), nil
+}
+
 func (config *Configuration) initNicConfig(nicBridgeMappings map[string]string) error {
 	// Support to specify node network card separately
 	node, err := config.KubeClient.CoreV1().Nodes().Get(context.Background(), config.NodeName, metav1.GetOptions{})
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"slices"
 	"strconv"
+	"sync"
 	"time"
 
 	nadutils "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/utils"
@@ -18,6 +19,7 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	coordinationlisterv1 "k8s.io/client-go/listers/coordination/v1"
 	listerv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -62,6 +64,12 @@ type Controller struct {
 	servicesSynced cache.InformerSynced
 	serviceQueue   workqueue.TypedRateLimitingInterface[*serviceEvent]
 
+	configMapsLister listerv1.ConfigMapLister
+	configMapsSynced cache.InformerSynced
+
+	leasesLister coordinationlisterv1.LeaseLister
+	leasesSynced cache.InformerSynced
+
 	recorder record.EventRecorder
 
 	protocol string
@@ -70,6 +78,13 @@ type Controller struct {
 	localPodName   string
 	localNamespace string
 
+	// expiry of the nat gateway leases held by the node, used to release the leases of the subnets no longer centralized on the node
+	natGatewayLeases     map[string]time.Time
+	natGatewayLeasesLock sync.Mutex
+	// stale active gateways of the subnets with the expected ones, used to log the staleness on transitions only
+	staleActivateGateways     map[string]string
+	staleActivateGatewaysLock sync.Mutex
+
 	k8sExec k8sexec.Interface
 }
 
@@ -81,7 +96,7 @@ func newTypedRateLimitingQueue[T comparable](name string, rateLimiter workqueue.
 }
 
 // NewController init a daemon controller
-func NewController(config *Configuration, stopCh <-chan struct{}, podInformerFactory, nodeInformerFactory informers.SharedInformerFactory, kubeovnInformerFactory kubeovninformer.SharedInformerFactory) (*Controller, error) {
+func NewController(config *Configuration, stopCh <-chan struct{}, podInformerFactory, nodeInformerFactory, cmInformerFactory, leaseInformerFactory informers.SharedInformerFactory, kubeovnInformerFactory kubeovninformer.SharedInformerFactory) (*Controller, error) {
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartLogging(klog.Infof)
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: config.KubeClient.CoreV1().Events(v1.NamespaceAll)})
@@ -93,6 +108,8 @@ func NewController(config *Configuration, stopCh <-chan struct{}, podInformerFac
 	podInformer := podInformerFactory.Core().V1().Pods()
 	nodeInformer := nodeInformerFactory.Core().V1().Nodes()
 	servicesInformer := nodeInformerFactory.Core().V1().Services()
+	configMapInformer := cmInformerFactory.Core().V1().ConfigMaps()
+	leaseInformer := leaseInformerFactory.Coordination().V1().Leases()
 
 	controller := &Controller{
 		config: config,
@@ -123,6 +140,12 @@ func NewController(config *Configuration, stopCh <-chan struct{}, podInformerFac
 		servicesSynced: servicesInformer.Informer().HasSynced,
 		serviceQueue:   newTypedRateLimitingQueue[*serviceEvent]("Service", nil),
 
+		configMapsLister: configMapInformer.Lister(),
+		configMapsSynced: configMapInformer.Informer().HasSynced,
+
+		leasesLister: leaseInformer.Lister(),
+		leasesSynced: leaseInformer.Informer().HasSynced,
+
 		recorder: recorder,
 		k8sExec:  k8sexec.New(),
 	}
@@ -139,11 +162,13 @@ func NewController(config *Configuration, stopCh <-chan struct{}, podInformerFac
 
 	podInformerFactory.Start(stopCh)
 	nodeInformerFactory.Start(stopCh)
+	cmInformerFactory.Start(stopCh)
+	leaseInformerFactory.Start(stopCh)
 	kubeovnInformerFactory.Start(stopCh)
 
 	if !cache.WaitForCacheSync(stopCh,
 		controller.providerNetworksSynced, controller.vlansSynced, controller.subnetsSynced,
-		controller.podsSynced, controller.nodesSynced, controller.servicesSynced) {
+		controller.podsSynced, controller.nodesSynced, controller.servicesSynced, controller.configMapsSynced, controller.leasesSynced) {
 		util.LogFatalAndExit(nil, "failed to wait for caches to sync")
 	}
 
@@ -634,7 +659,11 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	go wait.Until(c.runDeleteProviderNetworkWorker, time.Second, stopCh)
 	go wait.Until(c.runSubnetWorker, time.Second, stopCh)
 	go wait.Until(c.runPodWorker, time.Second, stopCh)
-	go wait.Until(c.runGateway, 3*time.Second, stopCh)
+	gatewayDone := make(chan struct{})
+	go func() {
+		defer close(gatewayDone)
+		wait.Until(c.loopGateway, 3*time.Second, stopCh)
+	}()
 	go wait.Until(c.loopEncapIPCheck, 3*time.Second, stopCh)
 	go wait.Until(c.ovnMetricsUpdate, 3*time.Second, stopCh)
 	go wait.Until(func() {
@@ -673,6 +702,15 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 
 	<-stopCh
 	klog.Info("Shutting down workers")
+
+	if c.config.TeardownGatewayOnExit {
+		// wait for the running gateway round, which would recreate the state being torn down
+		<-gatewayDone
+		klog.Info("tearing down the gateway")
+		if err := c.TeardownGateway(); err != nil {
+			klog.Errorf("failed to tear down the gateway: %v", err)
+		}
+	}
 }
 
 func recompute() {
@@ -10,7 +10,9 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	ovsutil "github.com/digitalocean/go-openvswitch/ovs"
 	nadutils "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/utils"
@@ -26,6 +28,7 @@ import (
 	"k8s.io/klog/v2"
 	k8sipset "k8s.io/kubernetes/pkg/proxy/ipvs/ipset"
 	k8siptables "k8s.io/kubernetes/pkg/util/iptables"
+	"k8s.io/utils/set"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	"github.com/kubeovn/kube-ovn/pkg/ovs"
@@ -37,14 +40,67 @@ const (
 	kernelModuleIP6Tables = "ip6_tables"
 )
 
+// ipsetBackend is the subset of ipsets.IPSets used to manage the gateway ipsets
+type ipsetBackend interface {
+	AddOrReplaceIPSet(setMetadata ipsets.IPSetMetadata, members []string)
+	RemoveIPSet(setID string)
+	ApplyUpdates()
+	ApplyDeletions()
+}
+
+var _ ipsetBackend = (*ipsets.IPSets)(nil)
+
+// iptablesBackend is the subset of iptables.IPTables used to manage the gateway iptables rules
+type iptablesBackend interface {
+	Exists(table, chain string, rulespec ...string) (bool, error)
+	Insert(table, chain string, pos int, rulespec ...string) error
+	Delete(table, chain string, rulespec ...string) error
+	List(table, chain string) ([]string, error)
+	ListWithCounters(table, chain string) ([]string, error)
+	ListChains(table string) ([]string, error)
+	ChainExists(table, chain string) (bool, error)
+	NewChain(table, chain string) error
+	ClearChain(table, chain string) error
+	DeleteChain(table, chain string) error
+	ClearAndDeleteChain(table, chain string) error
+}
+
+var _ iptablesBackend = (*iptables.IPTables)(nil)
+
 // ControllerRuntime represents runtime specific controller members
 type ControllerRuntime struct {
-	iptables         map[string]*iptables.IPTables
-	iptablesObsolete map[string]*iptables.IPTables
+	iptables         map[string]iptablesBackend
+	iptablesObsolete map[string]iptablesBackend
 	k8siptables      map[string]k8siptables.Interface
 	k8sipsets        k8sipset.Interface
-	ipsets           map[string]*ipsets.IPSets
+	ipsets           map[string]ipsetBackend
 	gwCounters       map[string]*util.GwIPtableCounters
+	// iptables works in nft mode, in which the rules may be listed in a normalized form different from legacy mode
+	iptablesNft bool
+	// members of the managed ipsets applied in the last gateway cycle, indexed by protocol and set id
+	ipsetMembers map[string]map[string]set.Set[string]
+	// time when the members of the managed ipsets are applied in the last gateway cycle, indexed by protocol and set id
+	ipsetAppliedAt   map[string]map[string]time.Time
+	ipsetMembersLock sync.RWMutex
+	// max sizes of the managed ipsets, indexed by protocol and set id
+	ipsetMaxSizes     map[string]map[string]int
+	ipsetMaxSizesLock sync.Mutex
+	// time when the ipset updates are applied last time and the timers applying the deferred updates, indexed by protocol,
+	// used to apply the ipset updates at most once within IPSetMinApplyInterval
+	ipsetLastApply   map[string]time.Time
+	ipsetApplyTimers map[string]*time.Timer
+	// set once the gateway is torn down, after which the ipsets are never applied again
+	ipsetApplyStopped bool
+	// serializes setIPSet between the gateway cycles and the deferred applies
+	ipsetApplyLock sync.Mutex
+	// records the duration and the result of applying the ipset updates, defaults to the prometheus metrics
+	ipsetApplyRecorder func(protocol string, sets []string, duration time.Duration, failed bool)
+	// deletes the conntrack entries matching the filter, defaults to netlink.ConntrackDeleteFilters on the conntrack table
+	conntrackDeleter func(family netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error)
+	// lists the addresses of all the links of the family, defaults to netlink.AddrList
+	addrLister func(family int) ([]netlink.Addr, error)
+	// protocols whose gateway rules created before the rules are stamped have been deleted since the daemon started
+	unstampedGatewayRulesDeleted set.Set[string]
 
 	nmSyncer  *networkManagerSyncer
 	ovsClient *ovsutil.Client
@@ -92,12 +148,16 @@ func (c *Controller) initRuntime() error {
 	}
 	if !ok {
 		// iptables works in nft mode, we should migrate iptables rules
-		c.iptablesObsolete = make(map[string]*iptables.IPTables, 2)
+		c.iptablesObsolete = make(map[string]iptablesBackend, 2)
+		c.iptablesNft = true
 	}
 
-	c.iptables = make(map[string]*iptables.IPTables)
-	c.ipsets = make(map[string]*ipsets.IPSets)
+	c.iptables = make(map[string]iptablesBackend)
+	c.ipsets = make(map[string]ipsetBackend)
 	c.gwCounters = make(map[string]*util.GwIPtableCounters)
+	c.ipsetMembers = make(map[string]map[string]set.Set[string])
+	c.ipsetAppliedAt = make(map[string]map[string]time.Time)
+	c.ipsetMaxSizes = make(map[string]map[string]int)
 	c.k8siptables = make(map[string]k8siptables.Interface)
 	c.k8sipsets = k8sipset.New(c.k8sExec)
 	c.ovsClient = ovsutil.New()
@@ -1,40 +1,158 @@
 package daemon
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	"github.com/kubeovn/kube-ovn/pkg/ovs"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
-func (c *Controller) runGateway() {
-	if err := c.setIPSet(); err != nil {
-		klog.Errorf("failed to set gw ipsets")
-	}
-	if err := c.setPolicyRouting(); err != nil {
-		klog.Errorf("failed to set gw policy routing")
+// gatewayOvsExecTimeout is the timeout of ovs-vsctl commands in gateway reconciliation,
+// so that a hung ovs-vsctl fails fast and the reconciliation is retried in the next round
+const gatewayOvsExecTimeout = 10 * time.Second
+
+// gatewayRetrySteps is the max number of retries of the failed gateway steps in a reconciliation round
+const gatewayRetrySteps = 3
+
+// gatewayStep is a step of the gateway reconciliation
+type gatewayStep string
+
+const (
+	gatewayStepIPSet         gatewayStep = "ipset"
+	gatewayStepPolicyRouting gatewayStep = "policy-routing"
+	gatewayStepIptables      gatewayStep = "iptables"
+	gatewayStepBandwidth     gatewayStep = "bandwidth"
+	gatewayStepICGateway     gatewayStep = "ic-gateway"
+	gatewayStepExGateway     gatewayStep = "ex-gateway"
+)
+
+// gatewayStepFunc is a step of the gateway reconciliation with the function applying it
+type gatewayStepFunc struct {
+	step gatewayStep
+	fn   func() error
+}
+
+// gatewayResult is the result of a gateway reconciliation round, it records the steps run and the errors of the failed ones
+type gatewayResult struct {
+	steps  []gatewayStep
+	errors map[gatewayStep]error
+}
+
+// Failed returns whether the step failed in the round
+func (r gatewayResult) Failed(step gatewayStep) bool {
+	_, ok := r.errors[step]
+	return ok
+}
+
+// Err returns the error of the step, nil if the step succeeded or was not run
+func (r gatewayResult) Err(step gatewayStep) error {
+	return r.errors[step]
+}
+
+// FailedSteps returns the failed steps in the order they were run
+func (r gatewayResult) FailedSteps() []gatewayStep {
+	var steps []gatewayStep
+	for _, step := range r.steps {
+		if r.Failed(step) {
+			steps = append(steps, step)
+		}
 	}
-	if err := c.setIptables(); err != nil {
-		klog.Errorf("failed to set gw iptables")
+	return steps
+}
+
+// SucceededSteps returns the succeeded steps in the order they were run
+func (r gatewayResult) SucceededSteps() []gatewayStep {
+	var steps []gatewayStep
+	for _, step := range r.steps {
+		if !r.Failed(step) {
+			steps = append(steps, step)
+		}
 	}
+	return steps
+}
 
-	if err := c.setGatewayBandwidth(); err != nil {
-		klog.Errorf("failed to set gw bandwidth, %v", err)
+// runGatewaySteps runs all the steps regardless of the failures of the previous ones and returns the result
+func runGatewaySteps(steps []gatewayStepFunc) gatewayResult {
+	result := gatewayResult{errors: make(map[gatewayStep]error)}
+	for _, s := range steps {
+		result.steps = append(result.steps, s.step)
+		if err := s.fn(); err != nil {
+			klog.Errorf("failed to set gw %s, %v", s.step, err)
+			result.errors[s.step] = err
+		}
 	}
-	if err := c.setICGateway(); err != nil {
-		klog.Errorf("failed to set ic gateway, %v", err)
+	return result
+}
+
+// filterGatewaySteps returns the steps failed in the result
+func filterGatewaySteps(steps []gatewayStepFunc, result gatewayResult) []gatewayStepFunc {
+	var failed []gatewayStepFunc
+	for _, s := range steps {
+		if result.Failed(s.step) {
+			failed = append(failed, s)
+		}
 	}
-	if err := c.setExGateway(); err != nil {
-		klog.Errorf("failed to set ex gateway, %v", err)
+	return failed
+}
+
+func (c *Controller) gatewaySteps() []gatewayStepFunc {
+	return []gatewayStepFunc{
+		{gatewayStepIPSet, c.setIPSet},
+		{gatewayStepPolicyRouting, c.setPolicyRouting},
+		{gatewayStepIptables, c.setIptables},
+		{gatewayStepBandwidth, c.setGatewayBandwidth},
+		{gatewayStepICGateway, c.setICGateway},
+		{gatewayStepExGateway, c.setExGateway},
 	}
+}
+
+// runGateway runs all the steps of the gateway reconciliation and returns the result,
+// so that the caller is able to retry the failed steps only
+func (c *Controller) runGateway() gatewayResult {
+	result := runGatewaySteps(c.gatewaySteps())
 	c.gcIPSet()
+	return result
+}
+
+// loopGateway runs the gateway reconciliation and retries the failed steps with exponential backoff
+// starting from GatewayRetryInterval, the steps still failing are left to the next round
+func (c *Controller) loopGateway() {
+	result := c.runGateway()
+	retryGatewaySteps(c.gatewaySteps(), result, c.config.GatewayRetryInterval)
+}
+
+// retryGatewaySteps retries the steps failed in the result with exponential backoff starting from interval
+// and returns the result of the last retry
+func retryGatewaySteps(steps []gatewayStepFunc, result gatewayResult, interval time.Duration) gatewayResult {
+	if interval <= 0 {
+		return result
+	}
+	backoff := wait.Backoff{Duration: interval, Factor: 2, Steps: gatewayRetrySteps}
+	for steps = filterGatewaySteps(steps, result); len(steps) != 0 && backoff.Steps > 0; steps = filterGatewaySteps(steps, result) {
+		time.Sleep(backoff.Step())
+		klog.Infof("retrying the failed gateway steps %v", result.FailedSteps())
+		result = runGatewaySteps(steps)
+	}
+	return result
 }
 
 func (c *Controller) setGatewayBandwidth() error {
@@ -61,27 +179,30 @@ func (c *Controller) setICGateway() error {
 	}
 	enable := node.Labels[util.ICGatewayLabel]
 	if enable == "true" {
-		icEnabled, err := ovs.Exec(ovs.IfExists, "get", "open", ".", "external_ids:ovn-is-interconn")
+		icEnabled, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, ovs.IfExists, "get", "open", ".", "external_ids:ovn-is-interconn")
 		if err != nil {
 			return fmt.Errorf("failed to get if ic enabled, %w", err)
 		}
 		if strings.Trim(icEnabled, "\"") != "true" {
-			if _, err := ovs.Exec("set", "open", ".", "external_ids:ovn-is-interconn=true"); err != nil {
+			if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, "set", "open", ".", "external_ids:ovn-is-interconn=true"); err != nil {
 				return fmt.Errorf("failed to enable ic gateway, %w", err)
 			}
 		}
 	} else {
-		if _, err := ovs.Exec("set", "open", ".", "external_ids:ovn-is-interconn=false"); err != nil {
+		if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, "set", "open", ".", "external_ids:ovn-is-interconn=false"); err != nil {
 			return fmt.Errorf("failed to disable ic gateway, %w", err)
 		}
 	}
 	return nil
 }
 
+// isSubnetNeedNat returns whether the traffic of the subnet needs nat, the subnets with underlay to overlay
+// interconnection enabled are excluded if they are annotated with U2ONoNatAnnotation
 func (c *Controller) isSubnetNeedNat(subnet *kubeovnv1.Subnet, protocol string) bool {
 	if subnet.DeletionTimestamp.IsZero() &&
 		subnet.Spec.NatOutgoing &&
 		(subnet.Spec.Vlan == "" || subnet.Spec.LogicalGateway) &&
+		(!subnet.Spec.U2OInterconnection || subnet.Annotations[util.U2ONoNatAnnotation] != "true") &&
 		subnet.Spec.Vpc == c.config.ClusterRouter &&
 		subnet.Spec.CIDRBlock != "" &&
 		(subnet.Spec.Protocol == kubeovnv1.ProtocolDual || subnet.Spec.Protocol == protocol) {
@@ -109,6 +230,61 @@ func (c *Controller) getSubnetsNeedNAT(protocol string) ([]string, error) {
 	return subnetsNeedNat, nil
 }
 
+// getSubnetsNatOutgoingSnatIP returns the cidrs of the nat outgoing subnets with a snat ip of the protocol configured,
+// traffic from the cidrs is snat to the ip instead of masquerade
+func (c *Controller) getSubnetsNatOutgoingSnatIP(protocol string) (map[string]string, error) {
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("list subnets failed, %v", err)
+		return nil, err
+	}
+
+	subnetsSnatIP := make(map[string]string)
+	for _, subnet := range subnets {
+		if subnet.Spec.NatOutgoingSnatIP == "" || len(subnet.Spec.NatOutgoingPolicyRules) != 0 || !c.isSubnetNeedNat(subnet, protocol) {
+			continue
+		}
+		cidrBlock, err := getCidrByProtocol(subnet.Spec.CIDRBlock, protocol)
+		if err != nil || cidrBlock == "" {
+			continue
+		}
+		for _, ip := range strings.Split(subnet.Spec.NatOutgoingSnatIP, ",") {
+			if util.CheckProtocol(ip) == protocol {
+				subnetsSnatIP[cidrBlock] = ip
+				break
+			}
+		}
+	}
+	return subnetsSnatIP, nil
+}
+
+// getSubnetsNatPreserveDSCP returns the dscp values to preserve of the nat outgoing subnets, keyed by the subnet cidrs
+func (c *Controller) getSubnetsNatPreserveDSCP(protocol string) (map[string][]uint8, error) {
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("list subnets failed, %v", err)
+		return nil, err
+	}
+
+	subnetsDSCP := make(map[string][]uint8)
+	for _, subnet := range subnets {
+		if subnet.Annotations[util.NatPreserveDSCPAnnotation] == "" || !c.isSubnetNeedNat(subnet, protocol) {
+			continue
+		}
+		values, err := parseDSCPValues(subnet.Annotations[util.NatPreserveDSCPAnnotation])
+		if err != nil {
+			klog.Warningf("ignore annotation %s of subnet %s: %v", util.NatPreserveDSCPAnnotation, subnet.Name, err)
+			continue
+		}
+		cidrBlock, err := getCidrByProtocol(subnet.Spec.CIDRBlock, protocol)
+		if err != nil || cidrBlock == "" {
+			continue
+		}
+		subnetsDSCP[cidrBlock] = values
+	}
+	return subnetsDSCP, nil
+}
+
 func (c *Controller) getSubnetsNatOutGoingPolicy(protocol string) ([]*kubeovnv1.Subnet, error) {
 	subnets, err := c.subnetsLister.List(labels.Everything())
 	if err != nil {
@@ -159,6 +335,15 @@ func (c *Controller) getServicesCIDR(protocol string) []string {
 	return ret
 }
 
+// getNodeSwitchCIDR returns the cidr of the node switch from config
+func (c *Controller) getNodeSwitchCIDR(protocol string) string {
+	cidr, err := getCidrByProtocol(c.config.NodeSwitchCIDR, protocol)
+	if err != nil {
+		return ""
+	}
+	return cidr
+}
+
 func (c *Controller) getDefaultVpcSubnetsCIDR(protocol string) ([]string, map[string]string, error) {
 	subnets, err := c.subnetsLister.List(labels.Everything())
 	if err != nil {
@@ -217,12 +402,248 @@ func getCidrByProtocol(cidr, protocol string) (string, error) {
 	return "", nil
 }
 
-func (c *Controller) getEgressNatIPByNode(nodeName string) (map[string]string, error) {
+// natGatewayHandoff decides the snat state of an active-backup centralized subnet on the node.
+// The node holding the nat gateway lease is the only one doing snat for the subnet:
+// the new active gateway claims the lease only after the old one released it or the lease expired,
+// the active gateway holding the lease keeps renewing it, and the old active gateway releases
+// the lease only after its snat rules are removed.
+func natGatewayHandoff(nodeName, activeGateway, holder string, holderValid bool) (claim, snat, release bool) {
+	if activeGateway != nodeName {
+		return false, false, holder == nodeName
+	}
+	if holder == nodeName || holder == "" || !holderValid {
+		return true, true, false
+	}
+	return false, false, false
+}
+
+const (
+	// natGatewayLeasePrefix is the name prefix of the leases electing the node doing snat for active-backup centralized subnets
+	natGatewayLeasePrefix = "kube-ovn-nat-gw-"
+	// natGatewayLeaseDuration is the duration after which the nat gateway lease not renewed can be taken over,
+	// e.g. the daemon of the holder node is dead while the node is still ready
+	natGatewayLeaseDuration = 15 * time.Second
+)
+
+// natGatewayLeaseHolder returns the holder of the nat gateway lease and whether the lease is held and not expired at now
+func natGatewayLeaseHolder(lease *coordinationv1.Lease, now time.Time) (string, bool) {
+	if lease == nil || lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		return "", false
+	}
+	holder := *lease.Spec.HolderIdentity
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return holder, false
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return holder, now.Before(expiry)
+}
+
+// getNatGatewayLease returns the cached nat gateway lease of the subnet, nil if it does not exist
+func (c *Controller) getNatGatewayLease(subnet string) (*coordinationv1.Lease, error) {
+	lease, err := c.leasesLister.Leases(c.config.PodNamespace).Get(natGatewayLeasePrefix + subnet)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		klog.Errorf("failed to get nat gateway lease of subnet %s: %v", subnet, err)
+		return nil, err
+	}
+	return lease, nil
+}
+
+// claimNatGateway acquires the nat gateway lease of the subnet for the node, or renews it if the node is the holder.
+// The lease is updated with the resource version read before, so the claim fails with a conflict if another node
+// claims the subnet at the same time. A lease renewed within a third of its duration is not renewed again
+func (c *Controller) claimNatGateway(lease *coordinationv1.Lease, subnet, nodeName string) error {
+	now := time.Now()
+	if holder, valid := natGatewayLeaseHolder(lease, now); valid && holder == nodeName &&
+		now.Sub(lease.Spec.RenewTime.Time) < natGatewayLeaseDuration/3 {
+		c.recordNatGatewayLease(subnet, lease.Spec.RenewTime.Add(natGatewayLeaseDuration))
+		return nil
+	}
+
+	renewTime := metav1.NewMicroTime(now)
+	client := c.config.KubeClient.CoordinationV1().Leases(c.config.PodNamespace)
+	var err error
+	if lease == nil {
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: natGatewayLeasePrefix + subnet, Namespace: c.config.PodNamespace}}
+		setNatGatewayLease(lease, nodeName, renewTime)
+		_, err = client.Create(context.Background(), lease, metav1.CreateOptions{})
+	} else {
+		lease = lease.DeepCopy()
+		setNatGatewayLease(lease, nodeName, renewTime)
+		_, err = client.Update(context.Background(), lease, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to claim nat gateway lease of subnet %s: %w", subnet, err)
+		klog.Error(err)
+		return err
+	}
+	if !c.recordNatGatewayLease(subnet, now.Add(natGatewayLeaseDuration)) {
+		klog.Infof("node %s claimed nat gateway of subnet %s", nodeName, subnet)
+	}
+	return nil
+}
+
+// setNatGatewayLease sets the holder of the nat gateway lease to the node, the acquire time is kept while renewing
+func setNatGatewayLease(lease *coordinationv1.Lease, nodeName string, renewTime metav1.MicroTime) {
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != nodeName {
+		lease.Spec.HolderIdentity = ptr.To(nodeName)
+		lease.Spec.AcquireTime = &renewTime
+	}
+	lease.Spec.RenewTime = &renewTime
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(natGatewayLeaseDuration / time.Second))
+}
+
+// recordNatGatewayLease records the expiry of the nat gateway lease of the subnet held by the node,
+// a zero expiry records the lease is released. It returns whether the lease was held before
+func (c *Controller) recordNatGatewayLease(subnet string, expiry time.Time) bool {
+	c.natGatewayLeasesLock.Lock()
+	defer c.natGatewayLeasesLock.Unlock()
+	if c.natGatewayLeases == nil {
+		c.natGatewayLeases = make(map[string]time.Time)
+	}
+	_, wasHeld := c.natGatewayLeases[subnet]
+	if expiry.IsZero() {
+		delete(c.natGatewayLeases, subnet)
+	} else {
+		c.natGatewayLeases[subnet] = expiry
+	}
+	return wasHeld
+}
+
+// isNatGatewayLeaseHeld returns whether the node has held the nat gateway lease of the subnet since the daemon started
+func (c *Controller) isNatGatewayLeaseHeld(subnet string) bool {
+	c.natGatewayLeasesLock.Lock()
+	defer c.natGatewayLeasesLock.Unlock()
+	_, held := c.natGatewayLeases[subnet]
+	return held
+}
+
+// isNatGatewayLeaseValid returns whether the nat gateway lease of the subnet held by the node has not expired at now
+func (c *Controller) isNatGatewayLeaseValid(subnet string, now time.Time) bool {
+	c.natGatewayLeasesLock.Lock()
+	defer c.natGatewayLeasesLock.Unlock()
+	expiry, held := c.natGatewayLeases[subnet]
+	return held && now.Before(expiry)
+}
+
+// expectedActivateGateway returns the node which should be the active gateway of an active-backup centralized subnet.
+// The current active gateway is kept while it is healthy, otherwise the first healthy node in the order of the
+// subnet gateway nodes is selected, which is the same as the selection of kube-ovn-controller
+func expectedActivateGateway(subnet *kubeovnv1.Subnet, healthyNodes set.Set[string]) string {
+	if subnet.Status.ActivateGateway != "" &&
+		util.GatewayContains(subnet.Spec.GatewayNode, subnet.Status.ActivateGateway) &&
+		healthyNodes.Has(subnet.Status.ActivateGateway) {
+		return subnet.Status.ActivateGateway
+	}
+	for _, gw := range gatewayNodeNames(subnet.Spec.GatewayNode) {
+		if healthyNodes.Has(gw) {
+			return gw
+		}
+	}
+	return ""
+}
+
+// gatewayNodeNames returns the node names of the gateway nodes string,
+// which can be like 'kube-ovn-worker:172.18.0.2, kube-ovn-control-plane:172.18.0.3'
+func gatewayNodeNames(gatewayNodeStr string) []string {
+	var names []string
+	for _, gw := range strings.Split(gatewayNodeStr, ",") {
+		gw = strings.TrimSpace(strings.Split(gw, ":")[0])
+		if gw != "" {
+			names = append(names, gw)
+		}
+	}
+	return names
+}
+
+// isActivateGatewayStale checks whether the active gateway in the subnet status differs from the expected one,
+// and returns the expected active gateway
+func (c *Controller) isActivateGatewayStale(subnet *kubeovnv1.Subnet) (bool, string) {
+	healthyNodes := set.New[string]()
+	for _, gw := range gatewayNodeNames(subnet.Spec.GatewayNode) {
+		if c.isNodeReady(gw) {
+			healthyNodes.Insert(gw)
+		}
+	}
+	expected := expectedActivateGateway(subnet, healthyNodes)
+	return expected != subnet.Status.ActivateGateway, expected
+}
+
+// recordActivateGatewayStale records whether the active gateway of the subnet is stale with the expected one,
+// and returns whether it changed since the last record, so that the staleness is logged only on transitions
+func (c *Controller) recordActivateGatewayStale(subnet *kubeovnv1.Subnet, stale bool, expected string) bool {
+	var state string
+	if stale {
+		state = subnet.Status.ActivateGateway + "/" + expected
+	}
+
+	c.staleActivateGatewaysLock.Lock()
+	defer c.staleActivateGatewaysLock.Unlock()
+	if c.staleActivateGateways[subnet.Name] == state {
+		return false
+	}
+	if state == "" {
+		delete(c.staleActivateGateways, subnet.Name)
+	} else {
+		if c.staleActivateGateways == nil {
+			c.staleActivateGateways = make(map[string]string)
+		}
+		c.staleActivateGateways[subnet.Name] = state
+	}
+	return true
+}
+
+func (c *Controller) isNodeReady(nodeName string) bool {
+	node, err := c.nodesLister.Get(nodeName)
+	if err != nil {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// releaseNatGateways clears the holder of the nat gateway leases of the subnets held by the node,
+// it must be called after the snat rules of the subnets have been removed
+func (c *Controller) releaseNatGateways(nodeName string, subnetNames []string) error {
+	for _, name := range subnetNames {
+		lease, err := c.getNatGatewayLease(name)
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		if holder, _ := natGatewayLeaseHolder(lease, time.Now()); holder != nodeName {
+			c.recordNatGatewayLease(name, time.Time{})
+			continue
+		}
+
+		lease = lease.DeepCopy()
+		lease.Spec.HolderIdentity, lease.Spec.AcquireTime, lease.Spec.RenewTime = nil, nil, nil
+		if _, err = c.config.KubeClient.CoordinationV1().Leases(c.config.PodNamespace).Update(context.Background(), lease, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to release nat gateway lease of subnet %s: %v", name, err)
+			return err
+		}
+		c.recordNatGatewayLease(name, time.Time{})
+		klog.Infof("node %s released nat gateway of subnet %s", nodeName, name)
+	}
+	return nil
+}
+
+// getEgressNatIPByNode returns the snat ips of the centralized subnets on the node,
+// and the active-backup subnets whose nat gateway should be released by the node.
+// The nat gateway leases are not claimed or renewed if dryRun is true
+func (c *Controller) getEgressNatIPByNode(nodeName string, dryRun bool) (map[string]string, []string, error) {
 	subnetsNatIP := make(map[string]string)
+	var toRelease []string
 	subnetList, err := c.subnetsLister.List(labels.Everything())
 	if err != nil {
 		klog.Errorf("failed to list subnets %v", err)
-		return subnetsNatIP, err
+		return subnetsNatIP, nil, err
 	}
 
 	for _, subnet := range subnetList {
@@ -231,24 +652,133 @@ func (c *Controller) getEgressNatIPByNode(nodeName string) (map[string]string, e
 			subnet.Spec.GatewayType != kubeovnv1.GWCentralizedType ||
 			!util.GatewayContains(subnet.Spec.GatewayNode, nodeName) ||
 			subnet.Spec.Vpc != c.config.ClusterRouter {
+			if c.isNatGatewayLeaseHeld(subnet.Name) {
+				toRelease = append(toRelease, subnet.Name)
+			}
 			continue
 		}
 
+		natIPs := make(map[string]string)
 		// only check format like 'kube-ovn-worker:172.18.0.2, kube-ovn-control-plane:172.18.0.3'
 		for _, cidr := range strings.Split(subnet.Spec.CIDRBlock, ",") {
 			for _, gw := range strings.Split(subnet.Spec.GatewayNode, ",") {
 				if strings.Contains(gw, ":") && util.GatewayContains(gw, nodeName) && util.CheckProtocol(cidr) == util.CheckProtocol(strings.Split(gw, ":")[1]) {
-					if subnet.Spec.EnableEcmp {
-						subnetsNatIP[cidr] = strings.TrimSpace(strings.Split(gw, ":")[1])
-					} else if subnet.Status.ActivateGateway == nodeName {
-						subnetsNatIP[cidr] = strings.TrimSpace(strings.Split(gw, ":")[1])
-					}
+					natIPs[cidr] = strings.TrimSpace(strings.Split(gw, ":")[1])
 					break
 				}
 			}
 		}
+
+		if subnet.Spec.EnableEcmp || len(natIPs) == 0 {
+			if c.isNatGatewayLeaseHeld(subnet.Name) {
+				toRelease = append(toRelease, subnet.Name)
+			}
+			if subnet.Spec.EnableEcmp {
+				maps.Copy(subnetsNatIP, natIPs)
+			}
+			continue
+		}
+
+		if stale, expected := c.isActivateGatewayStale(subnet); !dryRun && c.recordActivateGatewayStale(subnet, stale, expected) {
+			if stale {
+				klog.Warningf("active gateway %q of subnet %s is stale, expected %q", subnet.Status.ActivateGateway, subnet.Name, expected)
+			} else {
+				klog.Infof("active gateway %q of subnet %s is no longer stale", subnet.Status.ActivateGateway, subnet.Name)
+			}
+		}
+		lease, err := c.getNatGatewayLease(subnet.Name)
+		if err != nil {
+			klog.Error(err)
+			// keep the snat rules until the lease held by the node expires
+			if c.isNatGatewayLeaseValid(subnet.Name, time.Now()) {
+				maps.Copy(subnetsNatIP, natIPs)
+			}
+			continue
+		}
+		holder, valid := natGatewayLeaseHolder(lease, time.Now())
+		claim, snat, release := natGatewayHandoff(nodeName, subnet.Status.ActivateGateway, holder, valid)
+		if release {
+			toRelease = append(toRelease, subnet.Name)
+		}
+		if claim && !dryRun {
+			if err = c.claimNatGateway(lease, subnet.Name, nodeName); err != nil {
+				klog.Error(err)
+				// keep the snat rules until the lease held by the node expires
+				if !c.isNatGatewayLeaseValid(subnet.Name, time.Now()) {
+					continue
+				}
+			}
+		}
+		if snat {
+			maps.Copy(subnetsNatIP, natIPs)
+		}
+	}
+	return subnetsNatIP, toRelease, nil
+}
+
+// getETPLocalPodIPs returns the ips of local pods in distributed gateway subnets selected by node port or load balancer services
+// with external traffic policy set to local, the return traffic of centralized gateway subnets is masqueraded by the gateway node
+func (c *Controller) getETPLocalPodIPs(protocol string) ([]string, error) {
+	services, err := c.servicesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list services: %v", err)
+		return nil, err
+	}
+
+	distributed := make(map[string]bool)
+	isDistributed := func(pod *v1.Pod) (bool, error) {
+		subnetName := pod.Annotations[fmt.Sprintf(util.LogicalSwitchAnnotationTemplate, util.OvnProvider)]
+		if subnetName == "" {
+			return false, nil
+		}
+		if result, ok := distributed[subnetName]; ok {
+			return result, nil
+		}
+		subnet, err := c.subnetsLister.Get(subnetName)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return false, nil
+			}
+			klog.Errorf("failed to get subnet %s: %v", subnetName, err)
+			return false, err
+		}
+		distributed[subnetName] = subnet.Spec.GatewayType == kubeovnv1.GWDistributedType
+		return distributed[subnetName], nil
+	}
+
+	var ips []string
+	for _, svc := range services {
+		if (svc.Spec.Type != v1.ServiceTypeNodePort && svc.Spec.Type != v1.ServiceTypeLoadBalancer) ||
+			svc.Spec.ExternalTrafficPolicy != v1.ServiceExternalTrafficPolicyLocal ||
+			len(svc.Spec.Selector) == 0 {
+			continue
+		}
+
+		pods, err := c.podsLister.Pods(svc.Namespace).List(labels.SelectorFromSet(svc.Spec.Selector))
+		if err != nil {
+			klog.Errorf("failed to list pods of service %s/%s: %v", svc.Namespace, svc.Name, err)
+			return nil, err
+		}
+		for _, pod := range pods {
+			if pod.Spec.HostNetwork || !pod.DeletionTimestamp.IsZero() || pod.Spec.NodeName != c.config.NodeName {
+				continue
+			}
+			ok, err := isDistributed(pod)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			for _, ip := range strings.Split(pod.Annotations[util.IPAddressAnnotation], ",") {
+				if ip = strings.TrimSpace(ip); ip != "" && util.CheckProtocol(ip) == protocol {
+					ips = append(ips, ip)
+				}
+			}
+		}
 	}
-	return subnetsNatIP, nil
+	slices.Sort(ips)
+	return slices.Compact(ips), nil
 }
 
 func (c *Controller) getTProxyConditionPod(needSort bool) ([]*v1.Pod, error) {
@@ -289,3 +819,147 @@ func (c *Controller) getTProxyConditionPod(needSort bool) ([]*v1.Pod, error) {
 
 	return filteredPods, nil
 }
+
+const (
+	exGatewayDisableModeKey = "external-gw-disable-mode"
+	// remove only the external gateway nic from the external bridge if the bridge still has other uplinks
+	exGatewayDisableModeRemoveNic = "remove-nic"
+	// transfer the addresses and routes of the external gateway nic to the external bridge, and back when disabled
+	exGatewayKeepNicAddrKey = "external-gw-keep-nic-addr"
+	// comma separated cidrs of the networks attached to the external gateway, to which the traffic of the nat outgoing
+	// subnets is not masqueraded, while the traffic to other destinations is masqueraded as before
+	exGatewayNatExemptCIDRsKey = "external-gw-nat-exempt-cidrs"
+)
+
+// getExGatewayNatExemptCIDRs returns the nat exempt cidrs of ovn-external-gw-config indexed by protocol,
+// no cidr is exempted if the configmap does not exist or the external gateway is disabled
+func (c *Controller) getExGatewayNatExemptCIDRs() (map[string][]string, error) {
+	cm, err := c.configMapsLister.ConfigMaps(c.config.ExternalGatewayConfigNS).Get(util.ExternalGatewayConfig)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		klog.Errorf("failed to get ovn-external-gw-config, %v", err)
+		return nil, err
+	}
+	if cm.Data["enable-external-gw"] == "false" {
+		return nil, nil
+	}
+	return exGatewayNatExemptCIDRs(cm.Data), nil
+}
+
+// exGatewayNatExemptCIDRs parses the nat exempt cidrs indexed by protocol, the invalid ones are ignored with a warning
+func exGatewayNatExemptCIDRs(data map[string]string) map[string][]string {
+	cidrs := make(map[string][]string)
+	for _, s := range strings.Split(data[exGatewayNatExemptCIDRsKey], ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			klog.Warningf("ignore invalid %s %q in %s: %v", exGatewayNatExemptCIDRsKey, s, util.ExternalGatewayConfig, err)
+			continue
+		}
+		cidr := prefix.Masked().String()
+		protocol := util.CheckProtocol(cidr)
+		if !slices.Contains(cidrs[protocol], cidr) {
+			cidrs[protocol] = append(cidrs[protocol], cidr)
+		}
+	}
+	return cidrs
+}
+
+func exGatewayKeepNicAddr(data map[string]string) bool {
+	keep, _ := strconv.ParseBool(data[exGatewayKeepNicAddrKey])
+	return keep
+}
+
+// exGatewayOtherUplinks returns the ports of the external bridge other than the nic and the ovn patch ports
+func exGatewayOtherUplinks(ports []string, nic string) []string {
+	var uplinks []string
+	for _, port := range ports {
+		if port != nic && !strings.HasPrefix(port, "patch-") {
+			uplinks = append(uplinks, port)
+		}
+	}
+	return uplinks
+}
+
+// removeExGatewayNic removes only the external gateway nic from the external bridge in remove-nic disable mode
+// if the bridge has other uplinks, and returns whether the bridge is kept.
+// The bridge mapping is removed if no uplink remains, and the bridge is left to be deleted by the caller.
+// The addresses and routes of the nic are transferred back from the bridge if they are kept.
+// Nothing is done if the nic has already been removed from the bridge, e.g. in the previous rounds
+func (c *Controller) removeExGatewayNic(externalBridge string) (bool, error) {
+	cm, err := c.config.KubeClient.CoreV1().ConfigMaps(c.config.ExternalGatewayConfigNS).Get(context.Background(), util.ExternalGatewayConfig, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		klog.Errorf("failed to get ovn-external-gw-config, %v", err)
+		return false, err
+	}
+	nic := cm.Data["external-gw-nic"]
+	if nic == "" {
+		return false, nil
+	}
+	removeNic := cm.Data[exGatewayDisableModeKey] == exGatewayDisableModeRemoveNic
+	if bridge, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, "port-to-br", nic); err != nil || bridge != externalBridge {
+		// the bridge left after the nic removed keeps the other uplinks in remove-nic mode
+		klog.V(3).Infof("nic %s is not a port of external bridge %s", nic, externalBridge)
+		return removeNic, nil
+	}
+
+	keepNicAddr := exGatewayKeepNicAddr(cm.Data)
+	if !removeNic {
+		if keepNicAddr {
+			return false, c.restoreExGatewayNic(nic, externalBridge)
+		}
+		return false, nil
+	}
+
+	output, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, "list-ports", externalBridge)
+	if err != nil {
+		klog.Errorf("failed to list ports of external bridge %s: %v", externalBridge, err)
+		return false, err
+	}
+	uplinks := exGatewayOtherUplinks(strings.Fields(output), nic)
+	if len(uplinks) == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), gatewayOvsExecTimeout)
+		err = removeOvnMapping(ctx, "ovn-bridge-mappings", c.config.ExternalGatewaySwitch)
+		cancel()
+		if err != nil {
+			klog.Errorf("failed to remove ovn-bridge-mappings of %s: %v", c.config.ExternalGatewaySwitch, err)
+			return false, err
+		}
+		if keepNicAddr {
+			return false, c.restoreExGatewayNic(nic, externalBridge)
+		}
+		return false, nil
+	}
+
+	klog.Infof("remove nic %s from external bridge %s, which still has uplinks %v", nic, externalBridge, uplinks)
+	if keepNicAddr {
+		if err = c.restoreExGatewayNic(nic, externalBridge); err != nil {
+			return false, err
+		}
+	}
+	if _, err = ovs.ExecWithTimeout(gatewayOvsExecTimeout, ovs.IfExists, "del-port", externalBridge, nic); err != nil {
+		err = fmt.Errorf("failed to remove nic %s from external bridge %s, %w", nic, externalBridge, err)
+		klog.Error(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// restoreExGatewayNic removes the external gateway nic from the external bridge
+// and transfers the addresses and routes back to the nic
+func (c *Controller) restoreExGatewayNic(nic, externalBridge string) error {
+	klog.Infof("transfer addresses and routes of nic %s back from external bridge %s", nic, externalBridge)
+	if err := c.removeProviderNic(nic, externalBridge); err != nil {
+		err = fmt.Errorf("failed to restore nic %s from external bridge %s, %w", nic, externalBridge, err)
+		klog.Error(err)
+		return err
+	}
+	return nil
+}
@@ -4,18 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
+	"net/netip"
 	"os"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/kubeovn/felix/ipsets"
-	"github.com/kubeovn/go-iptables/iptables"
 	"github.com/scylladb/go-set/strset"
 	"github.com/vishvananda/netlink"
+	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -33,6 +36,7 @@ const (
 	SubnetNatSet               = "subnets-nat"
 	SubnetDistributedGwSet     = "subnets-distributed-gw"
 	LocalPodSet                = "local-pod-ip-nat"
+	ETPLocalPodSet             = "etp-local-pod"
 	OtherNodeSet               = "other-node"
 	IPSetPrefix                = "ovn"
 	NatOutGoingPolicySubnetSet = "subnets-nat-policy"
@@ -62,6 +66,16 @@ const (
 	TProxyPreroutingMask  = util.TProxyPreroutingMask
 )
 
+const (
+	// gatewayIptablesGeneration is bumped when the format of the gateway rules in the builtin filter chains changes,
+	// the rules stamped with older generations are removed after the ones of the current generation are installed
+	gatewayIptablesGeneration       = 1
+	gatewayIptablesGenerationPrefix = "kube-ovn-gateway-gen-"
+)
+
+// ipsetSlowApplyThreshold is the duration of applying the ipset updates beyond which a warning is logged
+const ipsetSlowApplyThreshold = time.Second
+
 var (
 	tProxyOutputMarkMask     = fmt.Sprintf("%#x/%#x", TProxyOutputMark, TProxyOutputMask)
 	tProxyPreRoutingMarkMask = fmt.Sprintf("%#x/%#x", TProxyPreroutingMark, TProxyPreroutingMask)
@@ -76,6 +90,12 @@ type policyRouteMeta struct {
 }
 
 func (c *Controller) setIPSet() error {
+	c.ipsetApplyLock.Lock()
+	defer c.ipsetApplyLock.Unlock()
+	if c.ipsetApplyStopped {
+		return nil
+	}
+
 	protocols := make([]string, 2)
 	if c.protocol == kubeovnv1.ProtocolDual {
 		protocols[0] = kubeovnv1.ProtocolIPv4
@@ -89,11 +109,18 @@ func (c *Controller) setIPSet() error {
 			continue
 		}
 		services := c.getServicesCIDR(protocol)
-		subnets, _, err := c.getDefaultVpcSubnetsCIDR(protocol)
+		subnets, subnetCIDRs, err := c.getDefaultVpcSubnetsCIDR(protocol)
 		if err != nil {
 			klog.Errorf("get subnets failed, %+v", err)
 			return err
 		}
+		// include the node switch from config before its subnet is synced,
+		// so that the traffic between nodes and pods is never masqueraded
+		if _, ok := subnetCIDRs[c.config.NodeSwitch]; !ok {
+			if cidr := c.getNodeSwitchCIDR(protocol); cidr != "" && !slices.Contains(subnets, cidr) {
+				subnets = append(subnets, cidr)
+			}
+		}
 		subnetsNeedNat, err := c.getSubnetsNeedNAT(protocol)
 		if err != nil {
 			klog.Errorf("get need nat subnets failed, %+v", err)
@@ -109,42 +136,641 @@ func (c *Controller) setIPSet() error {
 			klog.Errorf("failed to get node, %+v", err)
 			return err
 		}
+		var etpLocalPodIPs []string
+		if c.config.EnableETPLocalNoMasq {
+			if etpLocalPodIPs, err = c.getETPLocalPodIPs(protocol); err != nil {
+				klog.Errorf("failed to get local pods of services with external traffic policy local: %v", err)
+				return err
+			}
+		}
 		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-			MaxSize: 1048576,
+			MaxSize: c.getIPSetMaxSize(protocol, ServiceSet, len(services)),
 			SetID:   ServiceSet,
 			Type:    ipsets.IPSetTypeHashNet,
 		}, services)
 		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-			MaxSize: 1048576,
+			MaxSize: c.getIPSetMaxSize(protocol, SubnetSet, len(subnets)),
 			SetID:   SubnetSet,
 			Type:    ipsets.IPSetTypeHashNet,
 		}, subnets)
 		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-			MaxSize: 1048576,
+			MaxSize: c.getIPSetMaxSize(protocol, LocalPodSet, 0),
 			SetID:   LocalPodSet,
 			Type:    ipsets.IPSetTypeHashIP,
 		}, nil)
 		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-			MaxSize: 1048576,
+			MaxSize: c.getIPSetMaxSize(protocol, ETPLocalPodSet, len(etpLocalPodIPs)),
+			SetID:   ETPLocalPodSet,
+			Type:    ipsets.IPSetTypeHashIP,
+		}, etpLocalPodIPs)
+		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
+			MaxSize: c.getIPSetMaxSize(protocol, SubnetNatSet, len(subnetsNeedNat)),
 			SetID:   SubnetNatSet,
 			Type:    ipsets.IPSetTypeHashNet,
 		}, subnetsNeedNat)
 		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-			MaxSize: 1048576,
+			MaxSize: c.getIPSetMaxSize(protocol, SubnetDistributedGwSet, len(subnetsDistributedGateway)),
 			SetID:   SubnetDistributedGwSet,
 			Type:    ipsets.IPSetTypeHashNet,
 		}, subnetsDistributedGateway)
 		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-			MaxSize: 1048576,
+			MaxSize: c.getIPSetMaxSize(protocol, OtherNodeSet, len(otherNode)),
 			SetID:   OtherNodeSet,
 			Type:    ipsets.IPSetTypeHashNet,
 		}, otherNode)
 		c.reconcileNatOutGoingPolicyIPset(protocol)
-		c.ipsets[protocol].ApplyUpdates()
+
+		managedSets := []struct {
+			setID   string
+			members []string
+		}{
+			{ServiceSet, services},
+			{SubnetSet, subnets},
+			{LocalPodSet, nil},
+			{ETPLocalPodSet, etpLocalPodIPs},
+			{SubnetNatSet, subnetsNeedNat},
+			{SubnetDistributedGwSet, subnetsDistributedGateway},
+			{OtherNodeSet, otherNode},
+		}
+		var changedSets []string
+		for _, ipset := range managedSets {
+			if c.ipsetMembersChanged(protocol, ipset.setID, ipset.members) {
+				changedSets = append(changedSets, ipset.setID)
+			}
+		}
+		if c.deferIPSetApply(protocol) {
+			klog.V(3).Infof("%s ipset updates of %v are deferred to the end of the min apply interval", protocol, changedSets)
+			continue
+		}
+		if c.ipsetLastApply == nil {
+			c.ipsetLastApply = make(map[string]time.Time)
+		}
+		c.ipsetLastApply[protocol] = time.Now()
+		if err = c.applyIPSetUpdates(protocol, changedSets); err != nil {
+			klog.Error(err)
+			return err
+		}
+
+		var natRemoved []string
+		for _, ipset := range managedSets {
+			added, removed := c.recordIPSetMembers(protocol, ipset.setID, ipset.members)
+			if len(added) != 0 || len(removed) != 0 {
+				klog.V(2).Infof("%s ipset %s members added: %v, removed: %v", protocol, ipset.setID, added, removed)
+			}
+			if ipset.setID == SubnetNatSet && slices.Contains(changedSets, SubnetNatSet) {
+				natRemoved = removed
+			}
+		}
+		// the connections masqueraded before the nat outgoing of the subnets is disabled keep being masqueraded,
+		// they are flushed only after the cidrs are removed from the applied subnets-nat ipset, here or in the
+		// deferred apply, so that the new connections are never masqueraded again
+		if len(natRemoved) != 0 {
+			c.flushNatConntrack(protocol, natRemoved)
+		}
 	}
 	return nil
 }
 
+// natExemptRules inserts the rules returning the traffic of the nat outgoing subnets to the exempt cidrs
+// before the nat outgoing rules, which start from the one jumping to the nat outgoing policy chain
+func natExemptRules(rules []util.IPTableRule, natMatchset string, cidrs []string) []util.IPTableRule {
+	if len(cidrs) == 0 {
+		return rules
+	}
+
+	i := slices.IndexFunc(rules, func(rule util.IPTableRule) bool {
+		return slices.Contains(rule.Rule, OvnNatOutGoingPolicy)
+	})
+	if i == -1 {
+		i = len(rules)
+	}
+	exemptRules := make([]util.IPTableRule, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		exemptRules = append(exemptRules, util.IPTableRule{
+			Table: NAT,
+			Chain: OvnPostrouting,
+			Rule:  strings.Fields(fmt.Sprintf(`-m set --match-set %s src -d %s -j RETURN`, natMatchset, cidr)),
+		})
+	}
+	return slices.Insert(rules, i, exemptRules...)
+}
+
+// natGatewayRules removes the nat outgoing rules, which start from the one jumping to the nat outgoing policy chain,
+// from the nat postrouting rules if the node doesn't match the nat gateway selector. A nil selector matches all nodes
+func natGatewayRules(rules []util.IPTableRule, node *v1.Node, selector labels.Selector) []util.IPTableRule {
+	if selector == nil || selector.Matches(labels.Set(node.Labels)) {
+		return rules
+	}
+
+	i := slices.IndexFunc(rules, func(rule util.IPTableRule) bool {
+		return slices.Contains(rule.Rule, OvnNatOutGoingPolicy)
+	})
+	if i == -1 {
+		return rules
+	}
+	klog.V(3).Infof("node %s does not match nat gateway selector %q, skip the nat outgoing rules", node.Name, selector.String())
+	return rules[:i]
+}
+
+// subnetSnatRule returns the rule which snat the traffic from the cidr to outside of the matchset to the ip
+func subnetSnatRule(cidr, ip, matchset, randomFully string) util.IPTableRule {
+	s := fmt.Sprintf("-s %s -m set ! --match-set %s dst -j SNAT --to-source %s %s", cidr, matchset, ip, randomFully)
+	return util.IPTableRule{
+		Table: NAT,
+		Chain: OvnPostrouting,
+		Rule:  util.DoubleQuotedFields(s),
+	}
+}
+
+// subnetSnatRules returns the rules which snat the traffic of the nat outgoing subnets to their snat ips owned by the node,
+// e.g. the centralized gateway, qualified with the nat outgoing destination port matches if configured
+func (c *Controller) subnetSnatRules(protocol, matchset, randomFully string) ([]util.IPTableRule, error) {
+	subnetsSnatIP, err := c.getSubnetsNatOutgoingSnatIP(protocol)
+	if err != nil {
+		klog.Errorf("failed to get nat outgoing snat ips of subnets: %v", err)
+		return nil, err
+	}
+	if len(subnetsSnatIP) == 0 {
+		return nil, nil
+	}
+
+	family := netlink.FAMILY_V4
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		family = netlink.FAMILY_V6
+	}
+	listAddrs := c.addrLister
+	if listAddrs == nil {
+		listAddrs = func(family int) ([]netlink.Addr, error) { return netlink.AddrList(nil, family) }
+	}
+	addrs, err := listAddrs(family)
+	if err != nil {
+		klog.Errorf("failed to list addresses of the node: %v", err)
+		return nil, err
+	}
+	localIPs := set.New[string]()
+	for _, addr := range addrs {
+		localIPs.Insert(addr.IP.String())
+	}
+
+	var rules []util.IPTableRule
+	for _, cidr := range slices.Sorted(maps.Keys(subnetsSnatIP)) {
+		ip := subnetsSnatIP[cidr]
+		if !localIPs.Has(ip) {
+			klog.V(3).Infof("snat ip %s of nat outgoing subnet %s is not owned by the node, skip the snat rule", ip, cidr)
+			continue
+		}
+		rule := subnetSnatRule(cidr, ip, matchset, randomFully)
+		if len(c.config.NatOutgoingPortMatches) != 0 {
+			rules = append(rules, natOutgoingPortRules(rule, c.config.NatOutgoingPortMatches)...)
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// natOutgoingPortRules qualifies the nat outgoing rule with the destination port matches, one rule for each match
+func natOutgoingPortRules(rule util.IPTableRule, portMatches [][]string) []util.IPTableRule {
+	rules := make([]util.IPTableRule, 0, len(portMatches))
+	for _, match := range portMatches {
+		rules = append(rules, util.IPTableRule{
+			Table:   rule.Table,
+			Chain:   rule.Chain,
+			Rule:    slices.Concat(match, rule.Rule),
+			Comment: rule.Comment,
+		})
+	}
+	return rules
+}
+
+const (
+	// natDSCPMarkMask is the connection mark bits saving the dscp values of the nat outgoing connections
+	natDSCPMarkMask = 0x3f000000
+	// natDSCPMarkShift is the offset of the dscp values in the connection mark
+	natDSCPMarkShift = 24
+)
+
+// natDSCPPreserveRules returns the mangle rules which save each of the dscp values of the first packet matching src
+// to the connection mark, and restore the dscp values of the later packets changed on the way from the connection mark
+func natDSCPPreserveRules(src, matchset string, values []uint8) []util.IPTableRule {
+	rules := make([]util.IPTableRule, 0, 2*len(values))
+	for _, value := range values {
+		mark := fmt.Sprintf("%#x/%#x", uint32(value)<<natDSCPMarkShift, natDSCPMarkMask)
+		rules = append(rules,
+			util.IPTableRule{Table: MANGLE, Chain: OvnPostrouting, Rule: strings.Fields(fmt.Sprintf(`%s -m set ! --match-set %s dst -m connmark --mark 0x0/%#x -m dscp --dscp %d -j CONNMARK --set-xmark %s`, src, matchset, natDSCPMarkMask, value, mark))},
+			util.IPTableRule{Table: MANGLE, Chain: OvnPostrouting, Rule: strings.Fields(fmt.Sprintf(`%s -m set ! --match-set %s dst -m connmark --mark %s -m dscp ! --dscp %d -j DSCP --set-dscp %d`, src, matchset, mark, value, value))},
+		)
+	}
+	return rules
+}
+
+// etpLocalNatExclusionRule returns the rule which skips masquerade for external traffic to local endpoints in distributed
+// gateway subnets of services with external traffic policy set to local
+func etpLocalNatExclusionRule(protocol string) util.IPTableRule {
+	prefix := "ovn40"
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		prefix = "ovn60"
+	}
+	return util.IPTableRule{
+		Table: NAT,
+		Chain: OvnPostrouting,
+		Rule:  strings.Fields(fmt.Sprintf(`-m set ! --match-set %s src -m set --match-set %s dst -m set --match-set %s dst -j RETURN`, prefix+SubnetSet, prefix+SubnetDistributedGwSet, prefix+ETPLocalPodSet)),
+	}
+}
+
+const (
+	// ipsetMinMaxSize is the minimum max size of the managed ipsets
+	ipsetMinMaxSize = 1024
+	// ipsetSizeHeadroom is the ratio of the ipset max size to its members
+	ipsetSizeHeadroom = 2
+)
+
+// ipsetMaxSize returns the power of two max size of an ipset with headroom for the members
+func ipsetMaxSize(members int) int {
+	size := ipsetMinMaxSize
+	for size < members*ipsetSizeHeadroom {
+		size <<= 1
+	}
+	return size
+}
+
+// getIPSetMaxSize returns the max size of the ipset for the members. The size is only grown
+// when the members use up the headroom, so the set is not recreated by small membership changes
+func (c *Controller) getIPSetMaxSize(protocol, setID string, members int) int {
+	c.ipsetMaxSizesLock.Lock()
+	defer c.ipsetMaxSizesLock.Unlock()
+
+	if c.ipsetMaxSizes == nil {
+		c.ipsetMaxSizes = make(map[string]map[string]int)
+	}
+	if c.ipsetMaxSizes[protocol] == nil {
+		c.ipsetMaxSizes[protocol] = make(map[string]int)
+	}
+	current := c.ipsetMaxSizes[protocol][setID]
+	if current != 0 && members*ipsetSizeHeadroom <= current {
+		return current
+	}
+	size := ipsetMaxSize(members)
+	if current != 0 {
+		klog.Infof("grow %s ipset %s max size from %d to %d for %d members, the set will be recreated", protocol, setID, current, size, members)
+	}
+	c.ipsetMaxSizes[protocol][setID] = size
+	return size
+}
+
+// recordIPSetMembers records the members of the ipset applied in this cycle,
+// and returns the members added and removed since the last cycle
+func (c *Controller) recordIPSetMembers(protocol, setID string, members []string) (added, removed []string) {
+	c.ipsetMembersLock.Lock()
+	defer c.ipsetMembersLock.Unlock()
+
+	if c.ipsetMembers[protocol] == nil {
+		c.ipsetMembers[protocol] = make(map[string]set.Set[string])
+	}
+	if c.ipsetAppliedAt == nil {
+		c.ipsetAppliedAt = make(map[string]map[string]time.Time)
+	}
+	if c.ipsetAppliedAt[protocol] == nil {
+		c.ipsetAppliedAt[protocol] = make(map[string]time.Time)
+	}
+	previous, current := c.ipsetMembers[protocol][setID], set.New(members...)
+	c.ipsetMembers[protocol][setID] = current
+	c.ipsetAppliedAt[protocol][setID] = time.Now()
+	return current.Difference(previous).SortedList(), previous.Difference(current).SortedList()
+}
+
+// natConntrackFilter matches the conntrack entries originated from the cidr whose source address is translated
+type natConntrackFilter struct {
+	cidr *net.IPNet
+}
+
+func (f natConntrackFilter) MatchConntrackFlow(flow *netlink.ConntrackFlow) bool {
+	return f.cidr.Contains(flow.Forward.SrcIP) && !flow.Reverse.DstIP.Equal(flow.Forward.SrcIP)
+}
+
+// flushNatConntrack deletes the conntrack entries of the masqueraded connections from the cidrs,
+// so that the new connections from the cidrs are no longer masqueraded. Failures are only logged
+func (c *Controller) flushNatConntrack(protocol string, cidrs []string) {
+	family := netlink.InetFamily(netlink.FAMILY_V4)
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		family = netlink.FAMILY_V6
+	}
+	deleteFilter := c.conntrackDeleter
+	if deleteFilter == nil {
+		deleteFilter = func(family netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error) {
+			return netlink.ConntrackDeleteFilters(netlink.ConntrackTable, family, filter)
+		}
+	}
+
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			klog.Errorf("failed to parse cidr %s: %v", cidr, err)
+			continue
+		}
+		n, err := deleteFilter(family, natConntrackFilter{cidr: ipNet})
+		if err != nil {
+			klog.Errorf("failed to delete conntrack entries of masqueraded connections from %s: %v", cidr, err)
+			continue
+		}
+		klog.Infof("deleted %d conntrack entries of masqueraded connections from %s", n, cidr)
+	}
+}
+
+// ipsetMembersChanged returns whether the members of the managed ipset differ from the ones applied in the last
+// gateway cycle, an ipset never applied is always changed
+func (c *Controller) ipsetMembersChanged(protocol, setID string, members []string) bool {
+	c.ipsetMembersLock.RLock()
+	defer c.ipsetMembersLock.RUnlock()
+
+	previous, ok := c.ipsetMembers[protocol][setID]
+	return !ok || !previous.Equal(set.New(members...))
+}
+
+// deferIPSetApply returns whether the ipset updates of the protocol are applied within IPSetMinApplyInterval, in which case
+// setIPSet is scheduled at the end of the interval if not yet, so that the changes within the interval are applied together
+// and the final state is always applied. It must be called with ipsetApplyLock held
+func (c *Controller) deferIPSetApply(protocol string) bool {
+	interval := c.config.IPSetMinApplyInterval
+	if interval <= 0 {
+		return false
+	}
+	lastApply, ok := c.ipsetLastApply[protocol]
+	if !ok || time.Since(lastApply) >= interval {
+		return false
+	}
+
+	if c.ipsetApplyTimers[protocol] == nil {
+		if c.ipsetApplyTimers == nil {
+			c.ipsetApplyTimers = make(map[string]*time.Timer)
+		}
+		var timer *time.Timer
+		timer = time.AfterFunc(interval-time.Since(lastApply), func() {
+			c.ipsetApplyLock.Lock()
+			if c.ipsetApplyTimers[protocol] != timer {
+				// stopped by teardownIPSets
+				c.ipsetApplyLock.Unlock()
+				return
+			}
+			delete(c.ipsetApplyTimers, protocol)
+			c.ipsetApplyLock.Unlock()
+			if err := c.setIPSet(); err != nil {
+				klog.Errorf("failed to apply the deferred %s ipset updates: %v", protocol, err)
+			}
+		})
+		c.ipsetApplyTimers[protocol] = timer
+	}
+	return true
+}
+
+// applyIPSetUpdates applies the pending updates of the ipsets and records the duration and the result, labeled with
+// the managed ipsets whose members changed. The ipset backend panics if the updates still fail after retries, the panic
+// is recovered and returned as an error so that the updates are applied again in the next gateway cycle
+func (c *Controller) applyIPSetUpdates(protocol string, changedSets []string) (err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to apply %s ipset updates of %v: %v", protocol, changedSets, r)
+		}
+		duration := time.Since(start)
+		if duration >= ipsetSlowApplyThreshold {
+			klog.Warningf("applying %s ipset updates of %v took %v", protocol, changedSets, duration)
+		}
+		record := c.ipsetApplyRecorder
+		if record == nil {
+			record = c.recordIPSetApplyMetrics
+		}
+		record(protocol, changedSets, duration, err != nil)
+	}()
+
+	c.ipsets[protocol].ApplyUpdates()
+	return nil
+}
+
+// recordIPSetApplyMetrics records the duration and the failure of applying the ipset updates for each changed set,
+// an apply without changed sets is recorded with an empty set label
+func (c *Controller) recordIPSetApplyMetrics(protocol string, sets []string, duration time.Duration, failed bool) {
+	if len(sets) == 0 {
+		sets = []string{""}
+	}
+	for _, setID := range sets {
+		metricIPSetApplyDuration.WithLabelValues(c.config.NodeName, protocol, setID).Observe(duration.Seconds())
+		if failed {
+			metricIPSetApplyFailures.WithLabelValues(c.config.NodeName, protocol, setID).Inc()
+		}
+	}
+}
+
+// IPSetSnapshot is the members of a managed ipset applied in the last gateway cycle
+type IPSetSnapshot struct {
+	Members   []string  `json:"members"`
+	AppliedAt time.Time `json:"appliedAt"`
+}
+
+// GetAppliedIPSetMembers returns the snapshots of the managed ipsets of the protocol applied in the last
+// gateway cycle indexed by set id, which is useful to debug why the traffic of an ip is masqueraded
+func (c *Controller) GetAppliedIPSetMembers(protocol string) map[string]IPSetSnapshot {
+	c.ipsetMembersLock.RLock()
+	defer c.ipsetMembersLock.RUnlock()
+
+	snapshots := make(map[string]IPSetSnapshot, len(c.ipsetMembers[protocol]))
+	for setID, members := range c.ipsetMembers[protocol] {
+		snapshots[setID] = IPSetSnapshot{
+			Members:   members.SortedList(),
+			AppliedAt: c.ipsetAppliedAt[protocol][setID],
+		}
+	}
+	return snapshots
+}
+
+// ipsetMembersContain returns whether the ip matches any of the hash:net ipset members
+func ipsetMembersContain(members set.Set[string], ip netip.Addr) bool {
+	for member := range members {
+		if prefix, err := netip.ParsePrefix(member); err == nil {
+			if prefix.Contains(ip) {
+				return true
+			}
+		} else if addr, err := netip.ParseAddr(member); err == nil && addr == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// natPacket is the first packet of a new connection evaluated against the nat rules
+type natPacket struct {
+	src, dst netip.Addr
+	// transport protocol and destination port, empty and zero if unknown
+	proto   string
+	dstPort uint16
+}
+
+// natVerdict is the result of the packet traversing a nat chain
+type natVerdict int
+
+const (
+	// natContinue means no rule of the chain decides the verdict and the packet continues in the calling chain
+	natContinue natVerdict = iota
+	natReturn
+	natMasquerade
+)
+
+// multiportContains returns whether the port is in the comma separated ports and port ranges of multiport
+func multiportContains(ports string, port uint16) bool {
+	for _, item := range strings.Split(ports, ",") {
+		start, end, isRange := strings.Cut(item, ":")
+		if !isRange {
+			end = start
+		}
+		startPort, err1 := strconv.ParseUint(start, 10, 16)
+		endPort, err2 := strconv.ParseUint(end, 10, 16)
+		if err1 == nil && err2 == nil && uint64(port) >= startPort && uint64(port) <= endPort {
+			return true
+		}
+	}
+	return false
+}
+
+// natRuleMatches returns whether the packet matches the rule spec with the recorded ipset members. The packet is not
+// marked, so the rules matching marks set by kube-proxy or the nat outgoing policy chain never match, and the rules
+// with matches which can not be evaluated, e.g. conntrack states, tcp flags and ipsets not recorded, do not match
+func natRuleMatches(spec []string, pkt natPacket, members map[string]set.Set[string]) bool {
+	var negate bool
+	for i := 0; i < len(spec); i++ {
+		var matched bool
+		switch opt := spec[i]; opt {
+		case "!":
+			negate = true
+			continue
+		case "-j", "-g":
+			return true
+		case "-m":
+			// the options of the match extension are evaluated one by one
+			i++
+			continue
+		case "--comment":
+			i++
+			continue
+		case "-s", "-d", "-p", "--dports", "--mark":
+			if i++; i == len(spec) {
+				return false
+			}
+			switch opt {
+			case "-s", "-d":
+				ip := pkt.src
+				if opt == "-d" {
+					ip = pkt.dst
+				}
+				matched = ipsetMembersContain(set.New(spec[i]), ip)
+			case "-p":
+				matched = spec[i] == pkt.proto
+			case "--dports":
+				matched = pkt.dstPort != 0 && multiportContains(spec[i], pkt.dstPort)
+			}
+		case "--match-set":
+			if i += 2; i >= len(spec) {
+				return false
+			}
+			name, setID := spec[i-1], ""
+			for _, prefix := range [...]string{"ovn40", "ovn60"} {
+				if s, ok := strings.CutPrefix(name, prefix); ok {
+					setID = s
+				}
+			}
+			setMembers, ok := members[setID]
+			if !ok {
+				return false
+			}
+			ip := pkt.src
+			if spec[i] == "dst" {
+				ip = pkt.dst
+			}
+			matched = ipsetMembersContain(setMembers, ip)
+		default:
+			return false
+		}
+		if matched == negate {
+			return false
+		}
+		negate = false
+	}
+	return true
+}
+
+// evaluateNatChain traverses the packet through the nat chain in the rules, following the jumps to the chains
+// in the rules, and returns the verdict with the rule of the chain deciding it
+func evaluateNatChain(rules []util.IPTableRule, chain string, pkt natPacket, members map[string]set.Set[string]) (natVerdict, *util.IPTableRule) {
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Table != NAT || rule.Chain != chain || !natRuleMatches(rule.Rule, pkt, members) {
+			continue
+		}
+		j := slices.Index(rule.Rule, "-j")
+		if j == -1 || j == len(rule.Rule)-1 {
+			continue
+		}
+		switch target := rule.Rule[j+1]; target {
+		case "RETURN":
+			return natReturn, rule
+		case "MASQUERADE", "SNAT":
+			return natMasquerade, rule
+		default:
+			// the packet returning from the target chain, or not handled by it, continues in this chain
+			if verdict, _ := evaluateNatChain(rules, target, pkt, members); verdict == natMasquerade {
+				return natMasquerade, rule
+			}
+		}
+	}
+	return natContinue, nil
+}
+
+// WouldMasquerade evaluates the nat postrouting rules which would be applied for the node against the ipset members
+// recorded in the last cycle, and returns whether the new connection from the source ip to the destination is
+// masqueraded, or snat-ed, by kube-ovn with the rule which decides it. The transport protocol and destination port
+// are optional and only used by the nat outgoing port matches. The packet is evaluated as not marked,
+// so the traffic marked by kube-proxy and the nat outgoing policy rules are not evaluated
+func (c *Controller) WouldMasquerade(srcIP, dstIP, proto string, dstPort uint16) (bool, string, error) {
+	src, err := netip.ParseAddr(srcIP)
+	if err != nil {
+		return false, "", fmt.Errorf("invalid source ip %q: %w", srcIP, err)
+	}
+	dst, err := netip.ParseAddr(dstIP)
+	if err != nil {
+		return false, "", fmt.Errorf("invalid destination ip %q: %w", dstIP, err)
+	}
+	src, dst = src.Unmap(), dst.Unmap()
+	if src.Is4() != dst.Is4() {
+		return false, "", fmt.Errorf("source ip %s and destination ip %s are of different address families", srcIP, dstIP)
+	}
+	proto = strings.ToLower(proto)
+	if proto != "" && !slices.Contains([]string{"tcp", "udp", "sctp"}, proto) {
+		return false, "", fmt.Errorf("invalid protocol %q: protocol must be one of tcp, udp and sctp", proto)
+	}
+
+	protocol := kubeovnv1.ProtocolIPv4
+	if src.Is6() {
+		protocol = kubeovnv1.ProtocolIPv6
+	}
+	// the members of the sets are replaced rather than modified when recorded
+	c.ipsetMembersLock.RLock()
+	members := maps.Clone(c.ipsetMembers[protocol])
+	c.ipsetMembersLock.RUnlock()
+	if members == nil {
+		return false, "", fmt.Errorf("%s ipsets have not been set up", protocol)
+	}
+
+	rules, err := c.DumpIptablesRules(protocol)
+	if err != nil {
+		klog.Errorf("failed to generate %s iptables rules: %v", protocol, err)
+		return false, "", err
+	}
+	verdict, rule := evaluateNatChain(rules, OvnPostrouting, natPacket{src: src, dst: dst, proto: proto, dstPort: dstPort}, members)
+	if verdict == natContinue {
+		return false, "no nat rule matches", nil
+	}
+	return verdict == natMasquerade, fmt.Sprintf("-t %s -A %s %s", rule.Table, rule.Chain, strings.Join(rule.RuleSpec(), " ")), nil
+}
+
 func (c *Controller) gcIPSet() {
 	protocols := make([]string, 2)
 	if c.protocol == kubeovnv1.ProtocolDual {
@@ -154,6 +780,9 @@ func (c *Controller) gcIPSet() {
 		protocols[0] = c.protocol
 	}
 
+	c.ipsetApplyLock.Lock()
+	defer c.ipsetApplyLock.Unlock()
+
 	for _, protocol := range protocols {
 		if c.ipsets[protocol] == nil {
 			continue
@@ -165,20 +794,22 @@ func (c *Controller) gcIPSet() {
 func (c *Controller) addNatOutGoingPolicyRuleIPset(rule kubeovnv1.NatOutgoingPolicyRuleStatus, protocol string) {
 	if rule.Match.SrcIPs != "" {
 		ipsetName := getNatOutGoingPolicyRuleIPSetName(rule.RuleID, "src", "", false)
+		members := strings.Split(rule.Match.SrcIPs, ",")
 		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-			MaxSize: 1048576,
+			MaxSize: c.getIPSetMaxSize(protocol, ipsetName, len(members)),
 			SetID:   ipsetName,
 			Type:    ipsets.IPSetTypeHashNet,
-		}, strings.Split(rule.Match.SrcIPs, ","))
+		}, members)
 	}
 
 	if rule.Match.DstIPs != "" {
 		ipsetName := getNatOutGoingPolicyRuleIPSetName(rule.RuleID, "dst", "", false)
+		members := strings.Split(rule.Match.DstIPs, ",")
 		c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-			MaxSize: 1048576,
+			MaxSize: c.getIPSetMaxSize(protocol, ipsetName, len(members)),
 			SetID:   ipsetName,
 			Type:    ipsets.IPSetTypeHashNet,
-		}, strings.Split(rule.Match.DstIPs, ","))
+		}, members)
 	}
 }
 
@@ -227,7 +858,7 @@ func (c *Controller) reconcileNatOutGoingPolicyIPset(protocol string) {
 	}
 
 	c.ipsets[protocol].AddOrReplaceIPSet(ipsets.IPSetMetadata{
-		MaxSize: 1048576,
+		MaxSize: c.getIPSetMaxSize(protocol, NatOutGoingPolicySubnetSet, len(subnetCidrs)),
 		SetID:   NatOutGoingPolicySubnetSet,
 		Type:    ipsets.IPSetTypeHashNet,
 	}, subnetCidrs)
@@ -415,14 +1046,15 @@ func (c *Controller) deletePolicyRouting(family int, _ string, priority, tableID
 	return nil
 }
 
-func (c *Controller) createIptablesRule(ipt *iptables.IPTables, rule util.IPTableRule) error {
-	exists, err := ipt.Exists(rule.Table, rule.Chain, rule.Rule...)
+func (c *Controller) createIptablesRule(ipt iptablesBackend, rule util.IPTableRule) error {
+	spec := rule.RuleSpec()
+	exists, err := ipt.Exists(rule.Table, rule.Chain, spec...)
 	if err != nil {
 		klog.Errorf("failed to check iptables rule existence: %v", err)
 		return err
 	}
 
-	s := strings.Join(rule.Rule, " ")
+	s := strings.Join(spec, " ")
 	if exists {
 		if rule.Table == NAT && rule.Chain == Prerouting {
 			// make sure the nat prerouting iptable rule is in the first position
@@ -437,19 +1069,19 @@ func (c *Controller) createIptablesRule(ipt *iptables.IPTables, rule util.IPTabl
 					continue
 				}
 				if i == 1 {
-					if slices.Equal(ruleSpec[2:], rule.Rule) {
-						klog.V(3).Infof("the first nat prerouting rule is %q", rule.Rule)
+					if iptablesRuleSpecEqual(ruleSpec[2:], spec, c.iptablesNft) {
+						klog.V(3).Infof("the first nat prerouting rule is %q", spec)
 						continue
 					}
 					// iptables -t nat -F could cause this case, auto fix it
-					klog.Infof("insert nat prerouting rule: %q", rule.Rule)
-					if err = ipt.Insert(rule.Table, rule.Chain, 1, rule.Rule...); err != nil {
+					klog.Infof("insert nat prerouting rule: %q", spec)
+					if err = ipt.Insert(rule.Table, rule.Chain, 1, spec...); err != nil {
 						klog.Errorf(`failed to insert iptables rule %q: %v`, s, err)
 						return err
 					}
 					return nil
 				}
-				if slices.Equal(ruleSpec[2:], rule.Rule) {
+				if iptablesRuleSpecEqual(ruleSpec[2:], spec, c.iptablesNft) {
 					rule.Pos = strconv.Itoa(i)
 					klog.Warningf("delete the nat prerouting rule: %v", rule)
 					if err = deleteIptablesRule(ipt, rule); err != nil {
@@ -463,7 +1095,7 @@ func (c *Controller) createIptablesRule(ipt *iptables.IPTables, rule util.IPTabl
 	}
 
 	klog.Infof("creating iptables rule in table %s chain %s at position %d: %q", rule.Table, rule.Chain, 1, s)
-	if err = ipt.Insert(rule.Table, rule.Chain, 1, rule.Rule...); err != nil {
+	if err = ipt.Insert(rule.Table, rule.Chain, 1, spec...); err != nil {
 		klog.Errorf(`failed to insert iptables rule "%s": %v`, s, err)
 		return err
 	}
@@ -471,7 +1103,127 @@ func (c *Controller) createIptablesRule(ipt *iptables.IPTables, rule util.IPTabl
 	return nil
 }
 
-func (c *Controller) updateIptablesChain(ipt *iptables.IPTables, table, chain, parent string, rules []util.IPTableRule) error {
+// createIptablesJumpRule creates the jump rule to a kube-ovn chain at the configured position of the parent chain,
+// the rule is moved if it is found at another position
+func (c *Controller) createIptablesJumpRule(ipt iptablesBackend, rule util.IPTableRule) error {
+	if c.config.IptablesJumpPosition == 1 {
+		// the rule is inserted at the first position as before
+		return c.createIptablesRule(ipt, rule)
+	}
+
+	rules, err := ipt.List(rule.Table, rule.Chain)
+	if err != nil {
+		klog.Errorf("failed to list iptables rules in chain %s/%s: %v", rule.Table, rule.Chain, err)
+		return err
+	}
+	spec := rule.RuleSpec()
+	pos, stale := iptablesJumpRulePosition(rules, spec, c.config.IptablesJumpPosition, c.iptablesNft)
+	for _, p := range stale {
+		klog.Infof("delete iptables rule in table %s chain %s at position %d: %q", rule.Table, rule.Chain, p, strings.Join(spec, " "))
+		if err = ipt.Delete(rule.Table, rule.Chain, strconv.Itoa(p)); err != nil {
+			klog.Errorf("failed to delete iptables rule %q: %v", strings.Join(spec, " "), err)
+			return err
+		}
+	}
+	if pos == 0 {
+		return nil
+	}
+	klog.Infof("creating iptables rule in table %s chain %s at position %d: %q", rule.Table, rule.Chain, pos, strings.Join(spec, " "))
+	if err = ipt.Insert(rule.Table, rule.Chain, pos, spec...); err != nil {
+		klog.Errorf("failed to insert iptables rule %q: %v", strings.Join(spec, " "), err)
+		return err
+	}
+	return nil
+}
+
+// iptablesJumpRulePosition returns the position to insert the rule at in the chain listed by iptables, which is 0
+// if the rule is already in place, and the positions of the existing copies to delete in descending order.
+// A position of 0 or exceeding the rule count means appending, and an existing rule is kept wherever it is
+func iptablesJumpRulePosition(existingRules, spec []string, position int, nft bool) (int, []int) {
+	var count int
+	var found []int
+	for _, rule := range existingRules {
+		fields := util.DoubleQuotedFields(rule)
+		if len(fields) < 2 || fields[0] != "-A" {
+			continue
+		}
+		count++
+		// use fields[2:] to skip prefix "-A CHAIN"
+		if iptablesRuleSpecEqual(fields[2:], spec, nft) {
+			found = append(found, count)
+		}
+	}
+	slices.Reverse(found)
+
+	if position == 0 || position > count {
+		switch len(found) {
+		case 0:
+			return count + 1, nil
+		case 1:
+			return 0, nil
+		default:
+			// keep the first one and delete the duplicates
+			return 0, found[:len(found)-1]
+		}
+	}
+	if len(found) == 1 && found[0] == position {
+		return 0, nil
+	}
+	return min(position, count-len(found)+1), found
+}
+
+// normalizeIptablesRuleSpec returns the canonical form of the rule spec to compare the rules listed by iptables with
+// the desired ones. Host addresses are suffixed with the full prefix length and match-all addresses are removed, as
+// iptables does when listing the rules. In nft mode, iptables may list the comment match at another position and
+// the implicit protocol match following -p explicitly, so the comment match is moved before the target as
+// util.IPTableRule.RuleSpec does and the implicit protocol match is removed
+func normalizeIptablesRuleSpec(spec []string, nft bool) []string {
+	normalized := make([]string, 0, len(spec))
+	var comment []string
+	for i := 0; i < len(spec); i++ {
+		switch s := spec[i]; {
+		case (s == "-s" || s == "--source" || s == "-d" || s == "--destination") && i+1 < len(spec):
+			addr := spec[i+1]
+			i++
+			if prefix, err := netip.ParsePrefix(addr); err == nil {
+				if prefix.Bits() == 0 && (len(normalized) == 0 || normalized[len(normalized)-1] != "!") {
+					continue
+				}
+				addr = prefix.Masked().String()
+			} else if ip, err := netip.ParseAddr(addr); err == nil {
+				addr = netip.PrefixFrom(ip, ip.BitLen()).String()
+			}
+			if s == "--source" || s == "--destination" {
+				s = "-" + s[2:3]
+			}
+			normalized = append(normalized, s, addr)
+		case nft && s == "-m" && i+3 < len(spec) && spec[i+1] == "comment" && spec[i+2] == "--comment":
+			comment = spec[i : i+4]
+			i += 3
+		case nft && s == "-m" && i+1 < len(spec) && i >= 2 && spec[i-2] == "-p" && spec[i+1] == spec[i-1]:
+			// implicit match of the protocol, e.g. -p tcp -m tcp
+			i++
+		default:
+			normalized = append(normalized, s)
+		}
+	}
+	if comment == nil {
+		return normalized
+	}
+	for i, s := range normalized {
+		if s == "-j" || s == "-g" {
+			return slices.Insert(normalized, i, comment...)
+		}
+	}
+	return append(normalized, comment...)
+}
+
+// iptablesRuleSpecEqual reports whether the rule spec listed by iptables is the same as the desired one
+func iptablesRuleSpecEqual(listed, spec []string, nft bool) bool {
+	return slices.Equal(normalizeIptablesRuleSpec(listed, nft), normalizeIptablesRuleSpec(spec, nft))
+}
+
+func (c *Controller) updateIptablesChain(ipt iptablesBackend, table, chain, parent string, rules []util.IPTableRule) error {
 	ok, err := ipt.ChainExists(table, chain)
 	if err != nil {
 		klog.Errorf("failed to check existence of iptables chain %s in table %s: %v", chain, table, err)
@@ -485,16 +1237,29 @@ func (c *Controller) updateIptablesChain(ipt *iptables.IPTables, table, chain, p
 		klog.Infof("created iptables chain %s in table %s", chain, table)
 	}
 	if parent != "" {
-		comment := fmt.Sprintf("kube-ovn %s rules", strings.ToLower(parent))
 		rule := util.IPTableRule{
-			Table: table,
-			Chain: parent,
-			Rule:  []string{"-m", "comment", "--comment", comment, "-j", chain},
+			Table:   table,
+			Chain:   parent,
+			Rule:    []string{"-j", chain},
+			Comment: fmt.Sprintf("kube-ovn %s rules", strings.ToLower(parent)),
 		}
-		if err = c.createIptablesRule(ipt, rule); err != nil {
+		if err = c.createIptablesJumpRule(ipt, rule); err != nil {
 			klog.Errorf("failed to create iptables rule: %v", err)
 			return err
 		}
+
+		// remove the other rules marked with the same comment, e.g. the ones jumping to a stale chain
+		parentRules, err := ipt.List(table, parent)
+		if err != nil {
+			klog.Errorf("failed to list iptables rules in chain %s/%s: %v", table, parent, err)
+			return err
+		}
+		for _, r := range getObsoleteCommentedRules(parentRules, table, parent, rule.Comment, []util.IPTableRule{rule}, c.iptablesNft) {
+			if err = deleteIptablesRule(ipt, r); err != nil {
+				klog.Error(err)
+				return err
+			}
+		}
 	}
 
 	// list existing rules
@@ -516,13 +1281,14 @@ func (c *Controller) updateIptablesChain(ipt *iptables.IPTables, table, chain, p
 
 	var added int
 	for i, rule := range rules {
-		if i-added < len(existingRules) && slices.Equal(existingRules[i-added], rule.Rule) {
-			klog.V(5).Infof("iptables rule %v already exists", rule.Rule)
+		spec := rule.RuleSpec()
+		if i-added < len(existingRules) && iptablesRuleSpecEqual(existingRules[i-added], spec, c.iptablesNft) {
+			klog.V(5).Infof("iptables rule %v already exists", spec)
 			continue
 		}
-		klog.Infof("creating iptables rule in table %s chain %s at position %d: %q", table, chain, i+1, strings.Join(rule.Rule, " "))
-		if err = ipt.Insert(table, chain, i+1, rule.Rule...); err != nil {
-			klog.Errorf(`failed to insert iptables rule %v: %v`, rule.Rule, err)
+		klog.Infof("creating iptables rule in table %s chain %s at position %d: %q", table, chain, i+1, strings.Join(spec, " "))
+		if err = ipt.Insert(table, chain, i+1, spec...); err != nil {
+			klog.Errorf(`failed to insert iptables rule %v: %v`, spec, err)
 			return err
 		}
 		added++
@@ -546,19 +1312,192 @@ func (c *Controller) setIptables() error {
 		return err
 	}
 
-	nodeIPv4, nodeIPv6 := util.GetNodeInternalIP(*node)
-	nodeIPs := map[string]string{
-		kubeovnv1.ProtocolIPv4: nodeIPv4,
-		kubeovnv1.ProtocolIPv6: nodeIPv6,
-	}
-
-	centralGwNatIPs, err := c.getEgressNatIPByNode(c.config.NodeName)
+	centralGwNatIPs, natGatewaysToRelease, err := c.getEgressNatIPByNode(c.config.NodeName, false)
 	if err != nil {
 		klog.Errorf("failed to get centralized subnets nat ips on node %s, %v", c.config.NodeName, err)
 		return err
 	}
 	klog.V(3).Infof("centralized subnets nat ips %v", centralGwNatIPs)
+	natExemptCIDRs, err := c.getExGatewayNatExemptCIDRs()
+	if err != nil {
+		klog.Errorf("failed to get nat exempt cidrs of external gateway: %v", err)
+		return err
+	}
+
+	protocols := make([]string, 0, 2)
+	if c.protocol == kubeovnv1.ProtocolDual {
+		protocols = append(protocols, kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6)
+	} else {
+		protocols = append(protocols, c.protocol)
+	}
+
+	for _, protocol := range protocols {
+		ipt := c.iptables[protocol]
+		if ipt == nil {
+			continue
+		}
+
+		ruleSet, err := c.generateGatewayIptablesRules(protocol, node, centralGwNatIPs, natExemptCIDRs[protocol])
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+
+		for _, rule := range ruleSet.staleRules {
+			if err = deleteIptablesRule(ipt, rule); err != nil {
+				klog.Errorf("failed to delete obsolete iptables rule %v: %v", rule, err)
+				return err
+			}
+		}
+
+		rules, err := ipt.List("filter", "FORWARD")
+		if err != nil {
+			klog.Errorf(`failed to list iptables rule table "filter" chain "FORWARD" with err %v `, err)
+			return err
+		}
+
+		// remove the rules of subnets which no longer exist, including the ones deleted while the daemon is down
+		for _, rule := range getObsoleteSubnetGatewayRules(rules, ruleSet.subnetGatewayRules) {
+			if err = deleteIptablesRule(ipt, rule); err != nil {
+				klog.Error(err)
+				return err
+			}
+		}
+
+		for _, rule := range ruleSet.rules {
+			if err = c.createIptablesRule(ipt, rule); err != nil {
+				klog.Errorf(`failed to create iptables rule "%s": %v`, strings.Join(rule.Rule, " "), err)
+				return err
+			}
+		}
+
+		// remove the rules of older generations after the ones of the current generation are installed
+		for _, chain := range [...]string{"INPUT", "FORWARD", "OUTPUT"} {
+			rules, err := ipt.List("filter", chain)
+			if err != nil {
+				klog.Errorf("failed to list iptables rules in chain filter/%s: %v", chain, err)
+				return err
+			}
+			for _, rule := range getPreviousGenerationRules(rules, chain, gatewayIptablesGeneration) {
+				if err = deleteIptablesRule(ipt, rule); err != nil {
+					klog.Errorf("failed to delete iptables rule of previous generation %v: %v", rule, err)
+					return err
+				}
+			}
+		}
+		// the rules created before the rules are stamped are deleted by the exact rule spec,
+		// since iptables may list them in a form other than the one they are created with.
+		// They are never created again, so they are only deleted once after the daemon starts
+		if !c.unstampedGatewayRulesDeleted.Has(protocol) {
+			for _, rule := range getUnstampedGatewayRules(ruleSet.rules) {
+				if err = deleteIptablesRule(ipt, rule); err != nil {
+					klog.Errorf("failed to delete unstamped iptables rule %v: %v", rule, err)
+					return err
+				}
+			}
+			if c.unstampedGatewayRulesDeleted == nil {
+				c.unstampedGatewayRulesDeleted = set.New[string]()
+			}
+			c.unstampedGatewayRulesDeleted.Insert(protocol)
+		}
+
+		if err = c.reconcileNatOutgoingPolicyIptablesChain(protocol); err != nil {
+			klog.Error(err)
+			return err
+		}
+
+		if err = c.reconcileTProxyIPTableRules(protocol); err != nil {
+			klog.Error(err)
+			return err
+		}
+
+		for _, chain := range ruleSet.chains {
+			if err = c.updateIptablesChain(ipt, chain.table, chain.name, chain.parent, chain.rules); err != nil {
+				klog.Errorf("failed to update chain %s/%s: %v", chain.table, chain.name, err)
+				return err
+			}
+		}
+
+		if err = c.cleanObsoleteIptablesRules(protocol, ruleSet.obsoleteRules); err != nil {
+			klog.Errorf("failed to clean legacy iptables rules: %v", err)
+			return err
+		}
+	}
+
+	// the snat rules have been removed, so it's safe for the new active gateway to take over
+	if err = c.releaseNatGateways(c.config.NodeName, natGatewaysToRelease); err != nil {
+		klog.Error(err)
+		return err
+	}
+	return nil
+}
+
+// gatewayIptablesChain is a kube-ovn iptables chain with its ordered rules,
+// which is jumped to from the parent chain if the parent is not empty
+type gatewayIptablesChain struct {
+	table  string
+	name   string
+	parent string
+	rules  []util.IPTableRule
+}
 
+// gatewayIptablesRules is the gateway iptables rule set of a protocol generated for the node
+type gatewayIptablesRules struct {
+	// rules created in the builtin chains, including the subnet gateway rules
+	rules              []util.IPTableRule
+	subnetGatewayRules []util.IPTableRule
+	// kube-ovn chains in the order of update
+	chains []gatewayIptablesChain
+	// rules of older versions deleted from the builtin chains
+	staleRules []util.IPTableRule
+	// rules of older versions cleaned by cleanObsoleteIptablesRules
+	obsoleteRules []util.IPTableRule
+}
+
+// DumpIptablesRules returns the ordered gateway iptables rules of the protocol which would be applied for the node,
+// without touching the kernel. The jump rule to each kube-ovn chain is followed by the rules of the chain.
+// The rules of the nat outgoing policy and tproxy chains are not included
+func (c *Controller) DumpIptablesRules(protocol string) ([]util.IPTableRule, error) {
+	node, err := c.nodesLister.Get(c.config.NodeName)
+	if err != nil {
+		klog.Errorf("failed to get node %s, %v", c.config.NodeName, err)
+		return nil, err
+	}
+	centralGwNatIPs, _, err := c.getEgressNatIPByNode(c.config.NodeName, true)
+	if err != nil {
+		klog.Errorf("failed to get centralized subnets nat ips on node %s, %v", c.config.NodeName, err)
+		return nil, err
+	}
+	natExemptCIDRs, err := c.getExGatewayNatExemptCIDRs()
+	if err != nil {
+		klog.Errorf("failed to get nat exempt cidrs of external gateway: %v", err)
+		return nil, err
+	}
+
+	ruleSet, err := c.generateGatewayIptablesRules(protocol, node, centralGwNatIPs, natExemptCIDRs[protocol])
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	rules := slices.Clone(ruleSet.rules)
+	for _, chain := range ruleSet.chains {
+		if chain.parent != "" {
+			rules = append(rules, util.IPTableRule{
+				Table:   chain.table,
+				Chain:   chain.parent,
+				Rule:    []string{"-j", chain.name},
+				Comment: fmt.Sprintf("kube-ovn %s rules", strings.ToLower(chain.parent)),
+			})
+		}
+		rules = append(rules, chain.rules...)
+	}
+	return rules, nil
+}
+
+// generateGatewayIptablesRules generates the gateway iptables rules of the protocol for the node,
+// the traffic of the nat outgoing subnets to natExemptCIDRs is not masqueraded
+func (c *Controller) generateGatewayIptablesRules(protocol string, node *v1.Node, centralGwNatIPs map[string]string, natExemptCIDRs []string) (*gatewayIptablesRules, error) {
 	var (
 		v4Rules = []util.IPTableRule{
 			// mark packets from pod to service
@@ -642,227 +1581,192 @@ func (c *Controller) setIptables() error {
 			{Table: MANGLE, Chain: OvnPostrouting, Rule: strings.Fields(`-p tcp -m set --match-set ovn60subnets src -m tcp --tcp-flags RST RST -m state --state INVALID -j DROP`)},
 		}
 	)
-	protocols := make([]string, 0, 2)
-	if c.protocol == kubeovnv1.ProtocolDual {
-		protocols = append(protocols, kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6)
+
+	nodeIPv4, nodeIPv6 := util.GetNodeInternalIP(*node)
+	nodeIPs := map[string]string{
+		kubeovnv1.ProtocolIPv4: nodeIPv4,
+		kubeovnv1.ProtocolIPv6: nodeIPv6,
+	}
+
+	var kubeProxyIpsetProtocol, matchset, svcMatchset, nodeMatchSet string
+	var staleRules, obsoleteRules, iptablesRules []util.IPTableRule
+	if protocol == kubeovnv1.ProtocolIPv4 {
+		iptablesRules = v4Rules
+		matchset, svcMatchset, nodeMatchSet = "ovn40subnets", "ovn40services", "ovn40"+OtherNodeSet
 	} else {
-		protocols = append(protocols, c.protocol)
+		iptablesRules = v6Rules
+		kubeProxyIpsetProtocol, matchset, svcMatchset, nodeMatchSet = "6-", "ovn60subnets", "ovn60services", "ovn60"+OtherNodeSet
 	}
 
-	for _, protocol := range protocols {
-		ipt := c.iptables[protocol]
-		if ipt == nil {
-			continue
+	ipset := fmt.Sprintf("KUBE-%sCLUSTER-IP", kubeProxyIpsetProtocol)
+	ipsetExists, err := c.ipsetExists(ipset)
+	if err != nil {
+		klog.Errorf("failed to check existence of ipset %s: %v", ipset, err)
+		return nil, err
+	}
+	if ipsetExists {
+		iptablesRules[0].Rule = strings.Fields(fmt.Sprintf(`-i ovn0 -m set --match-set %s src -m set --match-set %s dst,dst -j MARK --set-xmark 0x4000/0x4000`, matchset, ipset))
+		rejectRule := strings.Fields(fmt.Sprintf(`-p tcp -m mark ! --mark 0x4000/0x4000 -m set --match-set %s dst -m conntrack --ctstate NEW -j REJECT`, svcMatchset))
+		obsoleteRejectRule := strings.Fields(fmt.Sprintf(`-m mark ! --mark 0x4000/0x4000 -m set --match-set %s dst -m conntrack --ctstate NEW -j REJECT`, svcMatchset))
+		iptablesRules = append(iptablesRules,
+			util.IPTableRule{Table: "filter", Chain: "INPUT", Rule: rejectRule},
+			util.IPTableRule{Table: "filter", Chain: "OUTPUT", Rule: rejectRule},
+		)
+		staleRules = []util.IPTableRule{
+			{Table: "filter", Chain: "INPUT", Rule: obsoleteRejectRule},
+			{Table: "filter", Chain: "OUTPUT", Rule: obsoleteRejectRule},
 		}
+	}
 
-		var kubeProxyIpsetProtocol, matchset, svcMatchset, nodeMatchSet string
-		var obsoleteRules, iptablesRules []util.IPTableRule
-		if protocol == kubeovnv1.ProtocolIPv4 {
-			iptablesRules = v4Rules
-			matchset, svcMatchset, nodeMatchSet = "ovn40subnets", "ovn40services", "ovn40"+OtherNodeSet
-		} else {
-			iptablesRules = v6Rules
-			kubeProxyIpsetProtocol, matchset, svcMatchset, nodeMatchSet = "6-", "ovn60subnets", "ovn60services", "ovn60"+OtherNodeSet
+	if nodeIP := nodeIPs[protocol]; nodeIP != "" {
+		obsoleteRules = []util.IPTableRule{
+			{Table: NAT, Chain: Postrouting, Rule: strings.Fields(fmt.Sprintf(`! -s %s -m set --match-set %s dst -j MASQUERADE`, nodeIP, matchset))},
+			{Table: NAT, Chain: Postrouting, Rule: strings.Fields(fmt.Sprintf(`! -s %s -m mark --mark 0x4000/0x4000 -j MASQUERADE`, nodeIP))},
+			{Table: NAT, Chain: Postrouting, Rule: strings.Fields(fmt.Sprintf(`! -s %s -m set ! --match-set %s src -m set --match-set %s dst -j MASQUERADE`, nodeIP, matchset, matchset))},
 		}
 
-		ipset := fmt.Sprintf("KUBE-%sCLUSTER-IP", kubeProxyIpsetProtocol)
-		ipsetExists, err := c.ipsetExists(ipset)
-		if err != nil {
-			klog.Errorf("failed to check existence of ipset %s: %v", ipset, err)
-			return err
-		}
-		if ipsetExists {
-			iptablesRules[0].Rule = strings.Fields(fmt.Sprintf(`-i ovn0 -m set --match-set %s src -m set --match-set %s dst,dst -j MARK --set-xmark 0x4000/0x4000`, matchset, ipset))
-			rejectRule := strings.Fields(fmt.Sprintf(`-p tcp -m mark ! --mark 0x4000/0x4000 -m set --match-set %s dst -m conntrack --ctstate NEW -j REJECT`, svcMatchset))
-			obsoleteRejectRule := strings.Fields(fmt.Sprintf(`-m mark ! --mark 0x4000/0x4000 -m set --match-set %s dst -m conntrack --ctstate NEW -j REJECT`, svcMatchset))
-			iptablesRules = append(iptablesRules,
-				util.IPTableRule{Table: "filter", Chain: "INPUT", Rule: rejectRule},
-				util.IPTableRule{Table: "filter", Chain: "OUTPUT", Rule: rejectRule},
-			)
-			obsoleteRejectRules := []util.IPTableRule{
-				{Table: "filter", Chain: "INPUT", Rule: obsoleteRejectRule},
-				{Table: "filter", Chain: "OUTPUT", Rule: obsoleteRejectRule},
-			}
-			for _, rule := range obsoleteRejectRules {
-				if err = deleteIptablesRule(ipt, rule); err != nil {
-					klog.Errorf("failed to delete obsolete iptables rule %v: %v", rule, err)
-					return err
-				}
-			}
+		rules := make([]util.IPTableRule, len(iptablesRules)+1)
+		copy(rules, iptablesRules[:1])
+		copy(rules[2:], iptablesRules[1:])
+		rules[1] = util.IPTableRule{
+			Table: NAT,
+			Chain: OvnPostrouting,
+			Rule:  strings.Fields(fmt.Sprintf(`-m set --match-set %s src -m set --match-set %s dst -m mark --mark 0x4000/0x4000 -j SNAT --to-source %s`, svcMatchset, matchset, nodeIP)),
 		}
+		iptablesRules = rules
 
-		if nodeIP := nodeIPs[protocol]; nodeIP != "" {
-			obsoleteRules = []util.IPTableRule{
-				{Table: NAT, Chain: Postrouting, Rule: strings.Fields(fmt.Sprintf(`! -s %s -m set --match-set %s dst -j MASQUERADE`, nodeIP, matchset))},
-				{Table: NAT, Chain: Postrouting, Rule: strings.Fields(fmt.Sprintf(`! -s %s -m mark --mark 0x4000/0x4000 -j MASQUERADE`, nodeIP))},
-				{Table: NAT, Chain: Postrouting, Rule: strings.Fields(fmt.Sprintf(`! -s %s -m set ! --match-set %s src -m set --match-set %s dst -j MASQUERADE`, nodeIP, matchset, matchset))},
-			}
-
-			rules := make([]util.IPTableRule, len(iptablesRules)+1)
-			copy(rules, iptablesRules[:1])
-			copy(rules[2:], iptablesRules[1:])
-			rules[1] = util.IPTableRule{
-				Table: NAT,
-				Chain: OvnPostrouting,
-				Rule:  strings.Fields(fmt.Sprintf(`-m set --match-set %s src -m set --match-set %s dst -m mark --mark 0x4000/0x4000 -j SNAT --to-source %s`, svcMatchset, matchset, nodeIP)),
+		for _, p := range [...]string{"tcp", "udp"} {
+			ipset := fmt.Sprintf("KUBE-%sNODE-PORT-LOCAL-%s", kubeProxyIpsetProtocol, strings.ToUpper(p))
+			ipsetExists, err := c.ipsetExists(ipset)
+			if err != nil {
+				klog.Errorf("failed to check existence of ipset %s: %v", ipset, err)
+				return nil, err
 			}
-			iptablesRules = rules
-
-			for _, p := range [...]string{"tcp", "udp"} {
-				ipset := fmt.Sprintf("KUBE-%sNODE-PORT-LOCAL-%s", kubeProxyIpsetProtocol, strings.ToUpper(p))
-				ipsetExists, err := c.ipsetExists(ipset)
-				if err != nil {
-					klog.Errorf("failed to check existence of ipset %s: %v", ipset, err)
-					return err
-				}
-				if !ipsetExists {
-					klog.V(5).Infof("ipset %s does not exist", ipset)
-					continue
-				}
-				rule := fmt.Sprintf("-p %s -m addrtype --dst-type LOCAL -m set --match-set %s dst -j MARK --set-xmark 0x80000/0x80000", p, ipset)
-				rule2 := fmt.Sprintf("-p %s -m set --match-set %s src -m set --match-set %s dst -j MARK --set-xmark 0x4000/0x4000", p, nodeMatchSet, ipset)
-				obsoleteRules = append(obsoleteRules, util.IPTableRule{Table: NAT, Chain: Prerouting, Rule: strings.Fields(rule)})
-				iptablesRules = append(iptablesRules,
-					util.IPTableRule{Table: NAT, Chain: OvnPrerouting, Rule: strings.Fields(rule)},
-					util.IPTableRule{Table: NAT, Chain: OvnPrerouting, Rule: strings.Fields(rule2)},
-				)
+			if !ipsetExists {
+				klog.V(5).Infof("ipset %s does not exist", ipset)
+				continue
 			}
-		}
-
-		_, subnetCidrs, err := c.getDefaultVpcSubnetsCIDR(protocol)
-		if err != nil {
-			klog.Errorf("get subnets failed, %+v", err)
-			return err
-		}
-
-		subnetNames := set.New[string]()
-		for name, subnetCidr := range subnetCidrs {
-			subnetNames.Insert(name)
+			rule := fmt.Sprintf("-p %s -m addrtype --dst-type LOCAL -m set --match-set %s dst -j MARK --set-xmark 0x80000/0x80000", p, ipset)
+			rule2 := fmt.Sprintf("-p %s -m set --match-set %s src -m set --match-set %s dst -j MARK --set-xmark 0x4000/0x4000", p, nodeMatchSet, ipset)
+			obsoleteRules = append(obsoleteRules, util.IPTableRule{Table: NAT, Chain: Prerouting, Rule: strings.Fields(rule)})
 			iptablesRules = append(iptablesRules,
-				util.IPTableRule{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(fmt.Sprintf(`-m comment --comment %s,%s -s %s`, util.OvnSubnetGatewayIptables, name, subnetCidr))},
-				util.IPTableRule{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(fmt.Sprintf(`-m comment --comment %s,%s -d %s`, util.OvnSubnetGatewayIptables, name, subnetCidr))},
+				util.IPTableRule{Table: NAT, Chain: OvnPrerouting, Rule: strings.Fields(rule)},
+				util.IPTableRule{Table: NAT, Chain: OvnPrerouting, Rule: strings.Fields(rule2)},
 			)
 		}
+	}
 
-		rules, err := ipt.List("filter", "FORWARD")
-		if err != nil {
-			klog.Errorf(`failed to list iptables rule table "filter" chain "FORWARD" with err %v `, err)
-			return err
+	_, subnetCidrs, err := c.getDefaultVpcSubnetsCIDR(protocol)
+	if err != nil {
+		klog.Errorf("get subnets failed, %+v", err)
+		return nil, err
+	}
+
+	subnetGatewayRules := make([]util.IPTableRule, 0, 2*len(subnetCidrs))
+	for _, name := range slices.Sorted(maps.Keys(subnetCidrs)) {
+		subnetGatewayRules = append(subnetGatewayRules,
+			util.IPTableRule{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(fmt.Sprintf(`-m comment --comment %s,%s -s %s`, util.OvnSubnetGatewayIptables, name, subnetCidrs[name]))},
+			util.IPTableRule{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(fmt.Sprintf(`-m comment --comment %s,%s -d %s`, util.OvnSubnetGatewayIptables, name, subnetCidrs[name]))},
+		)
+	}
+	// stamp the rules in the builtin filter chains with the generation, the subnet gateway rules have their own comment
+	for i := range iptablesRules {
+		if iptablesRules[i].Table == "filter" {
+			iptablesRules[i].Comment = gatewayIptablesGenerationComment(gatewayIptablesGeneration)
 		}
+	}
+	iptablesRules = append(iptablesRules, subnetGatewayRules...)
 
-		pattern := fmt.Sprintf(`-m comment --comment "%s,`, util.OvnSubnetGatewayIptables)
-		for _, rule := range rules {
-			if !strings.Contains(rule, pattern) {
-				continue
+	hasRandomFully := c.k8siptables[protocol].HasRandomFully()
+	ruleSet := &gatewayIptablesRules{
+		subnetGatewayRules: subnetGatewayRules,
+		staleRules:         staleRules,
+		obsoleteRules:      obsoleteRules,
+	}
+	var natPreroutingRules, natPostroutingRules, ovnMasqueradeRules, manglePostroutingRules []util.IPTableRule
+	for _, rule := range iptablesRules {
+		if rule.Table == NAT {
+			if hasRandomFully &&
+				(rule.Rule[len(rule.Rule)-1] == "MASQUERADE" || slices.Contains(rule.Rule, "SNAT")) {
+				rule.Rule = append(rule.Rule, "--random-fully")
 			}
-			fields := util.DoubleQuotedFields(rule)
-			// -A FORWARD -d 10.16.0.0/16 -m comment --comment "ovn-subnet-gateway,ovn-default"
-			if len(fields) != 8 || fields[6] != "--comment" {
+
+			switch rule.Chain {
+			case OvnPrerouting:
+				natPreroutingRules = append(natPreroutingRules, rule)
 				continue
-			}
-			commentFields := strings.Split(fields[7], ",")
-			if len(commentFields) != 2 {
+			case OvnPostrouting:
+				natPostroutingRules = append(natPostroutingRules, rule)
 				continue
-			}
-			if subnetNames.Has(commentFields[1]) {
+			case OvnMasquerade:
+				ovnMasqueradeRules = append(ovnMasqueradeRules, rule)
 				continue
 			}
-
-			// use fields[2:] to skip prefix "-A FORWARD"
-			if err = deleteIptablesRule(ipt, util.IPTableRule{Table: "filter", Chain: "FORWARD", Rule: fields[2:]}); err != nil {
-				klog.Error(err)
-				return err
-			}
-		}
-
-		var natPreroutingRules, natPostroutingRules, ovnMasqueradeRules, manglePostroutingRules []util.IPTableRule
-		for _, rule := range iptablesRules {
-			if rule.Table == NAT {
-				if c.k8siptables[protocol].HasRandomFully() &&
-					(rule.Rule[len(rule.Rule)-1] == "MASQUERADE" || slices.Contains(rule.Rule, "SNAT")) {
-					rule.Rule = append(rule.Rule, "--random-fully")
-				}
-
-				switch rule.Chain {
-				case OvnPrerouting:
-					natPreroutingRules = append(natPreroutingRules, rule)
-					continue
-				case OvnPostrouting:
-					natPostroutingRules = append(natPostroutingRules, rule)
-					continue
-				case OvnMasquerade:
-					ovnMasqueradeRules = append(ovnMasqueradeRules, rule)
-					continue
-				}
-			} else if rule.Table == MANGLE {
-				if rule.Chain == OvnPostrouting {
-					manglePostroutingRules = append(manglePostroutingRules, rule)
-					continue
-				}
-			}
-
-			if err = c.createIptablesRule(ipt, rule); err != nil {
-				klog.Errorf(`failed to create iptables rule "%s": %v`, strings.Join(rule.Rule, " "), err)
-				return err
+		} else if rule.Table == MANGLE {
+			if rule.Chain == OvnPostrouting {
+				manglePostroutingRules = append(manglePostroutingRules, rule)
+				continue
 			}
 		}
+		ruleSet.rules = append(ruleSet.rules, rule)
+	}
 
-		var randomFully string
-		if c.k8siptables[protocol].HasRandomFully() {
-			randomFully = "--random-fully"
-		}
+	if c.config.EnableETPLocalNoMasq {
+		// preserve source ip of external traffic to local endpoints of services with external traffic policy set to local
+		natPostroutingRules = append([]util.IPTableRule{etpLocalNatExclusionRule(protocol)}, natPostroutingRules...)
+	}
 
-		// add iptables rule for nat gw with designative ip in centralized subnet
-		for cidr, ip := range centralGwNatIPs {
-			if util.CheckProtocol(cidr) != protocol {
-				continue
-			}
+	var randomFully string
+	if hasRandomFully {
+		randomFully = "--random-fully"
+	}
 
-			s := fmt.Sprintf("-s %s -m set ! --match-set %s dst -j SNAT --to-source %s %s", cidr, matchset, ip, randomFully)
-			rule := util.IPTableRule{
-				Table: NAT,
-				Chain: OvnPostrouting,
-				Rule:  util.DoubleQuotedFields(s),
-			}
-			// insert the rule before the one for nat outgoing
-			n := len(natPostroutingRules)
-			natPostroutingRules = append(natPostroutingRules[:n-1], rule, natPostroutingRules[n-1])
+	// add iptables rule for nat gw with designative ip in centralized subnet
+	for _, cidr := range slices.Sorted(maps.Keys(centralGwNatIPs)) {
+		if util.CheckProtocol(cidr) != protocol {
+			continue
 		}
 
-		if err = c.reconcileNatOutgoingPolicyIptablesChain(protocol); err != nil {
-			klog.Error(err)
-			return err
-		}
+		// insert the rule before the one for nat outgoing
+		n := len(natPostroutingRules)
+		natPostroutingRules = append(natPostroutingRules[:n-1], subnetSnatRule(cidr, centralGwNatIPs[cidr], matchset, randomFully), natPostroutingRules[n-1])
+	}
 
-		if err = c.reconcileTProxyIPTableRules(protocol); err != nil {
-			klog.Error(err)
-			return err
-		}
+	// add iptables rule for nat outgoing subnets with snat ip configured
+	snatRules, err := c.subnetSnatRules(protocol, matchset, randomFully)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	n := len(natPostroutingRules)
+	natPostroutingRules = slices.Concat(natPostroutingRules[:n-1], snatRules, natPostroutingRules[n-1:])
 
-		if err = c.updateIptablesChain(ipt, NAT, OvnPrerouting, Prerouting, natPreroutingRules); err != nil {
-			klog.Errorf("failed to update chain %s/%s: %v", NAT, OvnPrerouting, err)
-			return err
-		}
-		if err = c.updateIptablesChain(ipt, NAT, OvnMasquerade, "", ovnMasqueradeRules); err != nil {
-			klog.Errorf("failed to update chain %s/%s: %v", NAT, OvnMasquerade, err)
-			return err
-		}
-		if err = c.updateIptablesChain(ipt, NAT, OvnPostrouting, Postrouting, natPostroutingRules); err != nil {
-			klog.Errorf("failed to update chain %s/%s: %v", NAT, OvnPostrouting, err)
-			return err
-		}
+	if len(c.config.NatOutgoingPortMatches) != 0 {
+		// only masquerade the nat outgoing traffic to the configured destination ports
+		n := len(natPostroutingRules)
+		natPostroutingRules = append(natPostroutingRules[:n-1], natOutgoingPortRules(natPostroutingRules[n-1], c.config.NatOutgoingPortMatches)...)
+	}
+	natPostroutingRules = natExemptRules(natPostroutingRules, strings.TrimSuffix(matchset, SubnetSet)+SubnetNatSet, natExemptCIDRs)
+	natPostroutingRules = natGatewayRules(natPostroutingRules, node, c.config.NatGatewaySelector)
 
-		if err = c.updateIptablesChain(ipt, MANGLE, OvnPostrouting, Postrouting, manglePostroutingRules); err != nil {
-			klog.Errorf("failed to update chain %s/%s: %v", MANGLE, OvnPostrouting, err)
-			return err
-		}
+	// preserve the dscp values of the nat outgoing traffic, the rules of the subnets are matched before the global ones
+	subnetsDSCP, err := c.getSubnetsNatPreserveDSCP(protocol)
+	if err != nil {
+		klog.Errorf("failed to get preserved dscp values of nat outgoing subnets: %v", err)
+		return nil, err
+	}
+	for _, cidr := range slices.Sorted(maps.Keys(subnetsDSCP)) {
+		manglePostroutingRules = append(manglePostroutingRules, natDSCPPreserveRules("-s "+cidr, matchset, subnetsDSCP[cidr])...)
+	}
+	manglePostroutingRules = append(manglePostroutingRules, natDSCPPreserveRules("-m set --match-set "+strings.TrimSuffix(matchset, SubnetSet)+SubnetNatSet+" src", matchset, c.config.NatPreserveDSCPValues)...)
 
-		if err = c.cleanObsoleteIptablesRules(protocol, obsoleteRules); err != nil {
-			klog.Errorf("failed to clean legacy iptables rules: %v", err)
-			return err
-		}
+	ruleSet.chains = []gatewayIptablesChain{
+		{table: NAT, name: OvnPrerouting, parent: Prerouting, rules: natPreroutingRules},
+		{table: NAT, name: OvnMasquerade, rules: ovnMasqueradeRules},
+		{table: NAT, name: OvnPostrouting, parent: Postrouting, rules: natPostroutingRules},
+		{table: MANGLE, name: OvnPostrouting, parent: Postrouting, rules: manglePostroutingRules},
 	}
-	return nil
+	return ruleSet, nil
 }
 
 func (c *Controller) reconcileTProxyIPTableRules(protocol string) error {
@@ -1068,7 +1972,122 @@ func (c *Controller) generateNatOutgoingPolicyChainRules(protocol string) ([]uti
 	return natPolicySubnetIptables, natPolicyRuleIptablesMap, gcNatPolicySubnetChains, nil
 }
 
-func deleteIptablesRule(ipt *iptables.IPTables, rule util.IPTableRule) error {
+// subnetGatewayRuleKey returns a key identifying a subnet gateway rule by its comment, direction and normalized cidr
+func subnetGatewayRuleKey(comment, direction, cidr string) string {
+	if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+		cidr = ipNet.String()
+	}
+	return strings.Join([]string{comment, direction, cidr}, " ")
+}
+
+// getObsoleteSubnetGatewayRules returns the existing subnet gateway rules in chain filter/FORWARD
+// which are not in the desired rules
+func getObsoleteSubnetGatewayRules(existingRules []string, desiredRules []util.IPTableRule) []util.IPTableRule {
+	desired := set.New[string]()
+	for _, rule := range desiredRules {
+		// -m comment --comment ovn-subnet-gateway,ovn-default -d 10.16.0.0/16
+		if len(rule.Rule) != 6 {
+			continue
+		}
+		desired.Insert(subnetGatewayRuleKey(rule.Rule[3], rule.Rule[4], rule.Rule[5]))
+	}
+
+	pattern := fmt.Sprintf(`-m comment --comment "%s,`, util.OvnSubnetGatewayIptables)
+	var obsoleteRules []util.IPTableRule
+	for _, rule := range existingRules {
+		if !strings.Contains(rule, pattern) {
+			continue
+		}
+		fields := util.DoubleQuotedFields(rule)
+		// -A FORWARD -d 10.16.0.0/16 -m comment --comment "ovn-subnet-gateway,ovn-default"
+		if len(fields) != 8 || fields[6] != "--comment" {
+			continue
+		}
+		if len(strings.Split(fields[7], ",")) != 2 {
+			continue
+		}
+		if desired.Has(subnetGatewayRuleKey(fields[7], fields[2], fields[3])) {
+			continue
+		}
+		// use fields[2:] to skip prefix "-A FORWARD"
+		obsoleteRules = append(obsoleteRules, util.IPTableRule{Table: "filter", Chain: "FORWARD", Rule: fields[2:]})
+	}
+	return obsoleteRules
+}
+
+// gatewayIptablesGenerationComment returns the comment stamping the gateway rules of the generation
+func gatewayIptablesGenerationComment(generation int) string {
+	return gatewayIptablesGenerationPrefix + strconv.Itoa(generation)
+}
+
+// getPreviousGenerationRules returns the rules of the builtin filter chain listed by iptables which are stamped
+// with a generation older than the current one. The rules of newer generations are kept for the daemons of the
+// new version during rolling updates
+func getPreviousGenerationRules(existingRules []string, chain string, generation int) []util.IPTableRule {
+	var rules []util.IPTableRule
+	for _, rule := range existingRules {
+		fields := util.DoubleQuotedFields(rule)
+		if len(fields) < 2 || fields[0] != "-A" || fields[1] != chain {
+			continue
+		}
+		// use fields[2:] to skip prefix "-A CHAIN"
+		spec := fields[2:]
+		for i := 0; i+1 < len(spec); i++ {
+			if spec[i] == "--comment" && strings.HasPrefix(spec[i+1], gatewayIptablesGenerationPrefix) {
+				gen, err := strconv.Atoi(strings.TrimPrefix(spec[i+1], gatewayIptablesGenerationPrefix))
+				if err == nil && gen < generation {
+					rules = append(rules, util.IPTableRule{Table: "filter", Chain: chain, Rule: spec})
+				}
+				break
+			}
+		}
+	}
+	return rules
+}
+
+// getUnstampedGatewayRules returns the stamped rules without the generation comment
+func getUnstampedGatewayRules(rules []util.IPTableRule) []util.IPTableRule {
+	var unstamped []util.IPTableRule
+	for _, rule := range rules {
+		if strings.HasPrefix(rule.Comment, gatewayIptablesGenerationPrefix) {
+			rule.Comment = ""
+			unstamped = append(unstamped, rule)
+		}
+	}
+	return unstamped
+}
+
+// getObsoleteCommentedRules returns the rules of the chain listed by iptables which are marked with the comment
+// but not in the desired rules, the rules without the comment are never returned
+func getObsoleteCommentedRules(existingRules []string, table, chain, comment string, desiredRules []util.IPTableRule, nft bool) []util.IPTableRule {
+	desired := set.New[string]()
+	for _, rule := range desiredRules {
+		desired.Insert(strings.Join(normalizeIptablesRuleSpec(rule.RuleSpec(), nft), " "))
+	}
+
+	var obsoleteRules []util.IPTableRule
+	for _, rule := range existingRules {
+		fields := util.DoubleQuotedFields(rule)
+		if len(fields) < 2 || fields[0] != "-A" || fields[1] != chain {
+			continue
+		}
+		// use fields[2:] to skip prefix "-A CHAIN"
+		spec := fields[2:]
+		marked := false
+		for i := 0; i+1 < len(spec); i++ {
+			if spec[i] == "--comment" && spec[i+1] == comment {
+				marked = true
+				break
+			}
+		}
+		if marked && !desired.Has(strings.Join(normalizeIptablesRuleSpec(spec, nft), " ")) {
+			obsoleteRules = append(obsoleteRules, util.IPTableRule{Table: table, Chain: chain, Rule: spec})
+		}
+	}
+	return obsoleteRules
+}
+
+func deleteIptablesRule(ipt iptablesBackend, rule util.IPTableRule) error {
 	if rule.Pos != "" {
 		klog.Infof("delete iptables rule by pos %s: %v", rule.Pos, rule)
 		if err := ipt.Delete(rule.Table, rule.Chain, rule.Pos); err != nil {
@@ -1077,19 +2096,20 @@ func deleteIptablesRule(ipt *iptables.IPTables, rule util.IPTableRule) error {
 		}
 		return nil
 	}
-	exists, err := ipt.Exists(rule.Table, rule.Chain, rule.Rule...)
+	spec := rule.RuleSpec()
+	exists, err := ipt.Exists(rule.Table, rule.Chain, spec...)
 	if err == nil && exists {
 		klog.Infof("delete iptables rule: %v", rule)
-		err = ipt.Delete(rule.Table, rule.Chain, rule.Rule...)
+		err = ipt.Delete(rule.Table, rule.Chain, spec...)
 	}
 	if err != nil {
-		klog.Errorf("failed to delete iptables rule %q: %v", strings.Join(rule.Rule, " "), err)
+		klog.Errorf("failed to delete iptables rule %q: %v", strings.Join(spec, " "), err)
 		return err
 	}
 	return nil
 }
 
-func clearObsoleteIptablesChain(ipt *iptables.IPTables, table, chain, parent string) error {
+func clearObsoleteIptablesChain(ipt iptablesBackend, table, chain, parent string) error {
 	exists, err := ipt.ChainExists(table, chain)
 	if err != nil {
 		klog.Error(err)
@@ -1099,8 +2119,13 @@ func clearObsoleteIptablesChain(ipt *iptables.IPTables, table, chain, parent str
 		return nil
 	}
 
-	rule := fmt.Sprintf(`-m comment --comment "kube-ovn %s rules" -j %s`, strings.ToLower(parent), chain)
-	if err = deleteIptablesRule(ipt, util.IPTableRule{Table: table, Chain: parent, Rule: util.DoubleQuotedFields(rule)}); err != nil {
+	rule := util.IPTableRule{
+		Table:   table,
+		Chain:   parent,
+		Rule:    []string{"-j", chain},
+		Comment: fmt.Sprintf("kube-ovn %s rules", strings.ToLower(parent)),
+	}
+	if err = deleteIptablesRule(ipt, rule); err != nil {
 		klog.Error(err)
 		return err
 	}
@@ -1378,8 +2403,17 @@ func (c *Controller) setExGateway() error {
 		klog.Errorf("failed to get node, %v", err)
 		return err
 	}
-	var isUserspaceDP bool
-	isUserspaceDP, err = ovs.IsUserspaceDataPath()
+	// the ovs-vsctl commands are bounded by the timeout like the ones run by ovs.ExecWithTimeout
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayOvsExecTimeout)
+	err = reconcileOvnBridgeMappings(ctx)
+	cancel()
+	if err != nil {
+		klog.Errorf("failed to reconcile ovn-bridge-mappings: %v", err)
+		return err
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), gatewayOvsExecTimeout)
+	isUserspaceDP, err := ovs.IsUserspaceDataPathContext(ctx)
+	cancel()
 	if err != nil {
 		klog.Error(err)
 		return err
@@ -1414,12 +2448,12 @@ func (c *Controller) setExGateway() error {
 
 		externalBrReady := false
 		// if external nic already attached into another bridge
-		if existBr, err := ovs.Exec("port-to-br", linkName); err == nil {
+		if existBr, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, "port-to-br", linkName); err == nil {
 			if existBr == externalBridge {
 				externalBrReady = true
 			} else {
 				klog.Infof("external bridge should change from %s to %s, delete external bridge %s", existBr, externalBridge, existBr)
-				if _, err := ovs.Exec(ovs.IfExists, "del-br", existBr); err != nil {
+				if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, ovs.IfExists, "del-br", existBr); err != nil {
 					err = fmt.Errorf("failed to del external br %s, %w", existBr, err)
 					klog.Error(err)
 					return err
@@ -1428,8 +2462,22 @@ func (c *Controller) setExGateway() error {
 		}
 
 		if !externalBrReady {
+			if !isUserspaceDP && exGatewayKeepNicAddr(cm.Data) {
+				// the addresses and routes are transferred only once when the nic is attached to the bridge,
+				// which must exist before the transfer
+				if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, ovs.MayExist, "add-br", externalBridge); err != nil {
+					err = fmt.Errorf("failed to create external bridge %s, %w", externalBridge, err)
+					klog.Error(err)
+					return err
+				}
+				if _, err := c.transferAddrsAndRoutes(linkName, externalBridge, false); err != nil {
+					klog.Errorf("failed to transfer addresses and routes from %s to %s: %v", linkName, externalBridge, err)
+					return err
+				}
+			}
+
 			klog.Infof("create external bridge %s and add nic %s", externalBridge, linkName)
-			if _, err := ovs.Exec(
+			if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout,
 				ovs.MayExist, "add-br", externalBridge, "--",
 				ovs.MayExist, "add-port", externalBridge, linkName,
 			); err != nil {
@@ -1437,12 +2485,17 @@ func (c *Controller) setExGateway() error {
 				klog.Error(err)
 			}
 		}
-		if err = addOvnMapping("ovn-bridge-mappings", c.config.ExternalGatewaySwitch, externalBridge, true); err != nil {
+		ctx, cancel = context.WithTimeout(context.Background(), gatewayOvsExecTimeout)
+		err = addOvnMapping(ctx, "ovn-bridge-mappings", c.config.ExternalGatewaySwitch, externalBridge, true)
+		cancel()
+		if err != nil {
 			klog.Error(err)
 			return err
 		}
 	} else {
-		brExists, err := ovs.BridgeExists(externalBridge)
+		ctx, cancel = context.WithTimeout(context.Background(), gatewayOvsExecTimeout)
+		brExists, err := ovs.BridgeExistsContext(ctx, externalBridge)
+		cancel()
 		if err != nil {
 			return fmt.Errorf("failed to check OVS bridge existence: %w", err)
 		}
@@ -1458,7 +2511,7 @@ func (c *Controller) setExGateway() error {
 
 		for _, pn := range providerNetworks {
 			// if external nic already attached into another bridge
-			if existBr, err := ovs.Exec("port-to-br", pn.Spec.DefaultInterface); err == nil {
+			if existBr, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, "port-to-br", pn.Spec.DefaultInterface); err == nil {
 				if existBr == externalBridge {
 					// delete switch after related provider network not exist
 					return nil
@@ -1480,8 +2533,16 @@ func (c *Controller) setExGateway() error {
 		}
 
 		if !isUserspaceDP && !keepExternalSubnet {
+			kept, err := c.removeExGatewayNic(externalBridge)
+			if err != nil {
+				klog.Error(err)
+				return err
+			}
+			if kept {
+				return nil
+			}
 			klog.Infof("delete external bridge %s", externalBridge)
-			if _, err := ovs.Exec(
+			if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout,
 				ovs.IfExists, "del-br", externalBridge); err != nil {
 				err = fmt.Errorf("failed to disable external gateway, %w", err)
 				klog.Error(err)
@@ -1608,7 +2669,7 @@ func (c *Controller) getSubnetsNeedPR(protocol string) (map[policyRouteMeta]stri
 	return subnetsNeedPR, nil
 }
 
-func (c *Controller) deleteObsoleteSnatRules(ipt *iptables.IPTables, table, chain string) error {
+func (c *Controller) deleteObsoleteSnatRules(ipt iptablesBackend, table, chain string) error {
 	rules, err := ipt.List(table, chain)
 	if err != nil {
 		klog.Errorf("failed to list iptables rules in table %v chain %v, %+v", table, chain, err)
@@ -1673,3 +2734,296 @@ func getNatPolicySubnetChainUID(chainName string) string {
 func formatIPsetUnPrefix(ipsetName string) string {
 	return ipsetName[len("ovn40"):]
 }
+
+// TeardownGateway reverses what runGateway sets up on the node: the policy routing rules,
+// the iptables chains and rules, the ipsets and the external gateway bridge.
+// Only the state marked as managed by kube-ovn is removed, and it's safe to be called multiple times.
+// It's called when the daemon exits with TeardownGatewayOnExit, after which the ipsets are never applied again
+func (c *Controller) TeardownGateway() error {
+	protocols := make([]string, 2)
+	if c.protocol == kubeovnv1.ProtocolDual {
+		protocols[0] = kubeovnv1.ProtocolIPv4
+		protocols[1] = kubeovnv1.ProtocolIPv6
+	} else {
+		protocols[0] = c.protocol
+	}
+
+	// the deferred applies would recreate the ipsets, even if the iptables rules referencing them fail to be removed
+	c.stopIPSetApplies()
+
+	var errs []error
+	for _, protocol := range protocols {
+		if protocol == "" {
+			continue
+		}
+		if err := c.teardownPolicyRouting(protocol); err != nil {
+			errs = append(errs, err)
+		}
+		// the iptables rules reference the ipsets, so they must be removed first
+		if err := c.teardownIptables(protocol); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := c.teardownIPSets(protocol); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := c.teardownExGateway(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// stopIPSetApplies cancels the deferred ipset applies and prevents setIPSet from applying the ipsets again
+func (c *Controller) stopIPSetApplies() {
+	c.ipsetApplyLock.Lock()
+	defer c.ipsetApplyLock.Unlock()
+
+	c.ipsetApplyStopped = true
+	for protocol, timer := range c.ipsetApplyTimers {
+		timer.Stop()
+		delete(c.ipsetApplyTimers, protocol)
+	}
+}
+
+func (c *Controller) teardownPolicyRouting(protocol string) error {
+	localPodIPs, err := c.getLocalPodIPsNeedPR(protocol)
+	if err != nil {
+		klog.Errorf("failed to get local pod ips: %v", err)
+		return err
+	}
+	subnetsNeedPR, err := c.getSubnetsNeedPR(protocol)
+	if err != nil {
+		klog.Errorf("failed to get subnets that need policy routing: %v", err)
+		return err
+	}
+	family, err := util.ProtocolToFamily(protocol)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	for meta, ips := range localPodIPs {
+		if err = c.deletePolicyRouting(family, meta.gateway, meta.priority, meta.tableID, ips...); err != nil {
+			klog.Errorf("failed to delete policy routing for local pods: %v", err)
+			return err
+		}
+	}
+	for meta, cidr := range subnetsNeedPR {
+		if err = c.deletePolicyRouting(family, meta.gateway, meta.priority, meta.tableID, cidr); err != nil {
+			klog.Errorf("failed to delete policy routing for subnet: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) teardownIptables(protocol string) error {
+	ipt := c.iptables[protocol]
+	if ipt == nil {
+		return nil
+	}
+
+	setPrefix := "ovn40"
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		setPrefix = "ovn60"
+	}
+	for _, chain := range [...]string{"INPUT", "FORWARD", "OUTPUT"} {
+		rules, err := ipt.List("filter", chain)
+		if err != nil {
+			klog.Errorf("failed to list iptables rules in chain filter/%s: %v", chain, err)
+			return err
+		}
+		for _, rule := range getManagedFilterRules(rules, chain, setPrefix) {
+			if err = deleteIptablesRule(ipt, rule); err != nil {
+				klog.Error(err)
+				return err
+			}
+		}
+	}
+	// the overlay tunnel unmark rules created before the rules are stamped carry no marker, so delete them by the exact rule spec
+	for _, port := range [...]string{"6081", "4789"} {
+		rule := util.IPTableRule{Table: "filter", Chain: "OUTPUT", Rule: strings.Fields(fmt.Sprintf(`-p udp -m udp --dport %s -j MARK --set-xmark 0x0`, port))}
+		if err := deleteIptablesRule(ipt, rule); err != nil {
+			klog.Error(err)
+			return err
+		}
+	}
+
+	for _, table := range [...]string{NAT, MANGLE} {
+		chains, err := ipt.ListChains(table)
+		if err != nil {
+			klog.Errorf("failed to list iptables chains in table %s: %v", table, err)
+			return err
+		}
+		managedChains := getManagedIptablesChains(chains)
+		if len(managedChains) == 0 {
+			continue
+		}
+
+		// remove the jump rules marked with the kube-ovn comment from the builtin chains
+		for _, parent := range [...]string{Prerouting, Postrouting, Output} {
+			rules, err := ipt.List(table, parent)
+			if err != nil {
+				klog.Errorf("failed to list iptables rules in chain %s/%s: %v", table, parent, err)
+				return err
+			}
+			comment := fmt.Sprintf("kube-ovn %s rules", strings.ToLower(parent))
+			for _, rule := range getObsoleteCommentedRules(rules, table, parent, comment, nil, c.iptablesNft) {
+				if err = deleteIptablesRule(ipt, rule); err != nil {
+					klog.Error(err)
+					return err
+				}
+			}
+		}
+		// the managed chains may jump to each other, so flush all of them before deletion
+		for _, chain := range managedChains {
+			if err = ipt.ClearChain(table, chain); err != nil {
+				klog.Errorf("failed to clear iptables chain %s in table %s: %v", chain, table, err)
+				return err
+			}
+		}
+		for _, chain := range managedChains {
+			if err = ipt.DeleteChain(table, chain); err != nil {
+				klog.Errorf("failed to delete iptables chain %s in table %s: %v", chain, table, err)
+				return err
+			}
+			klog.Infof("deleted iptables chain %s in table %s", chain, table)
+		}
+	}
+	return nil
+}
+
+// getManagedFilterRules returns the rules of the builtin filter chain created by kube-ovn,
+// which either match the kube-ovn ipsets or are marked with the subnet gateway or generation comment
+func getManagedFilterRules(existingRules []string, chain, setPrefix string) []util.IPTableRule {
+	var rules []util.IPTableRule
+	for _, rule := range existingRules {
+		fields := util.DoubleQuotedFields(rule)
+		if len(fields) < 2 || fields[0] != "-A" || fields[1] != chain {
+			continue
+		}
+		spec := fields[2:]
+		for i := 0; i+1 < len(spec); i++ {
+			if (spec[i] == "--match-set" && strings.HasPrefix(spec[i+1], setPrefix)) ||
+				(spec[i] == "--comment" && (strings.HasPrefix(spec[i+1], util.OvnSubnetGatewayIptables+",") ||
+					strings.HasPrefix(spec[i+1], gatewayIptablesGenerationPrefix))) {
+				rules = append(rules, util.IPTableRule{Table: "filter", Chain: chain, Rule: spec})
+				break
+			}
+		}
+	}
+	return rules
+}
+
+// getManagedIptablesChains returns the chains created by kube-ovn
+func getManagedIptablesChains(chains []string) []string {
+	var managed []string
+	for _, chain := range chains {
+		switch {
+		case chain == OvnPrerouting, chain == OvnPostrouting, chain == OvnOutput,
+			chain == OvnMasquerade, chain == OvnNatOutGoingPolicy,
+			strings.HasPrefix(chain, OvnNatOutGoingPolicySubnet):
+			managed = append(managed, chain)
+		}
+	}
+	return managed
+}
+
+func (c *Controller) teardownIPSets(protocol string) error {
+	if c.ipsets[protocol] == nil {
+		return nil
+	}
+
+	c.ipsetApplyLock.Lock()
+	defer c.ipsetApplyLock.Unlock()
+	// the deferred apply would recreate the ipsets after they are removed
+	if timer := c.ipsetApplyTimers[protocol]; timer != nil {
+		timer.Stop()
+		delete(c.ipsetApplyTimers, protocol)
+	}
+	delete(c.ipsetLastApply, protocol)
+
+	sets, err := c.k8sipsets.ListSets()
+	if err != nil {
+		klog.Errorf("failed to list ipsets: %v", err)
+		return err
+	}
+	setPrefix := "ovn40"
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		setPrefix = "ovn60"
+	}
+	setIDs := set.New[string]()
+	for _, name := range sets {
+		if strings.HasPrefix(name, setPrefix) {
+			setIDs.Insert(formatIPsetUnPrefix(name))
+		}
+	}
+
+	c.ipsetMembersLock.Lock()
+	for setID := range c.ipsetMembers[protocol] {
+		setIDs.Insert(setID)
+	}
+	delete(c.ipsetMembers, protocol)
+	delete(c.ipsetAppliedAt, protocol)
+	c.ipsetMembersLock.Unlock()
+	c.ipsetMaxSizesLock.Lock()
+	delete(c.ipsetMaxSizes, protocol)
+	c.ipsetMaxSizesLock.Unlock()
+
+	for _, setID := range setIDs.SortedList() {
+		klog.Infof("remove ipset %s%s", setPrefix, setID)
+		c.ipsets[protocol].RemoveIPSet(setID)
+	}
+	c.ipsets[protocol].ApplyUpdates()
+	c.ipsets[protocol].ApplyDeletions()
+	return nil
+}
+
+// teardownExGateway deletes the external gateway bridge, which is recognized by its ovn-bridge-mappings entry
+func (c *Controller) teardownExGateway() error {
+	externalBridge := util.ExternalBridgeName(c.config.ExternalGatewaySwitch)
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayOvsExecTimeout)
+	mappings, err := getOvnMappings(ctx, "ovn-bridge-mappings")
+	cancel()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if mappings[c.config.ExternalGatewaySwitch] != externalBridge {
+		return nil
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), gatewayOvsExecTimeout)
+	exists, err := ovs.BridgeExistsContext(ctx, externalBridge)
+	cancel()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if exists {
+		cm, err := c.config.KubeClient.CoreV1().ConfigMaps(c.config.ExternalGatewayConfigNS).Get(context.Background(), util.ExternalGatewayConfig, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get ovn-external-gw-config, %v", err)
+			return err
+		}
+		if cm != nil && cm.Data["external-gw-nic"] != "" && exGatewayKeepNicAddr(cm.Data) {
+			if err = c.restoreExGatewayNic(cm.Data["external-gw-nic"], externalBridge); err != nil {
+				return err
+			}
+		}
+		klog.Infof("delete external bridge %s", externalBridge)
+		if _, err = ovs.ExecWithTimeout(gatewayOvsExecTimeout, ovs.IfExists, "del-br", externalBridge); err != nil {
+			err = fmt.Errorf("failed to delete external bridge %s, %w", externalBridge, err)
+			klog.Error(err)
+			return err
+		}
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), gatewayOvsExecTimeout)
+	defer cancel()
+	if err = removeOvnMapping(ctx, "ovn-bridge-mappings", c.config.ExternalGatewaySwitch); err != nil {
+		klog.Errorf("failed to remove ovn-bridge-mappings of %s: %v", c.config.ExternalGatewaySwitch, err)
+		return err
+	}
+	return nil
+}
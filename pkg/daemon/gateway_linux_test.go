@@ -0,0 +1,1617 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeovn/felix/ipsets"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+	coordinationlisterv1 "k8s.io/client-go/listers/coordination/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	k8sipset "k8s.io/kubernetes/pkg/proxy/ipvs/ipset"
+	ipsetfake "k8s.io/kubernetes/pkg/proxy/ipvs/ipset/testing"
+	k8siptables "k8s.io/kubernetes/pkg/util/iptables"
+	iptablestest "k8s.io/kubernetes/pkg/util/iptables/testing"
+	"k8s.io/utils/set"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func TestGetObsoleteSubnetGatewayRules(t *testing.T) {
+	desired := []util.IPTableRule{
+		{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(`-m comment --comment ovn-subnet-gateway,ovn-default -s 10.16.0.0/16`)},
+		{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(`-m comment --comment ovn-subnet-gateway,ovn-default -d 10.16.0.0/16`)},
+		{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(`-m comment --comment ovn-subnet-gateway,foo -s 10.17.0.0/16`)},
+		{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(`-m comment --comment ovn-subnet-gateway,foo -d 10.17.0.0/16`)},
+	}
+	existing := []string{
+		`-P FORWARD ACCEPT`,
+		`-A FORWARD -m set --match-set ovn40subnets src -j ACCEPT`,
+		`-A FORWARD -s 10.16.0.0/16 -m comment --comment "ovn-subnet-gateway,ovn-default"`,
+		`-A FORWARD -d 10.16.0.0/16 -m comment --comment "ovn-subnet-gateway,ovn-default"`,
+		// subnet foo has been recreated with another cidr
+		`-A FORWARD -s 10.18.0.0/16 -m comment --comment "ovn-subnet-gateway,foo"`,
+		`-A FORWARD -s 10.17.0.0/16 -m comment --comment "ovn-subnet-gateway,foo"`,
+		`-A FORWARD -d 10.17.0.0/16 -m comment --comment "ovn-subnet-gateway,foo"`,
+		// subnet bar has been deleted while the daemon is down
+		`-A FORWARD -s 10.19.0.0/16 -m comment --comment "ovn-subnet-gateway,bar"`,
+		`-A FORWARD -d 10.19.0.0/16 -m comment --comment "ovn-subnet-gateway,bar"`,
+	}
+
+	expected := []util.IPTableRule{
+		{Table: "filter", Chain: "FORWARD", Rule: []string{"-s", "10.18.0.0/16", "-m", "comment", "--comment", "ovn-subnet-gateway,foo"}},
+		{Table: "filter", Chain: "FORWARD", Rule: []string{"-s", "10.19.0.0/16", "-m", "comment", "--comment", "ovn-subnet-gateway,bar"}},
+		{Table: "filter", Chain: "FORWARD", Rule: []string{"-d", "10.19.0.0/16", "-m", "comment", "--comment", "ovn-subnet-gateway,bar"}},
+	}
+	require.Equal(t, expected, getObsoleteSubnetGatewayRules(existing, desired))
+	require.Empty(t, getObsoleteSubnetGatewayRules(existing[:4], desired))
+}
+
+func TestGetObsoleteCommentedRules(t *testing.T) {
+	comment := "kube-ovn postrouting rules"
+	desired := []util.IPTableRule{{Table: NAT, Chain: Postrouting, Rule: []string{"-j", OvnPostrouting}, Comment: comment}}
+	existing := []string{
+		`-P POSTROUTING ACCEPT`,
+		`-A POSTROUTING -m comment --comment "kube-ovn postrouting rules" -j OVN-POSTROUTING`,
+		// jumping to a stale chain
+		`-A POSTROUTING -m comment --comment "kube-ovn postrouting rules" -j OVN-POSTROUTING-OLD`,
+		// rules not managed by kube-ovn
+		`-A POSTROUTING -m comment --comment "kubernetes postrouting rules" -j KUBE-POSTROUTING`,
+		`-A POSTROUTING -j OVN-POSTROUTING-OLD`,
+		`-A OVN-POSTROUTING -m comment --comment "kube-ovn postrouting rules" -j OVN-POSTROUTING-OLD`,
+	}
+
+	expected := []util.IPTableRule{
+		{Table: NAT, Chain: Postrouting, Rule: []string{"-m", "comment", "--comment", comment, "-j", "OVN-POSTROUTING-OLD"}},
+	}
+	require.Equal(t, expected, getObsoleteCommentedRules(existing, NAT, Postrouting, comment, desired, false))
+	require.Empty(t, getObsoleteCommentedRules(existing[:2], NAT, Postrouting, comment, desired, false))
+	require.Empty(t, getObsoleteCommentedRules(existing, NAT, Postrouting, "kube-ovn prerouting rules", desired, false))
+}
+
+func TestRecordIPSetMembers(t *testing.T) {
+	c := &Controller{ControllerRuntime: ControllerRuntime{ipsetMembers: make(map[string]map[string]set.Set[string])}}
+
+	added, removed := c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetNatSet, []string{"10.16.0.0/16", "10.17.0.0/16"})
+	require.Equal(t, []string{"10.16.0.0/16", "10.17.0.0/16"}, added)
+	require.Empty(t, removed)
+
+	added, removed = c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetNatSet, []string{"10.17.0.0/16", "10.18.0.0/16"})
+	require.Equal(t, []string{"10.18.0.0/16"}, added)
+	require.Equal(t, []string{"10.16.0.0/16"}, removed)
+
+	added, removed = c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetNatSet, []string{"10.18.0.0/16", "10.17.0.0/16"})
+	require.Empty(t, added)
+	require.Empty(t, removed)
+
+	// members of other sets and protocols are recorded separately
+	added, removed = c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetSet, []string{"10.16.0.0/16"})
+	require.Equal(t, []string{"10.16.0.0/16"}, added)
+	require.Empty(t, removed)
+	added, removed = c.recordIPSetMembers(kubeovnv1.ProtocolIPv6, SubnetNatSet, nil)
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
+
+func TestGetAppliedIPSetMembers(t *testing.T) {
+	c := &Controller{ControllerRuntime: ControllerRuntime{ipsetMembers: make(map[string]map[string]set.Set[string])}}
+	require.Empty(t, c.GetAppliedIPSetMembers(kubeovnv1.ProtocolIPv4))
+
+	before := time.Now()
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetNatSet, []string{"10.17.0.0/16", "10.16.0.0/16"})
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetSet, []string{"10.16.0.0/16"})
+	snapshots := c.GetAppliedIPSetMembers(kubeovnv1.ProtocolIPv4)
+	require.Len(t, snapshots, 2)
+	require.Equal(t, []string{"10.16.0.0/16", "10.17.0.0/16"}, snapshots[SubnetNatSet].Members)
+	require.False(t, snapshots[SubnetNatSet].AppliedAt.Before(before))
+
+	// the snapshot reflects the most recent applied members
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetNatSet, []string{"10.18.0.0/16"})
+	latest := c.GetAppliedIPSetMembers(kubeovnv1.ProtocolIPv4)
+	require.Equal(t, []string{"10.18.0.0/16"}, latest[SubnetNatSet].Members)
+	require.False(t, latest[SubnetNatSet].AppliedAt.Before(snapshots[SubnetNatSet].AppliedAt))
+	require.Equal(t, snapshots[SubnetSet], latest[SubnetSet])
+	require.Empty(t, c.GetAppliedIPSetMembers(kubeovnv1.ProtocolIPv6))
+}
+
+func TestETPLocalNoMasq(t *testing.T) {
+	newPod := func(name, nodeName, subnet, ips string, labels map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    labels,
+				Annotations: map[string]string{
+					util.IPAddressAnnotation: ips,
+					fmt.Sprintf(util.LogicalSwitchAnnotationTemplate, util.OvnProvider): subnet,
+				},
+			},
+			Spec: v1.PodSpec{NodeName: nodeName},
+		}
+	}
+	newService := func(name string, svcType v1.ServiceType, policy v1.ServiceExternalTrafficPolicy, selector map[string]string) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1.ServiceSpec{
+				Type:                  svcType,
+				ExternalTrafficPolicy: policy,
+				Selector:              selector,
+			},
+		}
+	}
+
+	distributed := newTestSubnet("ovn-default", "10.16.0.0/16,fd00:10:16::/64", false)
+	distributed.Spec.GatewayType = kubeovnv1.GWDistributedType
+	centralized := newTestSubnet("centralized", "10.17.0.0/16", false)
+	centralized.Spec.GatewayType = kubeovnv1.GWCentralizedType
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolDual, distributed, centralized)
+	for _, pod := range []*v1.Pod{
+		newPod("local", "node1", "ovn-default", "10.16.0.10,fd00:10:16::a", map[string]string{"app": "local"}),
+		newPod("remote", "node2", "ovn-default", "10.16.0.11", map[string]string{"app": "local"}),
+		newPod("cluster", "node1", "ovn-default", "10.16.0.12", map[string]string{"app": "cluster"}),
+		newPod("centralized", "node1", "centralized", "10.17.0.10", map[string]string{"app": "local"}),
+	} {
+		require.NoError(t, f.pods.Add(pod))
+	}
+	for _, svc := range []*v1.Service{
+		newService("local", v1.ServiceTypeLoadBalancer, v1.ServiceExternalTrafficPolicyLocal, map[string]string{"app": "local"}),
+		newService("local-node-port", v1.ServiceTypeNodePort, v1.ServiceExternalTrafficPolicyLocal, map[string]string{"app": "local"}),
+		newService("cluster", v1.ServiceTypeLoadBalancer, v1.ServiceExternalTrafficPolicyCluster, map[string]string{"app": "cluster"}),
+	} {
+		require.NoError(t, f.services.Add(svc))
+	}
+	c := f.c
+
+	// the pod in the centralized gateway subnet is excluded
+	ips, err := c.getETPLocalPodIPs(kubeovnv1.ProtocolIPv4)
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.16.0.10"}, ips)
+	ips, err = c.getETPLocalPodIPs(kubeovnv1.ProtocolIPv6)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fd00:10:16::a"}, ips)
+
+	rule := etpLocalNatExclusionRule(kubeovnv1.ProtocolIPv4)
+	require.Equal(t, OvnPostrouting, rule.Chain)
+	require.Equal(t, strings.Fields("-m set ! --match-set ovn40subnets src -m set --match-set ovn40subnets-distributed-gw dst -m set --match-set ovn40etp-local-pod dst -j RETURN"), rule.Rule)
+	rule = etpLocalNatExclusionRule(kubeovnv1.ProtocolIPv6)
+	require.Equal(t, strings.Fields("-m set ! --match-set ovn60subnets src -m set --match-set ovn60subnets-distributed-gw dst -m set --match-set ovn60etp-local-pod dst -j RETURN"), rule.Rule)
+}
+
+// fakeIPSets records the members and max sizes of the ipsets applied by the controller
+type fakeIPSets struct {
+	pending  map[string][]string
+	applied  map[string][]string
+	maxSizes map[string]int
+	// applyFailure makes ApplyUpdates panic like the ipset backend does when the updates still fail after retries
+	applyFailure any
+}
+
+func newFakeIPSets() *fakeIPSets {
+	return &fakeIPSets{
+		pending:  make(map[string][]string),
+		applied:  make(map[string][]string),
+		maxSizes: make(map[string]int),
+	}
+}
+
+func (f *fakeIPSets) AddOrReplaceIPSet(setMetadata ipsets.IPSetMetadata, members []string) {
+	f.pending[setMetadata.SetID] = members
+	f.maxSizes[setMetadata.SetID] = setMetadata.MaxSize
+}
+
+func (f *fakeIPSets) RemoveIPSet(setID string) {
+	delete(f.pending, setID)
+}
+
+func (f *fakeIPSets) ApplyUpdates() {
+	if f.applyFailure != nil {
+		panic(f.applyFailure)
+	}
+	f.applied = make(map[string][]string, len(f.pending))
+	for setID, members := range f.pending {
+		f.applied[setID] = members
+	}
+}
+
+func (f *fakeIPSets) ApplyDeletions() {}
+
+// fakeIptables is an in-memory iptables, which lists the rules in the form of iptables -S with the arguments
+// they are created with
+type fakeIptables struct {
+	// chains of the tables in the order of creation, beginning with the builtin ones
+	chains   map[string][]string
+	builtins set.Set[string]
+	// rules of the chains, indexed by table and chain
+	rules map[string]map[string][][]string
+}
+
+func newFakeIptables() *fakeIptables {
+	f := &fakeIptables{
+		chains:   make(map[string][]string),
+		builtins: set.New[string](),
+		rules:    make(map[string]map[string][][]string),
+	}
+	for table, chains := range map[string][]string{
+		"filter": {"INPUT", "FORWARD", "OUTPUT"},
+		NAT:      {Prerouting, "INPUT", Output, Postrouting},
+		MANGLE:   {Prerouting, "INPUT", "FORWARD", Output, Postrouting},
+	} {
+		f.rules[table] = make(map[string][][]string)
+		for _, chain := range chains {
+			f.chains[table] = append(f.chains[table], chain)
+			f.rules[table][chain] = nil
+		}
+		f.builtins.Insert(chains...)
+	}
+	return f
+}
+
+func (f *fakeIptables) chainRules(table, chain string) ([][]string, error) {
+	rules, ok := f.rules[table][chain]
+	if !ok {
+		return nil, fmt.Errorf("no chain %s in table %s", chain, table)
+	}
+	return rules, nil
+}
+
+func (f *fakeIptables) Exists(table, chain string, rulespec ...string) (bool, error) {
+	rules, err := f.chainRules(table, chain)
+	if err != nil {
+		return false, nil
+	}
+	return slices.ContainsFunc(rules, func(rule []string) bool { return slices.Equal(rule, rulespec) }), nil
+}
+
+func (f *fakeIptables) Insert(table, chain string, pos int, rulespec ...string) error {
+	rules, err := f.chainRules(table, chain)
+	if err != nil {
+		return err
+	}
+	if pos < 1 || pos > len(rules)+1 {
+		return fmt.Errorf("index %d of insertion too big for chain %s in table %s", pos, chain, table)
+	}
+	f.rules[table][chain] = slices.Insert(rules, pos-1, slices.Clone(rulespec))
+	return nil
+}
+
+// Delete deletes the rule by the spec or by the position if the spec is a single number
+func (f *fakeIptables) Delete(table, chain string, rulespec ...string) error {
+	rules, err := f.chainRules(table, chain)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(rules, func(rule []string) bool { return slices.Equal(rule, rulespec) })
+	if len(rulespec) == 1 {
+		if pos, err := strconv.Atoi(rulespec[0]); err == nil {
+			i = pos - 1
+		}
+	}
+	if i < 0 || i >= len(rules) {
+		return fmt.Errorf("no rule %v in chain %s of table %s", rulespec, chain, table)
+	}
+	f.rules[table][chain] = slices.Delete(rules, i, i+1)
+	return nil
+}
+
+func (f *fakeIptables) List(table, chain string) ([]string, error) {
+	rules, err := f.chainRules(table, chain)
+	if err != nil {
+		return nil, err
+	}
+	list := []string{"-N " + chain}
+	if f.builtins.Has(chain) {
+		list[0] = "-P " + chain + " ACCEPT"
+	}
+	for _, rule := range rules {
+		fields := make([]string, 0, len(rule))
+		for _, field := range rule {
+			if strings.Contains(field, " ") {
+				field = strconv.Quote(field)
+			}
+			fields = append(fields, field)
+		}
+		list = append(list, fmt.Sprintf("-A %s %s", chain, strings.Join(fields, " ")))
+	}
+	return list, nil
+}
+
+func (f *fakeIptables) ListWithCounters(table, chain string) ([]string, error) {
+	return f.List(table, chain)
+}
+
+func (f *fakeIptables) ListChains(table string) ([]string, error) {
+	return slices.Clone(f.chains[table]), nil
+}
+
+func (f *fakeIptables) ChainExists(table, chain string) (bool, error) {
+	_, ok := f.rules[table][chain]
+	return ok, nil
+}
+
+func (f *fakeIptables) NewChain(table, chain string) error {
+	if _, ok := f.rules[table][chain]; ok {
+		return fmt.Errorf("chain %s already exists in table %s", chain, table)
+	}
+	f.chains[table] = append(f.chains[table], chain)
+	f.rules[table][chain] = nil
+	return nil
+}
+
+// ClearChain flushes the chain, which is created if not exists
+func (f *fakeIptables) ClearChain(table, chain string) error {
+	if _, ok := f.rules[table][chain]; !ok {
+		return f.NewChain(table, chain)
+	}
+	f.rules[table][chain] = nil
+	return nil
+}
+
+// DeleteChain deletes the chain, which must be empty and not referenced by other chains
+func (f *fakeIptables) DeleteChain(table, chain string) error {
+	rules, err := f.chainRules(table, chain)
+	if err != nil {
+		return err
+	}
+	if len(rules) != 0 {
+		return fmt.Errorf("chain %s in table %s is not empty", chain, table)
+	}
+	for parent, rules := range f.rules[table] {
+		for _, rule := range rules {
+			if i := slices.IndexFunc(rule, func(s string) bool { return s == "-j" || s == "-g" }); i >= 0 && i+1 < len(rule) && rule[i+1] == chain {
+				return fmt.Errorf("chain %s in table %s is referenced by chain %s", chain, table, parent)
+			}
+		}
+	}
+	f.chains[table] = slices.DeleteFunc(f.chains[table], func(s string) bool { return s == chain })
+	delete(f.rules[table], chain)
+	return nil
+}
+
+func (f *fakeIptables) ClearAndDeleteChain(table, chain string) error {
+	if _, ok := f.rules[table][chain]; !ok {
+		return nil
+	}
+	if err := f.ClearChain(table, chain); err != nil {
+		return err
+	}
+	return f.DeleteChain(table, chain)
+}
+
+// dump lists the chains and rules of all the tables
+func (f *fakeIptables) dump() []string {
+	var dump []string
+	for _, table := range [...]string{"filter", NAT, MANGLE} {
+		for _, chain := range f.chains[table] {
+			rules, _ := f.List(table, chain)
+			for _, rule := range rules {
+				dump = append(dump, table+" "+rule)
+			}
+		}
+	}
+	return dump
+}
+
+// gatewayTestFixture is the controller of node1 for the gateway tests, whose listers are backed by the indexers
+// and whose iptables and ipsets of the protocols are the fakes
+type gatewayTestFixture struct {
+	c          *Controller
+	subnets    cache.Indexer
+	nodes      cache.Indexer
+	pods       cache.Indexer
+	services   cache.Indexer
+	configMaps cache.Indexer
+	leases     cache.Indexer
+	ipsets     map[string]*fakeIPSets
+	iptables   map[string]*fakeIptables
+	k8sipsets  *ipsetfake.FakeIPSet
+	kubeClient *fake.Clientset
+}
+
+func newGatewayTestFixture(t *testing.T, protocol string, subnets ...*kubeovnv1.Subnet) *gatewayTestFixture {
+	t.Helper()
+	namespaceIndexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	f := &gatewayTestFixture{
+		subnets:    cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}),
+		nodes:      cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}),
+		pods:       cache.NewIndexer(cache.MetaNamespaceKeyFunc, namespaceIndexers),
+		services:   cache.NewIndexer(cache.MetaNamespaceKeyFunc, namespaceIndexers),
+		configMaps: cache.NewIndexer(cache.MetaNamespaceKeyFunc, namespaceIndexers),
+		leases:     cache.NewIndexer(cache.MetaNamespaceKeyFunc, namespaceIndexers),
+		ipsets:     make(map[string]*fakeIPSets),
+		iptables:   make(map[string]*fakeIptables),
+		k8sipsets:  ipsetfake.NewFake(""),
+		kubeClient: fake.NewSimpleClientset(),
+	}
+	for _, subnet := range subnets {
+		require.NoError(t, f.subnets.Add(subnet))
+	}
+	require.NoError(t, f.nodes.Add(newTestNode("node1")))
+
+	f.c = &Controller{
+		config: &Configuration{
+			ClusterRouter:           "ovn-cluster",
+			NodeName:                "node1",
+			KubeClient:              f.kubeClient,
+			ExternalGatewayConfigNS: "kube-system",
+			ExternalGatewaySwitch:   "external",
+		},
+		protocol:         protocol,
+		subnetsLister:    kubeovnlister.NewSubnetLister(f.subnets),
+		nodesLister:      listerv1.NewNodeLister(f.nodes),
+		podsLister:       listerv1.NewPodLister(f.pods),
+		servicesLister:   listerv1.NewServiceLister(f.services),
+		configMapsLister: listerv1.NewConfigMapLister(f.configMaps),
+		leasesLister:     coordinationlisterv1.NewLeaseLister(f.leases),
+		ControllerRuntime: ControllerRuntime{
+			iptables:         make(map[string]iptablesBackend),
+			ipsets:           make(map[string]ipsetBackend),
+			k8siptables:      make(map[string]k8siptables.Interface),
+			k8sipsets:        f.k8sipsets,
+			ipsetMembers:     make(map[string]map[string]set.Set[string]),
+			conntrackDeleter: func(netlink.InetFamily, netlink.CustomConntrackFilter) (uint, error) { return 0, nil },
+			addrLister:       func(int) ([]netlink.Addr, error) { return nil, nil },
+		},
+	}
+	protocols := []string{protocol}
+	if protocol == kubeovnv1.ProtocolDual {
+		protocols = []string{kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6}
+	}
+	for _, p := range protocols {
+		f.ipsets[p], f.iptables[p] = newFakeIPSets(), newFakeIptables()
+		f.c.ipsets[p], f.c.iptables[p], f.c.k8siptables[p] = f.ipsets[p], f.iptables[p], iptablestest.NewFake()
+	}
+	return f
+}
+
+// newTestSubnet returns a subnet of the default vpc, whose protocol is the one of the cidr
+func newTestSubnet(name, cidr string, natOutgoing bool) *kubeovnv1.Subnet {
+	return &kubeovnv1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kubeovnv1.SubnetSpec{
+			Vpc:         "ovn-cluster",
+			CIDRBlock:   cidr,
+			Protocol:    util.CheckProtocol(cidr),
+			NatOutgoing: natOutgoing,
+		},
+	}
+}
+
+// newTestNode returns a node with the internal ips
+func newTestNode(name string, ips ...string) *v1.Node {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, ip := range ips {
+		node.Status.Addresses = append(node.Status.Addresses, v1.NodeAddress{Type: v1.NodeInternalIP, Address: ip})
+	}
+	return node
+}
+
+func TestSetIPSetApplyFailure(t *testing.T) {
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4, newTestSubnet("ovn-default", "10.16.0.0/16", true))
+	// the failures are counted by a global metric, which is labeled with the node name only used in this test
+	require.NoError(t, f.nodes.Add(newTestNode("ipset-apply-failure-node")))
+	c := f.c
+	c.config.NodeName = "ipset-apply-failure-node"
+	c.config.ServiceClusterIPRange = "10.96.0.0/12"
+	fake := f.ipsets[kubeovnv1.ProtocolIPv4]
+	fake.applyFailure = "ipset restore failed"
+	failures := func(setID string) float64 {
+		return testutil.ToFloat64(metricIPSetApplyFailures.WithLabelValues(c.config.NodeName, kubeovnv1.ProtocolIPv4, setID))
+	}
+
+	require.ErrorContains(t, c.setIPSet(), "ipset restore failed")
+	require.Equal(t, float64(1), failures(SubnetSet))
+	require.Equal(t, float64(1), failures(SubnetNatSet))
+	require.Empty(t, c.GetAppliedIPSetMembers(kubeovnv1.ProtocolIPv4))
+
+	// the sets are still changed in the next cycle since the failed apply is not recorded
+	var recorded []string
+	c.ipsetApplyRecorder = func(_ string, sets []string, _ time.Duration, failed bool) {
+		require.False(t, failed)
+		recorded = sets
+	}
+	fake.applyFailure = nil
+	require.NoError(t, c.setIPSet())
+	require.Contains(t, recorded, SubnetSet)
+	require.Contains(t, recorded, SubnetNatSet)
+	require.Equal(t, float64(1), failures(SubnetSet))
+	require.ElementsMatch(t, []string{"10.16.0.0/16"}, fake.applied[SubnetNatSet])
+}
+
+func TestSetIPSetMinApplyInterval(t *testing.T) {
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4, newTestSubnet("ovn-default", "10.16.0.0/16", true))
+	c, fake := f.c, f.ipsets[kubeovnv1.ProtocolIPv4]
+	c.config.ServiceClusterIPRange = "10.96.0.0/12"
+	c.config.IPSetMinApplyInterval = 200 * time.Millisecond
+	var applies int
+	c.ipsetApplyRecorder = func(string, []string, time.Duration, bool) {
+		applies++
+	}
+	// reads the state changed by the deferred applies
+	locked := func(fn func()) {
+		c.ipsetApplyLock.Lock()
+		defer c.ipsetApplyLock.Unlock()
+		fn()
+	}
+
+	require.NoError(t, c.setIPSet())
+	require.Equal(t, 1, applies)
+
+	// the burst of changes within the interval is applied once at the end of it
+	for i, cidr := range []string{"10.17.0.0/16", "10.18.0.0/16", "10.19.0.0/16"} {
+		require.NoError(t, f.subnets.Add(newTestSubnet(fmt.Sprintf("subnet%d", i), cidr, true)))
+		require.NoError(t, c.setIPSet())
+	}
+	locked(func() {
+		require.Equal(t, 1, applies)
+		require.ElementsMatch(t, []string{"10.16.0.0/16"}, fake.applied[SubnetNatSet])
+	})
+	require.Eventually(t, func() bool {
+		c.ipsetApplyLock.Lock()
+		defer c.ipsetApplyLock.Unlock()
+		return applies == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	locked(func() {
+		require.ElementsMatch(t, []string{"10.16.0.0/16", "10.17.0.0/16", "10.18.0.0/16", "10.19.0.0/16"}, fake.applied[SubnetNatSet])
+		require.Empty(t, c.ipsetApplyTimers)
+	})
+
+	time.Sleep(300 * time.Millisecond)
+	locked(func() { require.Equal(t, 2, applies) })
+
+	// no interval applies every change
+	c.config.IPSetMinApplyInterval = 0
+	require.NoError(t, c.setIPSet())
+	require.NoError(t, c.setIPSet())
+	require.Equal(t, 4, applies)
+
+	// tearing down the ipsets cancels the deferred apply
+	c.config.IPSetMinApplyInterval = 200 * time.Millisecond
+	require.NoError(t, f.subnets.Add(newTestSubnet("subnet3", "10.20.0.0/16", true)))
+	require.NoError(t, c.setIPSet())
+	locked(func() { require.Len(t, c.ipsetApplyTimers, 1) })
+	require.NoError(t, c.teardownIPSets(kubeovnv1.ProtocolIPv4))
+	time.Sleep(300 * time.Millisecond)
+	locked(func() {
+		require.Equal(t, 4, applies)
+		require.Empty(t, c.ipsetApplyTimers)
+		require.NotContains(t, fake.applied, SubnetNatSet)
+	})
+}
+
+func TestSetIPSetNatConntrackFlush(t *testing.T) {
+	subnet := newTestSubnet("ovn-default", "10.16.0.0/16", true)
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4, subnet)
+	c := f.c
+	c.config.ServiceClusterIPRange = "10.96.0.0/12"
+	var filters []netlink.CustomConntrackFilter
+	c.conntrackDeleter = func(family netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error) {
+		require.Equal(t, netlink.InetFamily(netlink.FAMILY_V4), family)
+		filters = append(filters, filter)
+		return 1, nil
+	}
+
+	// enabling nat outgoing does not touch conntrack
+	require.NoError(t, c.setIPSet())
+	require.NoError(t, c.setIPSet())
+	require.Empty(t, filters)
+
+	disabled := subnet.DeepCopy()
+	disabled.Spec.NatOutgoing = false
+	require.NoError(t, f.subnets.Update(disabled))
+	require.NoError(t, c.setIPSet())
+	require.Len(t, filters, 1)
+
+	// only the masqueraded connections from the subnet are deleted
+	newFlow := func(src, replyDst string) *netlink.ConntrackFlow {
+		flow := &netlink.ConntrackFlow{}
+		flow.Forward.SrcIP, flow.Forward.DstIP = net.ParseIP(src), net.ParseIP("1.1.1.1")
+		flow.Reverse.SrcIP, flow.Reverse.DstIP = net.ParseIP("1.1.1.1"), net.ParseIP(replyDst)
+		return flow
+	}
+	require.True(t, filters[0].MatchConntrackFlow(newFlow("10.16.0.5", "172.18.0.2")))
+	require.False(t, filters[0].MatchConntrackFlow(newFlow("10.16.0.5", "10.16.0.5")))
+	require.False(t, filters[0].MatchConntrackFlow(newFlow("10.17.0.5", "172.18.0.2")))
+
+	require.NoError(t, c.setIPSet())
+	require.Len(t, filters, 1)
+}
+
+func TestSetIPSetDeferredNatConntrackFlush(t *testing.T) {
+	subnet := newTestSubnet("ovn-default", "10.16.0.0/16", true)
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4, subnet)
+	c, fake := f.c, f.ipsets[kubeovnv1.ProtocolIPv4]
+	c.config.ServiceClusterIPRange = "10.96.0.0/12"
+	c.config.IPSetMinApplyInterval = 200 * time.Millisecond
+	var flushed []string
+	c.conntrackDeleter = func(_ netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error) {
+		// the conntrack entries are flushed only after the cidr is removed from the applied ipset
+		require.NotContains(t, fake.applied[SubnetNatSet], "10.16.0.0/16")
+		flushed = append(flushed, filter.(natConntrackFilter).cidr.String())
+		return 1, nil
+	}
+	// locked runs fn with ipsetApplyLock held, which serializes the deferred apply
+	locked := func(fn func()) {
+		c.ipsetApplyLock.Lock()
+		defer c.ipsetApplyLock.Unlock()
+		fn()
+	}
+	require.NoError(t, c.setIPSet())
+
+	// disabling nat outgoing within the min apply interval flushes nothing until the deferred apply
+	disabled := subnet.DeepCopy()
+	disabled.Spec.NatOutgoing = false
+	require.NoError(t, f.subnets.Update(disabled))
+	require.NoError(t, c.setIPSet())
+	locked(func() {
+		require.Contains(t, fake.applied[SubnetNatSet], "10.16.0.0/16")
+		require.Empty(t, flushed)
+	})
+	require.Eventually(t, func() bool {
+		c.ipsetApplyLock.Lock()
+		defer c.ipsetApplyLock.Unlock()
+		return len(flushed) != 0
+	}, 5*time.Second, 10*time.Millisecond)
+	locked(func() { require.Equal(t, []string{"10.16.0.0/16"}, flushed) })
+
+	// toggling nat outgoing back and forth within the interval leaves the applied ipset and conntrack unchanged
+	locked(func() { c.config.IPSetMinApplyInterval = time.Second })
+	require.NoError(t, f.subnets.Update(subnet))
+	require.NoError(t, c.setIPSet())
+	require.NoError(t, f.subnets.Update(disabled))
+	require.NoError(t, c.setIPSet())
+	time.Sleep(1200 * time.Millisecond)
+	locked(func() {
+		require.Empty(t, c.ipsetApplyTimers)
+		require.Equal(t, []string{"10.16.0.0/16"}, flushed)
+	})
+}
+
+func TestSetIPSet(t *testing.T) {
+	underlay := newTestSubnet("underlay", "10.18.0.0/16", true)
+	underlay.Spec.Vlan = "vlan1"
+	customVpc := newTestSubnet("custom-vpc", "10.19.0.0/16", true)
+	customVpc.Spec.Vpc = "vpc1"
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolDual,
+		newTestSubnet("ovn-default", "10.16.0.0/16,fd00:10:16::/64", true),
+		newTestSubnet("no-nat", "10.17.0.0/16", false),
+		underlay,
+		customVpc,
+	)
+	require.NoError(t, f.nodes.Update(newTestNode("node1", "172.18.0.2", "fc00:f853:ccd:e793::2")))
+	require.NoError(t, f.nodes.Add(newTestNode("node2", "172.18.0.3", "fc00:f853:ccd:e793::3")))
+	c, fakes := f.c, f.ipsets
+	c.config.ServiceClusterIPRange = "10.96.0.0/12,fd00:10:96::/112"
+	require.NoError(t, c.setIPSet())
+
+	v4, v6 := fakes[kubeovnv1.ProtocolIPv4].applied, fakes[kubeovnv1.ProtocolIPv6].applied
+	require.ElementsMatch(t, []string{"10.16.0.0/16", "10.17.0.0/16"}, v4[SubnetSet])
+	require.ElementsMatch(t, []string{"fd00:10:16::/64"}, v6[SubnetSet])
+	require.ElementsMatch(t, []string{"10.16.0.0/16"}, v4[SubnetNatSet])
+	require.ElementsMatch(t, []string{"fd00:10:16::/64"}, v6[SubnetNatSet])
+	require.Contains(t, v4, LocalPodSet)
+	require.Empty(t, v4[LocalPodSet])
+	require.Contains(t, v6, LocalPodSet)
+	require.Empty(t, v6[LocalPodSet])
+	require.Equal(t, []string{"172.18.0.3"}, v4[OtherNodeSet])
+	require.Equal(t, []string{"fc00:f853:ccd:e793::3"}, v6[OtherNodeSet])
+	require.Equal(t, []string{"10.96.0.0/12"}, v4[ServiceSet])
+	for setID, members := range v4 {
+		require.GreaterOrEqual(t, fakes[kubeovnv1.ProtocolIPv4].maxSizes[setID], len(members)*ipsetSizeHeadroom, setID)
+	}
+
+	// members are replaced after the subnet topology changes
+	require.NoError(t, f.subnets.Delete(newTestSubnet("ovn-default", "", false)))
+	require.NoError(t, f.subnets.Update(newTestSubnet("no-nat", "10.17.0.0/16", true)))
+	require.NoError(t, c.setIPSet())
+	v4, v6 = fakes[kubeovnv1.ProtocolIPv4].applied, fakes[kubeovnv1.ProtocolIPv6].applied
+	require.ElementsMatch(t, []string{"10.17.0.0/16"}, v4[SubnetSet])
+	require.Empty(t, v6[SubnetSet])
+	require.ElementsMatch(t, []string{"10.17.0.0/16"}, v4[SubnetNatSet])
+	require.Empty(t, v6[SubnetNatSet])
+}
+
+func TestSetIPSetNodeSwitchCIDR(t *testing.T) {
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolDual, newTestSubnet("ovn-default", "10.16.0.0/16,fd00:10:16::/64", false))
+	c := f.c
+	c.config.NodeSwitch = "join"
+	c.config.NodeSwitchCIDR = "100.64.0.0/16,fd00:100:64::/112"
+
+	// the node switch cidr from config is included before the join subnet is synced
+	require.NoError(t, c.setIPSet())
+	v4, v6 := f.ipsets[kubeovnv1.ProtocolIPv4], f.ipsets[kubeovnv1.ProtocolIPv6]
+	require.ElementsMatch(t, []string{"10.16.0.0/16", "100.64.0.0/16"}, v4.applied[SubnetSet])
+	require.ElementsMatch(t, []string{"fd00:10:16::/64", "fd00:100:64::/112"}, v6.applied[SubnetSet])
+	require.NotContains(t, v4.applied[SubnetNatSet], "100.64.0.0/16")
+
+	// the cidr of the join subnet takes precedence over the config
+	require.NoError(t, f.subnets.Add(newTestSubnet("join", "100.65.0.0/16", false)))
+	require.NoError(t, c.setIPSet())
+	require.ElementsMatch(t, []string{"10.16.0.0/16", "100.65.0.0/16"}, v4.applied[SubnetSet])
+	require.ElementsMatch(t, []string{"fd00:10:16::/64", "fd00:100:64::/112"}, v6.applied[SubnetSet])
+}
+
+func TestNatOutgoingPortRules(t *testing.T) {
+	matches, err := parseNatOutgoingPorts("")
+	require.NoError(t, err)
+	require.Empty(t, matches)
+
+	matches, err = parseNatOutgoingPorts("tcp:80,443,8000-9000; UDP:53")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		strings.Fields("-p tcp -m multiport --dports 80,443,8000:9000"),
+		strings.Fields("-p udp -m multiport --dports 53"),
+	}, matches)
+
+	for _, ports := range []string{"icmp:80", "tcp", "tcp:0", "tcp:65536", "tcp:9000-8000", "tcp:http", "tcp:1,2,3,4,5,6,7,8,9,10,11,12,13,14,15-16"} {
+		_, err = parseNatOutgoingPorts(ports)
+		require.Error(t, err, ports)
+	}
+
+	rule := util.IPTableRule{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(`-m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -j ` + OvnMasquerade)}
+	require.Equal(t, []util.IPTableRule{
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(`-p tcp -m multiport --dports 80,443,8000:9000 -m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -j ` + OvnMasquerade)},
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(`-p udp -m multiport --dports 53 -m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -j ` + OvnMasquerade)},
+	}, natOutgoingPortRules(rule, matches))
+	require.Equal(t, strings.Fields(`-m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -j `+OvnMasquerade), rule.Rule)
+}
+
+func TestSubnetNatOutgoingSnatIP(t *testing.T) {
+	newSubnet := func(name, cidr, snatIP string, natOutgoing bool) *kubeovnv1.Subnet {
+		subnet := newTestSubnet(name, cidr, natOutgoing)
+		subnet.Spec.NatOutgoingSnatIP = snatIP
+		return subnet
+	}
+	c := newGatewayTestFixture(t, kubeovnv1.ProtocolDual,
+		newSubnet("ovn-default", "10.16.0.0/16,fd00:10:16::/64", "", true),
+		newSubnet("snat-dual", "10.17.0.0/16,fd00:10:17::/64", "172.18.0.100", true),
+		newSubnet("snat-v6", "fd00:10:18::/64", "fc00:f853:ccd:e793::100", true),
+		newSubnet("no-nat", "10.19.0.0/16", "172.18.0.101", false),
+	).c
+
+	v4, err := c.getSubnetsNatOutgoingSnatIP(kubeovnv1.ProtocolIPv4)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"10.17.0.0/16": "172.18.0.100"}, v4)
+
+	v6, err := c.getSubnetsNatOutgoingSnatIP(kubeovnv1.ProtocolIPv6)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"fd00:10:18::/64": "fc00:f853:ccd:e793::100"}, v6)
+
+	require.Equal(t, util.IPTableRule{
+		Table: NAT,
+		Chain: OvnPostrouting,
+		Rule:  strings.Fields(`-s 10.17.0.0/16 -m set ! --match-set ovn40subnets dst -j SNAT --to-source 172.18.0.100 --random-fully`),
+	}, subnetSnatRule("10.17.0.0/16", "172.18.0.100", "ovn40subnets", "--random-fully"))
+	require.Equal(t, strings.Fields(`-s fd00:10:18::/64 -m set ! --match-set ovn60subnets dst -j SNAT --to-source fc00:f853:ccd:e793::100`),
+		subnetSnatRule("fd00:10:18::/64", "fc00:f853:ccd:e793::100", "ovn60subnets", "").Rule)
+
+	// only the snat ips owned by the node are used
+	var addrs []netlink.Addr
+	c.addrLister = func(int) ([]netlink.Addr, error) { return addrs, nil }
+	rules, err := c.subnetSnatRules(kubeovnv1.ProtocolIPv4, "ovn40subnets", "")
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	addrs = []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("172.18.0.100"), Mask: net.CIDRMask(16, 32)}}}
+	rules, err = c.subnetSnatRules(kubeovnv1.ProtocolIPv4, "ovn40subnets", "")
+	require.NoError(t, err)
+	require.Equal(t, []util.IPTableRule{subnetSnatRule("10.17.0.0/16", "172.18.0.100", "ovn40subnets", "")}, rules)
+
+	// the snat rules are qualified with the nat outgoing destination ports
+	c.config.NatOutgoingPortMatches, err = parseNatOutgoingPorts("tcp:80,443;udp:53")
+	require.NoError(t, err)
+	rules, err = c.subnetSnatRules(kubeovnv1.ProtocolIPv4, "ovn40subnets", "")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		strings.Fields(`-p tcp -m multiport --dports 80,443 -s 10.17.0.0/16 -m set ! --match-set ovn40subnets dst -j SNAT --to-source 172.18.0.100`),
+		strings.Fields(`-p udp -m multiport --dports 53 -s 10.17.0.0/16 -m set ! --match-set ovn40subnets dst -j SNAT --to-source 172.18.0.100`),
+	}, [][]string{rules[0].Rule, rules[1].Rule})
+}
+
+func TestNatGatewayRules(t *testing.T) {
+	rules := []util.IPTableRule{
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(`-m mark --mark 0x4000/0x4000 -j ` + OvnMasquerade)},
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(`-p tcp -m tcp --tcp-flags SYN NONE -m conntrack --ctstate NEW -j RETURN`)},
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(`-m set --match-set ovn40subnets-nat-policy src -m set ! --match-set ovn40subnets dst -j ` + OvnNatOutGoingPolicy)},
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(fmt.Sprintf(`-m mark --mark %s -j %s`, OnOutGoingNatMark, OvnMasquerade))},
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(fmt.Sprintf(`-m mark --mark %s -j RETURN`, OnOutGoingForwardMark))},
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(`-s 10.16.0.0/16 -m set ! --match-set ovn40subnets dst -j SNAT --to-source 172.18.0.2`)},
+		{Table: NAT, Chain: OvnPostrouting, Rule: strings.Fields(`-m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -j ` + OvnMasquerade)},
+	}
+	gateway := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"kube-ovn/role": "gateway"}}}
+	worker := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2"}}
+
+	selector, err := labels.Parse("kube-ovn/role=gateway")
+	require.NoError(t, err)
+	require.Equal(t, rules, natGatewayRules(rules, gateway, selector))
+	require.Equal(t, rules[:2], natGatewayRules(rules, worker, selector))
+
+	// all the nodes are nat gateways if no selector is configured
+	selector, err = labels.Parse("")
+	require.NoError(t, err)
+	require.Equal(t, rules, natGatewayRules(rules, worker, selector))
+	require.Equal(t, rules, natGatewayRules(rules, worker, nil))
+
+	// rules without the nat outgoing rules are unchanged
+	selector, err = labels.Parse("kube-ovn/role")
+	require.NoError(t, err)
+	require.Equal(t, rules[:2], natGatewayRules(rules[:2], worker, selector))
+}
+
+func TestWouldMasquerade(t *testing.T) {
+	snat := newTestSubnet("snat", "10.19.0.0/16", true)
+	snat.Spec.NatOutgoingSnatIP = "192.168.0.100"
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolDual,
+		newTestSubnet("ovn-default", "10.16.0.0/16,fd00:10:16::/64", true),
+		newTestSubnet("underlay", "172.18.0.0/16", false),
+		snat,
+	)
+	require.NoError(t, f.nodes.Update(newTestNode("node1", "192.168.0.2")))
+	c := f.c
+	c.addrLister = func(int) ([]netlink.Addr, error) {
+		return []netlink.Addr{{IPNet: &net.IPNet{IP: net.ParseIP("192.168.0.100"), Mask: net.CIDRMask(24, 32)}}}, nil
+	}
+	_, _, err := c.WouldMasquerade("10.16.0.2", "1.1.1.1", "", 0)
+	require.Error(t, err)
+
+	// ovn-default and the snat subnet need nat outgoing while the underlay subnet doesn't
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetSet, []string{"10.16.0.0/16", "172.18.0.0/16", "10.19.0.0/16"})
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetNatSet, []string{"10.16.0.0/16", "10.19.0.0/16"})
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, SubnetDistributedGwSet, []string{"10.16.0.0/16"})
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, ETPLocalPodSet, []string{"10.16.0.10"})
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, OtherNodeSet, []string{"192.168.0.3"})
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv6, SubnetSet, []string{"fd00:10:16::/64"})
+	c.recordIPSetMembers(kubeovnv1.ProtocolIPv6, SubnetNatSet, []string{"fd00:10:16::/64"})
+
+	type testCase struct {
+		name       string
+		src, dst   string
+		proto      string
+		dstPort    uint16
+		masquerade bool
+		reason     string
+	}
+	check := func(t *testing.T, tests []testCase) {
+		t.Helper()
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				masquerade, reason, err := c.WouldMasquerade(tt.src, tt.dst, tt.proto, tt.dstPort)
+				require.NoError(t, err)
+				require.Equal(t, tt.masquerade, masquerade)
+				require.Contains(t, reason, tt.reason)
+			})
+		}
+	}
+	masqueradeRule := "-m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -j " + OvnMasquerade
+	check(t, []testCase{
+		{name: "nat outgoing subnet to external", src: "10.16.0.2", dst: "1.1.1.1", masquerade: true, reason: masqueradeRule},
+		{name: "subnet without nat outgoing to external", src: "172.18.0.2", dst: "1.1.1.1", reason: "no nat rule matches"},
+		{name: "between subnets", src: "10.16.0.2", dst: "172.18.0.2", masquerade: true, reason: "ovn40subnets dst -j " + OvnMasquerade},
+		{name: "source not in subnets", src: "192.168.0.5", dst: "1.1.1.1", reason: "no nat rule matches"},
+		{name: "external to pod", src: "192.168.0.5", dst: "10.16.0.11", reason: "ovn40other-node src"},
+		{name: "subnet with snat ip owned by the node", src: "10.19.0.2", dst: "1.1.1.1", masquerade: true, reason: "-j SNAT --to-source 192.168.0.100"},
+		{name: "ipv6 nat outgoing subnet to external", src: "fd00:10:16::2", dst: "2001:db8::1", masquerade: true, reason: "ovn60subnets-nat src"},
+		{name: "ipv4-mapped ipv6 address", src: "::ffff:10.16.0.2", dst: "1.1.1.1", masquerade: true, reason: masqueradeRule},
+	})
+
+	// external traffic to the local endpoints of services with external traffic policy set to local
+	c.config.EnableETPLocalNoMasq = true
+	check(t, []testCase{
+		{name: "external to etp local pod", src: "192.168.0.5", dst: "10.16.0.10", reason: "ovn40etp-local-pod dst -j RETURN"},
+		{name: "external to other pod", src: "192.168.0.5", dst: "10.16.0.11", reason: "ovn40other-node src"},
+	})
+
+	// the traffic to the exempt cidrs of the external gateway is not masqueraded
+	require.NoError(t, f.configMaps.Add(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: util.ExternalGatewayConfig, Namespace: "kube-system"},
+		Data:       map[string]string{"enable-external-gw": "true", exGatewayNatExemptCIDRsKey: "1.1.1.0/24"},
+	}))
+	check(t, []testCase{
+		{name: "nat outgoing subnet to exempt cidr", src: "10.16.0.2", dst: "1.1.1.1", reason: "-d 1.1.1.0/24 -j RETURN"},
+		{name: "nat outgoing subnet to other external", src: "10.16.0.2", dst: "8.8.8.8", masquerade: true, reason: masqueradeRule},
+	})
+
+	// only the nat outgoing traffic to the configured ports is masqueraded
+	c.config.NatOutgoingPortMatches, err = parseNatOutgoingPorts("tcp:80,8000-9000")
+	require.NoError(t, err)
+	check(t, []testCase{
+		{name: "matching port", src: "10.16.0.2", dst: "8.8.8.8", proto: "tcp", dstPort: 8080, masquerade: true, reason: "-p tcp -m multiport --dports 80,8000:9000"},
+		{name: "port of other protocol", src: "10.16.0.2", dst: "8.8.8.8", proto: "udp", dstPort: 80, reason: "no nat rule matches"},
+		{name: "port not configured", src: "10.16.0.2", dst: "8.8.8.8", proto: "TCP", dstPort: 443, reason: "no nat rule matches"},
+		{name: "unknown port", src: "10.16.0.2", dst: "8.8.8.8", reason: "no nat rule matches"},
+		{name: "snat subnet to matching port", src: "10.19.0.2", dst: "8.8.8.8", proto: "tcp", dstPort: 80, masquerade: true, reason: "-j SNAT --to-source 192.168.0.100"},
+	})
+	c.config.NatOutgoingPortMatches = nil
+
+	// the nat outgoing traffic is not masqueraded on the node not matching the nat gateway selector
+	c.config.NatGatewaySelector, err = labels.Parse("kube-ovn/role=gateway")
+	require.NoError(t, err)
+	check(t, []testCase{
+		{name: "nat outgoing subnet on non gateway node", src: "10.16.0.2", dst: "8.8.8.8", reason: "no nat rule matches"},
+		{name: "between subnets on non gateway node", src: "10.16.0.2", dst: "172.18.0.2", masquerade: true, reason: "ovn40subnets dst -j " + OvnMasquerade},
+	})
+
+	_, _, err = c.WouldMasquerade("10.16.0.2", "2001:db8::1", "", 0)
+	require.ErrorContains(t, err, "different address families")
+	_, _, err = c.WouldMasquerade("10.16.0", "1.1.1.1", "", 0)
+	require.ErrorContains(t, err, "invalid source ip")
+	_, _, err = c.WouldMasquerade("10.16.0.2", "", "", 0)
+	require.ErrorContains(t, err, "invalid destination ip")
+	_, _, err = c.WouldMasquerade("10.16.0.2", "1.1.1.1", "icmp", 0)
+	require.ErrorContains(t, err, "invalid protocol")
+}
+
+func TestGetManagedFilterRules(t *testing.T) {
+	rules := []string{
+		`-P FORWARD ACCEPT`,
+		`-A FORWARD -m set --match-set ovn40subnets src -j ACCEPT`,
+		`-A FORWARD -m set --match-set ovn60subnets src -j ACCEPT`,
+		`-A FORWARD -d 10.16.0.0/16 -m comment --comment "ovn-subnet-gateway,ovn-default"`,
+		`-A FORWARD -m set --match-set KUBE-CLUSTER-IP dst -j ACCEPT`,
+		`-A FORWARD -m comment --comment "foreign rule" -j ACCEPT`,
+		`-A INPUT -m set --match-set ovn40services dst -j ACCEPT`,
+	}
+	require.Equal(t, []util.IPTableRule{
+		{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(`-m set --match-set ovn40subnets src -j ACCEPT`)},
+		{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(`-d 10.16.0.0/16 -m comment --comment ovn-subnet-gateway,ovn-default`)},
+	}, getManagedFilterRules(rules, "FORWARD", "ovn40"))
+	require.Empty(t, getManagedFilterRules(rules, "OUTPUT", "ovn40"))
+
+	stamped := []string{`-A OUTPUT -p udp -m udp --dport 6081 -m comment --comment kube-ovn-gateway-gen-1 -j MARK --set-xmark 0x0/0xffffffff`}
+	require.Equal(t, []util.IPTableRule{
+		{Table: "filter", Chain: "OUTPUT", Rule: strings.Fields(`-p udp -m udp --dport 6081 -m comment --comment kube-ovn-gateway-gen-1 -j MARK --set-xmark 0x0/0xffffffff`)},
+	}, getManagedFilterRules(stamped, "OUTPUT", "ovn40"))
+}
+
+func TestGetPreviousGenerationRules(t *testing.T) {
+	// the rules listed by iptables after upgrading from generation 1 to 2
+	rules := []string{
+		`-P INPUT ACCEPT`,
+		`-A INPUT -m set --match-set ovn40subnets src -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT`,
+		`-A INPUT -m set --match-set ovn40subnets src -m comment --comment kube-ovn-gateway-gen-2 -j ACCEPT`,
+		`-A INPUT -m set --match-set ovn40services src -m comment --comment kube-ovn-gateway-gen-3 -j ACCEPT`,
+		`-A INPUT -m set --match-set ovn40services dst -m comment --comment kube-ovn-gateway-gen-x -j ACCEPT`,
+		`-A INPUT -m comment --comment "foreign rule" -j ACCEPT`,
+		`-A OUTPUT -p udp -m udp --dport 6081 -m comment --comment kube-ovn-gateway-gen-1 -j MARK --set-xmark 0x0/0xffffffff`,
+	}
+	require.Equal(t, []util.IPTableRule{
+		{Table: "filter", Chain: "INPUT", Rule: strings.Fields(`-m set --match-set ovn40subnets src -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT`)},
+	}, getPreviousGenerationRules(rules, "INPUT", 2))
+	require.Empty(t, getPreviousGenerationRules(rules, "INPUT", 1))
+	require.Len(t, getPreviousGenerationRules(rules, "OUTPUT", 2), 1)
+
+	// the rules created before the rules are stamped are deleted by their unstamped forms
+	desired := []util.IPTableRule{
+		{Table: "filter", Chain: "INPUT", Rule: strings.Fields(`-m set --match-set ovn40subnets src -j ACCEPT`), Comment: gatewayIptablesGenerationComment(2)},
+		{Table: "filter", Chain: "FORWARD", Rule: strings.Fields(`-m comment --comment ovn-subnet-gateway,ovn-default -s 10.16.0.0/16`)},
+	}
+	require.Equal(t, []util.IPTableRule{
+		{Table: "filter", Chain: "INPUT", Rule: strings.Fields(`-m set --match-set ovn40subnets src -j ACCEPT`)},
+	}, getUnstampedGatewayRules(desired))
+	require.Equal(t, gatewayIptablesGenerationComment(2), desired[0].Comment)
+}
+
+func TestDeleteUnstampedGatewayRules(t *testing.T) {
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4, newTestSubnet("ovn-default", "10.16.0.0/16", true))
+	require.NoError(t, f.nodes.Update(newTestNode("node1", "172.18.0.2")))
+	c, ipt := f.c, f.iptables[kubeovnv1.ProtocolIPv4]
+	unstamped := strings.Fields(`-m set --match-set ovn40subnets src -j ACCEPT`)
+
+	// the rule created before the rules are stamped is deleted in the first cycle
+	require.NoError(t, ipt.Insert("filter", "INPUT", 1, unstamped...))
+	require.NoError(t, c.setIptables())
+	exists, err := ipt.Exists("filter", "INPUT", unstamped...)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	// and it is not looked up again in the following cycles
+	require.NoError(t, ipt.Insert("filter", "INPUT", 1, unstamped...))
+	require.NoError(t, c.setIptables())
+	exists, err = ipt.Exists("filter", "INPUT", unstamped...)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestIptablesJumpRulePosition(t *testing.T) {
+	rule := util.IPTableRule{Table: NAT, Chain: Postrouting, Rule: []string{"-j", OvnPostrouting}, Comment: "kube-ovn postrouting rules"}
+	spec := rule.RuleSpec()
+	jump := `-A POSTROUTING -m comment --comment "kube-ovn postrouting rules" -j OVN-POSTROUTING`
+	others := []string{
+		`-P POSTROUTING ACCEPT`,
+		`-A POSTROUTING -m comment --comment "kubernetes postrouting rules" -j KUBE-POSTROUTING`,
+		`-A POSTROUTING -j FOO`,
+	}
+
+	for _, c := range []struct {
+		name     string
+		rules    []string
+		position int
+		insertAt int
+		stale    []int
+	}{
+		{"insert at the configured position", others, 2, 2, nil},
+		{"append", others, 0, 3, nil},
+		{"append if exceeding the rule count", others, 5, 3, nil},
+		{"in place", slices.Insert(slices.Clone(others), 2, jump), 2, 0, nil},
+		{"move to the configured position", append(slices.Clone(others), jump), 1, 1, []int{3}},
+		{"keep the existing rule when appending", slices.Insert(slices.Clone(others), 1, jump), 0, 0, nil},
+		{"delete duplicates when appending", append(slices.Insert(slices.Clone(others), 1, jump), jump), 0, 0, []int{4}},
+		{"delete duplicates", append(slices.Insert(slices.Clone(others), 1, jump), jump), 1, 1, []int{4, 1}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			insertAt, stale := iptablesJumpRulePosition(c.rules, spec, c.position, false)
+			require.Equal(t, c.insertAt, insertAt)
+			require.Equal(t, c.stale, stale)
+		})
+	}
+}
+
+func TestNormalizeIptablesRuleSpec(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		listed string
+		spec   string
+		nft    bool
+		equal  bool
+	}{
+		{"same", `-s 10.16.0.0/16 -j MASQUERADE`, `-s 10.16.0.0/16 -j MASQUERADE`, false, true},
+		{"host address", `-d 10.16.0.1/32 -j ACCEPT`, `-d 10.16.0.1 -j ACCEPT`, false, true},
+		{"host address v6", `-d fd00::1/128 -j ACCEPT`, `-d fd00::1 -j ACCEPT`, false, true},
+		{"long option", `-s 10.16.0.0/16 -j ACCEPT`, `--source 10.16.0.0/16 -j ACCEPT`, false, true},
+		{"match-all address", `-j ACCEPT`, `-s 0.0.0.0/0 -j ACCEPT`, false, true},
+		{"negated match-all address", `-j ACCEPT`, `! -s 0.0.0.0/0 -j ACCEPT`, false, false},
+		{"different address", `-s 10.16.0.0/16 -j ACCEPT`, `-s 10.17.0.0/16 -j ACCEPT`, true, false},
+		{"comment position in legacy mode", `-j ACCEPT -m comment --comment "foo bar"`, `-m comment --comment "foo bar" -j ACCEPT`, false, false},
+		{"comment position in nft mode", `-m comment --comment "foo bar" -p tcp -j ACCEPT`, `-p tcp -m comment --comment "foo bar" -j ACCEPT`, true, true},
+		{"implicit protocol match in nft mode", `-p tcp -m tcp --dport 80 -j ACCEPT`, `-p tcp --dport 80 -j ACCEPT`, true, true},
+		{"other match in nft mode", `-p tcp -m udp --dport 80 -j ACCEPT`, `-p tcp --dport 80 -j ACCEPT`, true, false},
+		{"different comment in nft mode", `-m comment --comment foo -j ACCEPT`, `-m comment --comment bar -j ACCEPT`, true, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.equal, iptablesRuleSpecEqual(util.DoubleQuotedFields(c.listed), util.DoubleQuotedFields(c.spec), c.nft))
+		})
+	}
+}
+
+func TestIptablesRulesIdempotentInNftMode(t *testing.T) {
+	// iptables-nft lists the comment match after the other matches and the implicit protocol match explicitly
+	nftStyle := func(rule util.IPTableRule) string {
+		var fields []string
+		for i := 0; i < len(rule.Rule); i++ {
+			fields = append(fields, rule.Rule[i])
+			if rule.Rule[i] == "-p" && i+1 < len(rule.Rule) {
+				fields = append(fields, rule.Rule[i+1], "-m", rule.Rule[i+1])
+				i++
+			}
+		}
+		return fmt.Sprintf("-A %s %s -m comment --comment %q", rule.Chain, strings.Join(fields, " "), rule.Comment)
+	}
+
+	jump := util.IPTableRule{Table: NAT, Chain: Postrouting, Rule: []string{"-j", OvnPostrouting}, Comment: "kube-ovn postrouting rules"}
+	dns := util.IPTableRule{Table: NAT, Chain: Postrouting, Rule: strings.Fields("-p udp --dport 53 -d 10.96.0.10 -j RETURN"), Comment: "kube-ovn dns"}
+	rules := []string{`-P POSTROUTING ACCEPT`, nftStyle(dns), nftStyle(jump)}
+	require.Equal(t, `-A POSTROUTING -j OVN-POSTROUTING -m comment --comment "kube-ovn postrouting rules"`, rules[2])
+
+	// the rules listed in the nft style are regarded as different in legacy mode, which causes duplicate inserts
+	insertAt, stale := iptablesJumpRulePosition(rules, jump.RuleSpec(), 0, false)
+	require.Equal(t, 3, insertAt)
+	require.Empty(t, stale)
+
+	// no rule is inserted or deleted repeatedly in nft mode
+	for range 3 {
+		insertAt, stale = iptablesJumpRulePosition(rules, jump.RuleSpec(), 0, true)
+		require.Zero(t, insertAt)
+		require.Empty(t, stale)
+		insertAt, stale = iptablesJumpRulePosition(rules, jump.RuleSpec(), 2, true)
+		require.Zero(t, insertAt)
+		require.Empty(t, stale)
+	}
+	require.Empty(t, getObsoleteCommentedRules(rules, NAT, Postrouting, jump.Comment, []util.IPTableRule{jump}, true))
+	require.True(t, iptablesRuleSpecEqual(util.DoubleQuotedFields(rules[1])[2:], dns.RuleSpec(), true))
+}
+
+func TestGetManagedIptablesChains(t *testing.T) {
+	chains := []string{Prerouting, Postrouting, OvnPrerouting, OvnPostrouting, OvnMasquerade, OvnNatOutGoingPolicy, OvnNatOutGoingPolicySubnet + "abcdef", "OVN-KUBE-NODEPORT", "KUBE-SERVICES"}
+	require.Equal(t, []string{OvnPrerouting, OvnPostrouting, OvnMasquerade, OvnNatOutGoingPolicy, OvnNatOutGoingPolicySubnet + "abcdef"}, getManagedIptablesChains(chains))
+}
+
+func TestTeardownIPSets(t *testing.T) {
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4)
+	for _, name := range []string{"ovn40" + SubnetSet, "ovn40" + NatOutGoingPolicyRuleSet + "abc-src", "ovn60" + SubnetSet, "KUBE-CLUSTER-IP"} {
+		require.NoError(t, f.k8sipsets.CreateSet(&k8sipset.IPSet{Name: name}, true))
+	}
+	c, fake := f.c, f.ipsets[kubeovnv1.ProtocolIPv4]
+	for _, setID := range []string{SubnetSet, ServiceSet} {
+		fake.AddOrReplaceIPSet(ipsets.IPSetMetadata{SetID: setID}, []string{"10.16.0.0/16"})
+		c.recordIPSetMembers(kubeovnv1.ProtocolIPv4, setID, []string{"10.16.0.0/16"})
+	}
+	fake.AddOrReplaceIPSet(ipsets.IPSetMetadata{SetID: NatOutGoingPolicyRuleSet + "abc-src"}, nil)
+	fake.ApplyUpdates()
+	require.Len(t, fake.applied, 3)
+
+	// no managed ipset remains, and tearing down again is a no-op
+	for range 2 {
+		require.NoError(t, c.teardownIPSets(kubeovnv1.ProtocolIPv4))
+		require.Empty(t, fake.applied)
+		require.Empty(t, c.ipsetMembers)
+	}
+}
+
+func TestTeardownGateway(t *testing.T) {
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolDual, newTestSubnet("ovn-default", "10.16.0.0/16,fd00:10:16::/64", true))
+	require.NoError(t, f.nodes.Update(newTestNode("node1", "192.168.0.2", "fd00::2")))
+	c, ipts, fakes := f.c, f.iptables, f.ipsets
+	c.config.IPSetMinApplyInterval = time.Hour
+
+	// the chains and rules not managed by kube-ovn are kept
+	foreign := make(map[string][]string, len(ipts))
+	for protocol, ipt := range ipts {
+		require.NoError(t, ipt.NewChain(NAT, "KUBE-SERVICES"))
+		require.NoError(t, ipt.Insert(NAT, Prerouting, 1, "-m", "comment", "--comment", "kubernetes service portals", "-j", "KUBE-SERVICES"))
+		require.NoError(t, ipt.Insert("filter", "FORWARD", 1, "-m", "comment", "--comment", "kubernetes forwarding rules", "-j", "ACCEPT"))
+		foreign[protocol] = ipt.dump()
+	}
+	require.NoError(t, c.setIPSet())
+	require.NoError(t, c.setIptables())
+	for protocol, ipt := range ipts {
+		require.NotEmpty(t, fakes[protocol].applied)
+		require.NotEqual(t, foreign[protocol], ipt.dump())
+	}
+
+	// the deferred apply pending is cancelled
+	require.NoError(t, f.subnets.Add(newTestSubnet("subnet1", "10.17.0.0/16", true)))
+	require.NoError(t, c.setIPSet())
+	require.NotEmpty(t, c.ipsetApplyTimers)
+	// the applied ipsets are listed from the kernel, including the ones whose members are not recorded
+	for protocol, fake := range fakes {
+		setPrefix := "ovn40"
+		if protocol == kubeovnv1.ProtocolIPv6 {
+			setPrefix = "ovn60"
+		}
+		for setID := range fake.applied {
+			require.NoError(t, f.k8sipsets.CreateSet(&k8sipset.IPSet{Name: setPrefix + setID}, true))
+		}
+	}
+
+	// no managed chain, rule or ipset remains, and tearing down again is a no-op
+	commands := fakeOvsVsctl(t)
+	for range 2 {
+		require.NoError(t, c.TeardownGateway())
+		for protocol, ipt := range ipts {
+			require.Equal(t, foreign[protocol], ipt.dump())
+			require.Empty(t, fakes[protocol].applied)
+		}
+		require.Empty(t, c.ipsetApplyTimers)
+	}
+	require.Equal(t, []string{"--if-exists get open . external-ids:ovn-bridge-mappings", "--if-exists get open . external-ids:ovn-bridge-mappings"}, commands())
+
+	// the ipsets are never applied again
+	c.config.IPSetMinApplyInterval = 0
+	require.NoError(t, c.setIPSet())
+	for _, fake := range fakes {
+		require.Empty(t, fake.applied)
+	}
+}
+
+func TestIPSetMaxSize(t *testing.T) {
+	require.Equal(t, ipsetMinMaxSize, ipsetMaxSize(0))
+	require.Equal(t, ipsetMinMaxSize, ipsetMaxSize(ipsetMinMaxSize/ipsetSizeHeadroom))
+	require.Equal(t, 2*ipsetMinMaxSize, ipsetMaxSize(ipsetMinMaxSize/ipsetSizeHeadroom+1))
+	require.Equal(t, 1<<21, ipsetMaxSize(600000))
+
+	c := &Controller{}
+	require.Equal(t, ipsetMinMaxSize, c.getIPSetMaxSize(kubeovnv1.ProtocolIPv4, SubnetSet, 10))
+	// the size is kept until the members use up the headroom
+	require.Equal(t, ipsetMinMaxSize, c.getIPSetMaxSize(kubeovnv1.ProtocolIPv4, SubnetSet, ipsetMinMaxSize/ipsetSizeHeadroom))
+	size := c.getIPSetMaxSize(kubeovnv1.ProtocolIPv4, SubnetSet, 5000)
+	require.Equal(t, 16384, size)
+	require.Greater(t, size, 5000*ipsetSizeHeadroom)
+	// and it is not shrunk when the members decrease
+	require.Equal(t, size, c.getIPSetMaxSize(kubeovnv1.ProtocolIPv4, SubnetSet, 10))
+	// the sizes are tracked per family
+	require.Equal(t, ipsetMinMaxSize, c.getIPSetMaxSize(kubeovnv1.ProtocolIPv6, SubnetSet, 10))
+}
+
+func TestDumpIptablesRules(t *testing.T) {
+	central := newTestSubnet("central", "10.17.0.0/16", true)
+	central.Spec.GatewayType = kubeovnv1.GWCentralizedType
+	central.Spec.GatewayNode = "node1:172.18.0.10"
+	central.Status.ActivateGateway = "node1"
+	customVpc := newTestSubnet("custom-vpc", "10.18.0.0/16", true)
+	customVpc.Spec.Vpc = "vpc1"
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4, newTestSubnet("ovn-default", "10.16.0.0/16", true), central, customVpc)
+	require.NoError(t, f.nodes.Update(newTestNode("node1", "172.18.0.2")))
+	require.NoError(t, f.k8sipsets.CreateSet(&k8sipset.IPSet{Name: "KUBE-CLUSTER-IP"}, true))
+	c := f.c
+	c.k8siptables[kubeovnv1.ProtocolIPv4] = iptablestest.NewFake().SetHasRandomFully(true)
+
+	expected := `
+-t filter -A INPUT -m set --match-set ovn40subnets src -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT
+-t filter -A INPUT -m set --match-set ovn40subnets dst -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT
+-t filter -A INPUT -m set --match-set ovn40services src -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT
+-t filter -A INPUT -m set --match-set ovn40services dst -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT
+-t filter -A FORWARD -m set --match-set ovn40subnets src -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT
+-t filter -A FORWARD -m set --match-set ovn40subnets dst -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT
+-t filter -A FORWARD -m set --match-set ovn40services src -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT
+-t filter -A FORWARD -m set --match-set ovn40services dst -m comment --comment kube-ovn-gateway-gen-1 -j ACCEPT
+-t filter -A OUTPUT -p udp -m udp --dport 6081 -m comment --comment kube-ovn-gateway-gen-1 -j MARK --set-xmark 0x0
+-t filter -A OUTPUT -p udp -m udp --dport 4789 -m comment --comment kube-ovn-gateway-gen-1 -j MARK --set-xmark 0x0
+-t filter -A INPUT -p tcp -m mark ! --mark 0x4000/0x4000 -m set --match-set ovn40services dst -m conntrack --ctstate NEW -m comment --comment kube-ovn-gateway-gen-1 -j REJECT
+-t filter -A OUTPUT -p tcp -m mark ! --mark 0x4000/0x4000 -m set --match-set ovn40services dst -m conntrack --ctstate NEW -m comment --comment kube-ovn-gateway-gen-1 -j REJECT
+-t filter -A FORWARD -m comment --comment ovn-subnet-gateway,central -s 10.17.0.0/16
+-t filter -A FORWARD -m comment --comment ovn-subnet-gateway,central -d 10.17.0.0/16
+-t filter -A FORWARD -m comment --comment ovn-subnet-gateway,ovn-default -s 10.16.0.0/16
+-t filter -A FORWARD -m comment --comment ovn-subnet-gateway,ovn-default -d 10.16.0.0/16
+-t nat -A PREROUTING -m comment --comment kube-ovn prerouting rules -j OVN-PREROUTING
+-t nat -A OVN-PREROUTING -i ovn0 -m set --match-set ovn40subnets src -m set --match-set KUBE-CLUSTER-IP dst,dst -j MARK --set-xmark 0x4000/0x4000
+-t nat -A OVN-MASQUERADE -j MARK --set-xmark 0x0/0xffffffff
+-t nat -A OVN-MASQUERADE -j MASQUERADE --random-fully
+-t nat -A POSTROUTING -m comment --comment kube-ovn postrouting rules -j OVN-POSTROUTING
+-t nat -A OVN-POSTROUTING -m set --match-set ovn40services src -m set --match-set ovn40subnets dst -m mark --mark 0x4000/0x4000 -j SNAT --to-source 172.18.0.2 --random-fully
+-t nat -A OVN-POSTROUTING -m mark --mark 0x4000/0x4000 -j OVN-MASQUERADE
+-t nat -A OVN-POSTROUTING -m set --match-set ovn40subnets src -m set --match-set ovn40subnets dst -j OVN-MASQUERADE
+-t nat -A OVN-POSTROUTING -m mark --mark 0x80000/0x80000 -m set --match-set ovn40subnets-distributed-gw dst -j RETURN
+-t nat -A OVN-POSTROUTING -m mark --mark 0x80000/0x80000 -j OVN-MASQUERADE
+-t nat -A OVN-POSTROUTING -p tcp -m tcp --tcp-flags SYN NONE -m conntrack --ctstate NEW -j RETURN
+-t nat -A OVN-POSTROUTING -m set ! --match-set ovn40subnets src -m set ! --match-set ovn40other-node src -m set --match-set ovn40subnets-nat dst -j RETURN
+-t nat -A OVN-POSTROUTING -m set --match-set ovn40subnets-nat-policy src -m set ! --match-set ovn40subnets dst -j OVN-NAT-POLICY
+-t nat -A OVN-POSTROUTING -m mark --mark 0x90001/0x90001 -j OVN-MASQUERADE
+-t nat -A OVN-POSTROUTING -m mark --mark 0x90002/0x90002 -j RETURN
+-t nat -A OVN-POSTROUTING -s 10.17.0.0/16 -m set ! --match-set ovn40subnets dst -j SNAT --to-source 172.18.0.10 --random-fully
+-t nat -A OVN-POSTROUTING -m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -j OVN-MASQUERADE
+-t mangle -A POSTROUTING -m comment --comment kube-ovn postrouting rules -j OVN-POSTROUTING
+-t mangle -A OVN-POSTROUTING -p tcp -m set --match-set ovn40subnets src -m tcp --tcp-flags RST RST -m state --state INVALID -j DROP
+`
+	// the dump is stable, and the nat gateway of the subnet is not claimed
+	for range 2 {
+		rules, err := c.DumpIptablesRules(kubeovnv1.ProtocolIPv4)
+		require.NoError(t, err)
+		var dump strings.Builder
+		for _, rule := range rules {
+			fmt.Fprintf(&dump, "\n-t %s -A %s %s", rule.Table, rule.Chain, strings.Join(rule.RuleSpec(), " "))
+		}
+		require.Equal(t, expected, dump.String()+"\n")
+	}
+}
+
+func TestNatDSCPPreserveRules(t *testing.T) {
+	values, err := parseDSCPValues("")
+	require.NoError(t, err)
+	require.Empty(t, values)
+	values, err = parseDSCPValues("46, 10,46")
+	require.NoError(t, err)
+	require.Equal(t, []uint8{10, 46}, values)
+	for _, s := range []string{"0", "64", "ef", "-1"} {
+		_, err = parseDSCPValues(s)
+		require.Error(t, err, s)
+	}
+
+	newSubnet := func(name, cidr, dscp string, natOutgoing bool) *kubeovnv1.Subnet {
+		subnet := newTestSubnet(name, cidr, natOutgoing)
+		if dscp != "" {
+			subnet.Annotations = map[string]string{util.NatPreserveDSCPAnnotation: dscp}
+		}
+		return subnet
+	}
+	c := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4,
+		newSubnet("ovn-default", "10.16.0.0/16", "", true),
+		newSubnet("voice", "10.17.0.0/16", "34", true),
+		newSubnet("invalid", "10.18.0.0/16", "64", true),
+		newSubnet("no-nat", "10.19.0.0/16", "34", false),
+	).c
+	dscpRules := func() []string {
+		rules, err := c.DumpIptablesRules(kubeovnv1.ProtocolIPv4)
+		require.NoError(t, err)
+		var result []string
+		for _, rule := range rules {
+			if rule.Table == MANGLE && slices.Contains(rule.Rule, "dscp") {
+				result = append(result, strings.Join(rule.RuleSpec(), " "))
+			}
+		}
+		return result
+	}
+
+	// only the annotated subnet preserves the dscp values if disabled globally
+	require.Equal(t, []string{
+		"-s 10.17.0.0/16 -m set ! --match-set ovn40subnets dst -m connmark --mark 0x0/0x3f000000 -m dscp --dscp 34 -j CONNMARK --set-xmark 0x22000000/0x3f000000",
+		"-s 10.17.0.0/16 -m set ! --match-set ovn40subnets dst -m connmark --mark 0x22000000/0x3f000000 -m dscp ! --dscp 34 -j DSCP --set-dscp 34",
+	}, dscpRules())
+
+	c.config.NatPreserveDSCPValues = []uint8{46}
+	require.Equal(t, []string{
+		"-s 10.17.0.0/16 -m set ! --match-set ovn40subnets dst -m connmark --mark 0x0/0x3f000000 -m dscp --dscp 34 -j CONNMARK --set-xmark 0x22000000/0x3f000000",
+		"-s 10.17.0.0/16 -m set ! --match-set ovn40subnets dst -m connmark --mark 0x22000000/0x3f000000 -m dscp ! --dscp 34 -j DSCP --set-dscp 34",
+		"-m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -m connmark --mark 0x0/0x3f000000 -m dscp --dscp 46 -j CONNMARK --set-xmark 0x2e000000/0x3f000000",
+		"-m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -m connmark --mark 0x2e000000/0x3f000000 -m dscp ! --dscp 46 -j DSCP --set-dscp 46",
+	}, dscpRules())
+}
+
+func TestExGatewayNatExemptRules(t *testing.T) {
+	require.Equal(t, map[string][]string{
+		kubeovnv1.ProtocolIPv4: {"192.168.100.0/24", "172.20.0.0/16"},
+		kubeovnv1.ProtocolIPv6: {"fd00:100::/64"},
+	}, exGatewayNatExemptCIDRs(map[string]string{exGatewayNatExemptCIDRsKey: "192.168.100.0/24, 192.168.100.5/24,fd00:100::1/64,invalid,,172.20.0.0/16"}))
+	require.Empty(t, exGatewayNatExemptCIDRs(nil))
+
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4, newTestSubnet("ovn-default", "10.16.0.0/16", true))
+	c := f.c
+	natPostroutingRules := func() []string {
+		rules, err := c.DumpIptablesRules(kubeovnv1.ProtocolIPv4)
+		require.NoError(t, err)
+		var result []string
+		for _, rule := range rules {
+			if rule.Table == NAT && rule.Chain == OvnPostrouting {
+				result = append(result, strings.Join(rule.RuleSpec(), " "))
+			}
+		}
+		return result
+	}
+	exemptRule := "-m set --match-set ovn40subnets-nat src -d 192.168.100.0/24 -j RETURN"
+	policyRule := "-m set --match-set ovn40subnets-nat-policy src -m set ! --match-set ovn40subnets dst -j OVN-NAT-POLICY"
+	masqueradeRule := "-m set --match-set ovn40subnets-nat src -m set ! --match-set ovn40subnets dst -j OVN-MASQUERADE"
+
+	// all the nat outgoing traffic is masqueraded without the configmap
+	require.NotContains(t, natPostroutingRules(), exemptRule)
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: util.ExternalGatewayConfig, Namespace: "kube-system"},
+		Data:       map[string]string{"enable-external-gw": "true", exGatewayNatExemptCIDRsKey: "192.168.100.0/24,fd00:100::/64"},
+	}
+	require.NoError(t, f.configMaps.Add(cm))
+
+	// the traffic to the external network returns before the nat outgoing rules, the other traffic is still masqueraded
+	rules := natPostroutingRules()
+	require.Equal(t, 1, slices.Index(rules, policyRule)-slices.Index(rules, exemptRule))
+	require.Contains(t, rules, masqueradeRule)
+	require.NotContains(t, strings.Join(rules, "\n"), "fd00:100::/64")
+
+	cm = cm.DeepCopy()
+	cm.Data["enable-external-gw"] = "false"
+	require.NoError(t, f.configMaps.Update(cm))
+	require.NotContains(t, natPostroutingRules(), exemptRule)
+}
+
+func TestSetIPSetU2OSubnetNat(t *testing.T) {
+	u2o := newTestSubnet("u2o", "10.17.0.0/16", true)
+	u2o.Spec.GatewayType = kubeovnv1.GWCentralizedType
+	u2o.Spec.Vlan = "vlan1"
+	u2o.Spec.LogicalGateway = true
+	u2o.Spec.U2OInterconnection = true
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4, newTestSubnet("ovn-default", "10.16.0.0/16", true), u2o)
+	c, fakes := f.c, f.ipsets[kubeovnv1.ProtocolIPv4]
+
+	// the u2o subnet is masqueraded as before by default
+	require.NoError(t, c.setIPSet())
+	require.ElementsMatch(t, []string{"10.16.0.0/16", "10.17.0.0/16"}, fakes.applied[SubnetSet])
+	require.ElementsMatch(t, []string{"10.16.0.0/16", "10.17.0.0/16"}, fakes.applied[SubnetNatSet])
+
+	// the annotated u2o subnet is excluded from the nat subnets
+	u2o = u2o.DeepCopy()
+	u2o.Annotations = map[string]string{util.U2ONoNatAnnotation: "true"}
+	require.NoError(t, f.subnets.Update(u2o))
+	require.NoError(t, c.setIPSet())
+	require.ElementsMatch(t, []string{"10.16.0.0/16", "10.17.0.0/16"}, fakes.applied[SubnetSet])
+	require.ElementsMatch(t, []string{"10.16.0.0/16"}, fakes.applied[SubnetNatSet])
+}
+
+// fakeOvsVsctlCommand is the output of the ovs-vsctl command with the arguments, the command fails if fail is set
+type fakeOvsVsctlCommand struct {
+	args   string
+	output string
+	fail   bool
+}
+
+// fakeOvsVsctl replaces ovs-vsctl in PATH with a script replying the commands, the commands not listed succeed
+// with no output. It returns the function listing the arguments of the commands run, without the global options
+func fakeOvsVsctl(t *testing.T, commands ...fakeOvsVsctlCommand) func() []string {
+	t.Helper()
+	dir := t.TempDir()
+	var script strings.Builder
+	fmt.Fprintf(&script, "#!/bin/sh\necho \"$*\" >> %s/commands\ncase \"$*\" in\n", dir)
+	for _, cmd := range commands {
+		exitCode := 0
+		if cmd.fail {
+			exitCode = 1
+		}
+		fmt.Fprintf(&script, "*%q) printf '%%s\\n' '%s'; exit %d ;;\n", " "+cmd.args, cmd.output, exitCode)
+	}
+	script.WriteString("esac\n")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ovs.OvsVsCtl), []byte(script.String()), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return func() []string {
+		data, err := os.ReadFile(filepath.Join(dir, "commands"))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		require.NoError(t, err)
+		var run []string
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			run = append(run, strings.TrimPrefix(line, "--timeout=30 "))
+		}
+		return run
+	}
+}
+
+func TestRemoveExGatewayNicFromBridge(t *testing.T) {
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4)
+	c, kubeClient := f.c, f.kubeClient
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: util.ExternalGatewayConfig, Namespace: "kube-system"},
+		Data:       map[string]string{"external-gw-nic": "eth1", exGatewayDisableModeKey: exGatewayDisableModeRemoveNic},
+	}
+	_, err := kubeClient.CoreV1().ConfigMaps(cm.Namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// only the nic is removed from the bridge which still has other uplinks
+	commands := fakeOvsVsctl(t,
+		fakeOvsVsctlCommand{args: "port-to-br eth1", output: "br-external"},
+		fakeOvsVsctlCommand{args: "list-ports br-external", output: "eth1\neth2\npatch-br-external-to-br-int"},
+	)
+	kept, err := c.removeExGatewayNic("br-external")
+	require.NoError(t, err)
+	require.True(t, kept)
+	require.Equal(t, []string{"port-to-br eth1", "list-ports br-external", "--if-exists del-port br-external eth1"}, commands())
+
+	// the bridge is kept without touching it again after the nic removed
+	commands = fakeOvsVsctl(t, fakeOvsVsctlCommand{args: "port-to-br eth1", output: "ovs-vsctl: no port named eth1", fail: true})
+	kept, err = c.removeExGatewayNic("br-external")
+	require.NoError(t, err)
+	require.True(t, kept)
+	require.Equal(t, []string{"port-to-br eth1"}, commands())
+
+	// the bridge mapping is removed with the last uplink, and the bridge is left to be deleted
+	commands = fakeOvsVsctl(t,
+		fakeOvsVsctlCommand{args: "port-to-br eth1", output: "br-external"},
+		fakeOvsVsctlCommand{args: "list-ports br-external", output: "eth1\npatch-br-external-to-br-int"},
+		fakeOvsVsctlCommand{args: "get open . external-ids:ovn-bridge-mappings", output: `"external:br-external,provider:br-provider"`},
+	)
+	kept, err = c.removeExGatewayNic("br-external")
+	require.NoError(t, err)
+	require.False(t, kept)
+	require.Equal(t, []string{
+		"port-to-br eth1",
+		"list-ports br-external",
+		"--if-exists get open . external-ids:ovn-bridge-mappings",
+		"set open . external-ids:ovn-bridge-mappings=provider:br-provider",
+	}, commands())
+
+	// the bridge is deleted by the caller as before if not in remove-nic mode
+	cm.Data[exGatewayDisableModeKey] = ""
+	_, err = kubeClient.CoreV1().ConfigMaps(cm.Namespace).Update(context.Background(), cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	kept, err = c.removeExGatewayNic("br-external")
+	require.NoError(t, err)
+	require.False(t, kept)
+	require.Equal(t, []string{"port-to-br eth1"}, commands()[4:])
+}
+
+func TestSetExGatewayKeepNicAddr(t *testing.T) {
+	// the links are created in a new network namespace of the locked thread, which is never unlocked
+	// so that the thread is terminated with the test goroutine
+	runtime.LockOSThread()
+	if err := unix.Unshare(unix.CLONE_NEWNET); err != nil {
+		t.Skipf("failed to create network namespace: %v", err)
+	}
+
+	require.NoError(t, netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "eth1"}, PeerName: "eth1-peer"}))
+	require.NoError(t, netlink.LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "br-external"}}))
+	nic, err := netlink.LinkByName("eth1")
+	require.NoError(t, err)
+	peer, err := netlink.LinkByName("eth1-peer")
+	require.NoError(t, err)
+	bridge, err := netlink.LinkByName("br-external")
+	require.NoError(t, err)
+	require.NoError(t, netlink.LinkSetUp(nic))
+	require.NoError(t, netlink.LinkSetUp(peer))
+	addr, err := netlink.ParseAddr("192.168.100.10/24")
+	require.NoError(t, err)
+	require.NoError(t, netlink.AddrAdd(nic, addr))
+	_, dst, err := net.ParseCIDR("10.100.0.0/16")
+	require.NoError(t, err)
+	require.NoError(t, netlink.RouteAdd(&netlink.Route{LinkIndex: nic.Attrs().Index, Dst: dst, Gw: net.ParseIP("192.168.100.1")}))
+
+	linkIPs := func(link netlink.Link) []string {
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+		require.NoError(t, err)
+		var ips []string
+		for _, addr := range addrs {
+			ips = append(ips, addr.IPNet.String())
+		}
+		return ips
+	}
+	linkRoutes := func(link netlink.Link) []string {
+		routes, err := netlink.RouteList(link, netlink.FAMILY_V4)
+		require.NoError(t, err)
+		var dsts []string
+		for _, route := range routes {
+			if route.Gw != nil {
+				dsts = append(dsts, route.Dst.String()+" via "+route.Gw.String())
+			}
+		}
+		return dsts
+	}
+
+	f := newGatewayTestFixture(t, kubeovnv1.ProtocolIPv4)
+	node := newTestNode("node1")
+	node.Labels = map[string]string{util.ExGatewayLabel: "true"}
+	require.NoError(t, f.nodes.Update(node))
+	_, err = f.kubeClient.CoreV1().ConfigMaps("kube-system").Create(context.Background(), &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: util.ExternalGatewayConfig, Namespace: "kube-system"},
+		Data:       map[string]string{"external-gw-nic": "eth1", exGatewayKeepNicAddrKey: "true"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	c := f.c
+	c.nmSyncer = &networkManagerSyncer{}
+
+	// the addresses and routes of the nic are transferred to the bridge when the nic is attached
+	commands := fakeOvsVsctl(t, fakeOvsVsctlCommand{args: "port-to-br eth1", output: "ovs-vsctl: no port named eth1", fail: true})
+	require.NoError(t, c.setExGateway())
+	require.Empty(t, linkIPs(nic))
+	require.Equal(t, []string{"192.168.100.10/24"}, linkIPs(bridge))
+	require.Empty(t, linkRoutes(nic))
+	require.Equal(t, []string{"10.100.0.0/16 via 192.168.100.1"}, linkRoutes(bridge))
+	require.Contains(t, commands(), "--may-exist add-br br-external -- --may-exist add-port br-external eth1")
+
+	// the addresses of the attached nic are left untouched in the later rounds
+	addr, err = netlink.ParseAddr("192.168.200.10/24")
+	require.NoError(t, err)
+	require.NoError(t, netlink.AddrAdd(nic, addr))
+	commands = fakeOvsVsctl(t, fakeOvsVsctlCommand{args: "port-to-br eth1", output: "br-external"})
+	require.NoError(t, c.setExGateway())
+	require.Equal(t, []string{"192.168.200.10/24"}, linkIPs(nic))
+	require.Equal(t, []string{"192.168.100.10/24"}, linkIPs(bridge))
+	for _, cmd := range commands() {
+		require.NotContains(t, cmd, "add-br")
+		require.NotContains(t, cmd, "set bridge br-external")
+	}
+}
+
+func TestReconcileOvnBridgeMappings(t *testing.T) {
+	// the mapping of the external gateway bridge, which is not labeled with the vendor, is kept without writes
+	commands := fakeOvsVsctl(t,
+		fakeOvsVsctlCommand{args: "get open . external-ids:ovn-bridge-mappings", output: `"external:br-external,provider:br-provider"`},
+		fakeOvsVsctlCommand{args: "list-br", output: "br-external\nbr-int\nbr-provider"},
+	)
+	for range 2 {
+		require.NoError(t, reconcileOvnBridgeMappings(context.Background()))
+	}
+	require.Equal(t, []string{
+		"--if-exists get open . external-ids:ovn-bridge-mappings",
+		"list-br",
+		"--if-exists get open . external-ids:ovn-bridge-mappings",
+		"list-br",
+	}, commands())
+
+	// the mapping of the bridge deleted out-of-band is removed
+	commands = fakeOvsVsctl(t,
+		fakeOvsVsctlCommand{args: "get open . external-ids:ovn-bridge-mappings", output: `"external:br-external,provider:br-provider"`},
+		fakeOvsVsctlCommand{args: "list-br", output: "br-int\nbr-provider"},
+	)
+	require.NoError(t, reconcileOvnBridgeMappings(context.Background()))
+	require.Equal(t, "set open . external-ids:ovn-bridge-mappings=provider:br-provider", commands()[2])
+
+	// nothing is listed without mappings
+	commands = fakeOvsVsctl(t)
+	require.NoError(t, reconcileOvnBridgeMappings(context.Background()))
+	require.Equal(t, []string{"--if-exists get open . external-ids:ovn-bridge-mappings"}, commands())
+}
@@ -1,11 +1,26 @@
 package daemon
 
 import (
+	"context"
+	"errors"
+	"math/rand/v2"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	coordinationlisterv1 "k8s.io/client-go/listers/coordination/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
 func TestGetCidrByProtocol(t *testing.T) {
@@ -59,3 +74,367 @@ func TestGetCidrByProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestNatGatewayHandoff(t *testing.T) {
+	type node struct {
+		name    string
+		alive   bool // the daemon renews the lease it holds
+		snat    bool
+		pending bool // snat rules applied but the subnet is not released yet
+	}
+
+	var holder, activeGateway string
+	nodes := []*node{{name: "node1", alive: true}, {name: "node2", alive: true}}
+	isValid := func(name string) bool {
+		for _, n := range nodes {
+			if n.name == name {
+				return n.alive
+			}
+		}
+		return false
+	}
+	// apply iptables rules of a gateway cycle, the claim is an optimistic update of the lease
+	apply := func(n *node) {
+		observed := holder
+		claim, snat, release := natGatewayHandoff(n.name, activeGateway, observed, observed != "" && isValid(observed))
+		if claim {
+			if holder != observed {
+				return
+			}
+			holder = n.name
+		}
+		n.snat, n.pending = snat, release
+	}
+	// release the subnet after the iptables rules are applied
+	release := func(n *node) {
+		if n.pending && holder == n.name {
+			holder = ""
+		}
+		n.pending = false
+	}
+	check := func() {
+		require.False(t, nodes[0].snat && nodes[1].snat, "double snat with active gateway %q and holder %q", activeGateway, holder)
+	}
+
+	// all daemons are alive, the active gateway changes at any time
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 10000; i++ {
+		switch r.IntN(7) {
+		case 0:
+			activeGateway = nodes[r.IntN(len(nodes))].name
+		case 1, 2, 3:
+			apply(nodes[r.IntN(len(nodes))])
+		default:
+			release(nodes[r.IntN(len(nodes))])
+		}
+		check()
+	}
+
+	// the new active gateway takes over once the old one has released the subnet
+	activeGateway = "node2"
+	apply(nodes[0])
+	apply(nodes[1])
+	release(nodes[1])
+	check()
+	require.False(t, nodes[0].snat)
+	release(nodes[0])
+	apply(nodes[1])
+	require.True(t, nodes[1].snat)
+	require.Equal(t, "node2", holder)
+
+	// the new active gateway takes over once the lease of the old one expires, e.g. its daemon is dead
+	activeGateway = "node1"
+	nodes[1].alive = false
+	apply(nodes[0])
+	require.True(t, nodes[0].snat)
+	require.Equal(t, "node1", holder)
+}
+
+func TestNatGatewayLease(t *testing.T) {
+	subnetIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	subnet := &kubeovnv1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "central"},
+		Spec: kubeovnv1.SubnetSpec{
+			Vpc:         "ovn-cluster",
+			CIDRBlock:   "10.17.0.0/16",
+			NatOutgoing: true,
+			GatewayType: kubeovnv1.GWCentralizedType,
+			GatewayNode: "node1:172.18.0.2,node2:172.18.0.3",
+		},
+		Status: kubeovnv1.SubnetStatus{ActivateGateway: "node1"},
+	}
+	require.NoError(t, subnetIndexer.Add(subnet))
+	for _, name := range []string{"node1", "node2"} {
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}}
+		require.NoError(t, nodeIndexer.Add(node))
+	}
+	leaseIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	kubeClient := fake.NewSimpleClientset()
+	newController := func() *Controller {
+		return &Controller{
+			config:        &Configuration{ClusterRouter: "ovn-cluster", KubeClient: kubeClient, PodNamespace: "kube-system"},
+			subnetsLister: kubeovnlister.NewSubnetLister(subnetIndexer),
+			nodesLister:   listerv1.NewNodeLister(nodeIndexer),
+			leasesLister:  coordinationlisterv1.NewLeaseLister(leaseIndexer),
+		}
+	}
+	node1, node2 := newController(), newController()
+	// syncLeases updates the lease cache with the leases written to the api server
+	syncLeases := func() {
+		leases, err := kubeClient.CoordinationV1().Leases("kube-system").List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		items := make([]any, 0, len(leases.Items))
+		for i := range leases.Items {
+			items = append(items, &leases.Items[i])
+		}
+		require.NoError(t, leaseIndexer.Replace(items, ""))
+	}
+	getEgressNatIPByNode := func(c *Controller, nodeName string, dryRun bool) (map[string]string, []string, error) {
+		defer syncLeases()
+		return c.getEgressNatIPByNode(nodeName, dryRun)
+	}
+	getHolder := func() (string, bool) {
+		lease, err := node1.getNatGatewayLease(subnet.Name)
+		require.NoError(t, err)
+		return natGatewayLeaseHolder(lease, time.Now())
+	}
+
+	// the active gateway creates the lease
+	natIPs, toRelease, err := getEgressNatIPByNode(node1, "node1", false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"10.17.0.0/16": "172.18.0.2"}, natIPs)
+	require.Empty(t, toRelease)
+	holder, valid := getHolder()
+	require.Equal(t, "node1", holder)
+	require.True(t, valid)
+
+	// the new active gateway does not take over before the old one releases the lease
+	subnet.Status.ActivateGateway = "node2"
+	natIPs, _, err = getEgressNatIPByNode(node2, "node2", false)
+	require.NoError(t, err)
+	require.Empty(t, natIPs)
+	_, toRelease, err = getEgressNatIPByNode(node1, "node1", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{subnet.Name}, toRelease)
+	require.NoError(t, node1.releaseNatGateways("node1", toRelease))
+	syncLeases()
+	holder, _ = getHolder()
+	require.Empty(t, holder)
+	natIPs, _, err = getEgressNatIPByNode(node2, "node2", false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"10.17.0.0/16": "172.18.0.3"}, natIPs)
+
+	// the lease of a ready node whose daemon is dead expires and is taken over
+	subnet.Status.ActivateGateway = "node1"
+	natIPs, _, err = getEgressNatIPByNode(node1, "node1", false)
+	require.NoError(t, err)
+	require.Empty(t, natIPs)
+	lease, err := node1.getNatGatewayLease(subnet.Name)
+	require.NoError(t, err)
+	lease = lease.DeepCopy()
+	lease.Spec.RenewTime = ptr.To(metav1.NewMicroTime(time.Now().Add(-2 * natGatewayLeaseDuration)))
+	_, err = kubeClient.CoordinationV1().Leases("kube-system").Update(context.Background(), lease, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	syncLeases()
+	natIPs, _, err = getEgressNatIPByNode(node1, "node1", false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"10.17.0.0/16": "172.18.0.2"}, natIPs)
+	holder, valid = getHolder()
+	require.Equal(t, "node1", holder)
+	require.True(t, valid)
+
+	// the leases of the subnets no longer centralized on the node are released
+	subnet.Spec.GatewayNode = "node2:172.18.0.3"
+	natIPs, toRelease, err = getEgressNatIPByNode(node1, "node1", true)
+	require.NoError(t, err)
+	require.Empty(t, natIPs)
+	require.Equal(t, []string{subnet.Name}, toRelease)
+
+	// the snat rules are kept while the lease held by the node is valid if the lease fails to be read
+	subnet.Spec.GatewayNode = "node1:172.18.0.2,node2:172.18.0.3"
+	node1.leasesLister = failingLeaseLister{}
+	natIPs, _, err = node1.getEgressNatIPByNode("node1", false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"10.17.0.0/16": "172.18.0.2"}, natIPs)
+	node1.recordNatGatewayLease(subnet.Name, time.Now().Add(-time.Second))
+	natIPs, _, err = node1.getEgressNatIPByNode("node1", false)
+	require.NoError(t, err)
+	require.Empty(t, natIPs)
+}
+
+// failingLeaseLister is a lease lister failing to get any lease
+type failingLeaseLister struct {
+	coordinationlisterv1.LeaseLister
+}
+
+func (failingLeaseLister) Leases(string) coordinationlisterv1.LeaseNamespaceLister {
+	return failingLeaseNamespaceLister{}
+}
+
+type failingLeaseNamespaceLister struct {
+	coordinationlisterv1.LeaseNamespaceLister
+}
+
+func (failingLeaseNamespaceLister) Get(string) (*coordinationv1.Lease, error) {
+	return nil, errors.New("failed to get lease")
+}
+
+func TestExpectedActivateGateway(t *testing.T) {
+	subnet := &kubeovnv1.Subnet{
+		Spec: kubeovnv1.SubnetSpec{GatewayNode: "node1:172.18.0.2, node2, node3:172.18.0.4"},
+	}
+
+	cases := []struct {
+		name   string
+		active string
+		health []string
+		expect string
+	}{
+		{"first healthy node", "", []string{"node3", "node2"}, "node2"},
+		{"keep healthy active gateway", "node3", []string{"node1", "node2", "node3"}, "node3"},
+		{"active gateway not ready", "node1", []string{"node3"}, "node3"},
+		{"active gateway not in gateway nodes", "node4", []string{"node1", "node4"}, "node1"},
+		{"no healthy node", "node1", nil, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			subnet.Status.ActivateGateway = c.active
+			for range 10 {
+				require.Equal(t, c.expect, expectedActivateGateway(subnet, set.New(c.health...)))
+			}
+		})
+	}
+}
+
+func TestIsActivateGatewayStale(t *testing.T) {
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for name, status := range map[string]v1.ConditionStatus{"node1": v1.ConditionFalse, "node2": v1.ConditionTrue} {
+		node := &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: status}}},
+		}
+		require.NoError(t, nodeIndexer.Add(node))
+	}
+	c := &Controller{nodesLister: listerv1.NewNodeLister(nodeIndexer)}
+
+	subnet := &kubeovnv1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "subnet"},
+		Spec:       kubeovnv1.SubnetSpec{GatewayNode: "node1,node2,node3"},
+	}
+	for active, expectStale := range map[string]bool{"node1": true, "node2": false, "node3": true, "": true} {
+		subnet.Status.ActivateGateway = active
+		stale, expected := c.isActivateGatewayStale(subnet)
+		require.Equal(t, expectStale, stale, "active gateway %q", active)
+		require.Equal(t, "node2", expected)
+	}
+
+	subnet.Spec.GatewayNode = "node1,node3"
+	subnet.Status.ActivateGateway = ""
+	stale, expected := c.isActivateGatewayStale(subnet)
+	require.False(t, stale)
+	require.Empty(t, expected)
+
+	// the staleness is reported on transitions only
+	require.False(t, c.recordActivateGatewayStale(subnet, false, ""))
+	subnet.Status.ActivateGateway = "node1"
+	require.True(t, c.recordActivateGatewayStale(subnet, true, "node2"))
+	require.False(t, c.recordActivateGatewayStale(subnet, true, "node2"))
+	require.True(t, c.recordActivateGatewayStale(subnet, true, "node3"))
+	subnet.Status.ActivateGateway = "node3"
+	require.True(t, c.recordActivateGatewayStale(subnet, false, "node3"))
+	require.False(t, c.recordActivateGatewayStale(subnet, false, "node3"))
+	require.Empty(t, c.staleActivateGateways)
+}
+
+func TestRemoveExGatewayNic(t *testing.T) {
+	// removing one of the two uplinks keeps the bridge
+	ports := []string{"eth1", "eth2", "patch-br-external-to-br-int"}
+	require.Equal(t, []string{"eth2"}, exGatewayOtherUplinks(ports, "eth1"))
+	// the bridge is deleted after the last uplink removed
+	require.Empty(t, exGatewayOtherUplinks([]string{"eth1", "patch-br-external-to-br-int"}, "eth1"))
+	require.Empty(t, exGatewayOtherUplinks(nil, "eth1"))
+
+	// the bridge is deleted as before if not in remove-nic mode
+	kubeClient := fake.NewSimpleClientset()
+	c := &Controller{config: &Configuration{KubeClient: kubeClient, ExternalGatewayConfigNS: "kube-system"}}
+	kept, err := c.removeExGatewayNic("br-external")
+	require.NoError(t, err)
+	require.False(t, kept)
+
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: util.ExternalGatewayConfig, Namespace: "kube-system"},
+		Data:       map[string]string{"external-gw-nic": "eth1"},
+	}
+	_, err = kubeClient.CoreV1().ConfigMaps(cm.Namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+	require.NoError(t, err)
+	kept, err = c.removeExGatewayNic("br-external")
+	require.NoError(t, err)
+	require.False(t, kept)
+}
+
+func TestExGatewayKeepNicAddr(t *testing.T) {
+	require.False(t, exGatewayKeepNicAddr(nil))
+	require.False(t, exGatewayKeepNicAddr(map[string]string{"external-gw-nic": "eth1"}))
+	require.False(t, exGatewayKeepNicAddr(map[string]string{exGatewayKeepNicAddrKey: "false"}))
+	require.False(t, exGatewayKeepNicAddr(map[string]string{exGatewayKeepNicAddrKey: "yes"}))
+	require.True(t, exGatewayKeepNicAddr(map[string]string{exGatewayKeepNicAddrKey: "true"}))
+}
+
+func TestRunGatewaySteps(t *testing.T) {
+	errExGateway := errors.New("failed to set ex gateway")
+	calls := make(map[gatewayStep]int)
+	newStep := func(step gatewayStep, err error) gatewayStepFunc {
+		return gatewayStepFunc{step, func() error {
+			calls[step]++
+			return err
+		}}
+	}
+	steps := []gatewayStepFunc{
+		newStep(gatewayStepIPSet, nil),
+		newStep(gatewayStepPolicyRouting, nil),
+		newStep(gatewayStepIptables, nil),
+		newStep(gatewayStepExGateway, errExGateway),
+	}
+
+	result := runGatewaySteps(steps)
+	require.Equal(t, []gatewayStep{gatewayStepExGateway}, result.FailedSteps())
+	require.Equal(t, []gatewayStep{gatewayStepIPSet, gatewayStepPolicyRouting, gatewayStepIptables}, result.SucceededSteps())
+	require.True(t, result.Failed(gatewayStepExGateway))
+	require.False(t, result.Failed(gatewayStepIPSet))
+	require.ErrorIs(t, result.Err(gatewayStepExGateway), errExGateway)
+	require.NoError(t, result.Err(gatewayStepIptables))
+	for _, s := range steps {
+		require.Equal(t, 1, calls[s.step])
+	}
+
+	// only the failed step is retried
+	result = retryGatewaySteps(steps, result, time.Millisecond)
+	require.Equal(t, []gatewayStep{gatewayStepExGateway}, result.FailedSteps())
+	require.Equal(t, 1+gatewayRetrySteps, calls[gatewayStepExGateway])
+	require.Equal(t, 1, calls[gatewayStepIPSet])
+	require.Equal(t, 1, calls[gatewayStepIptables])
+
+	// no retry if disabled
+	result = retryGatewaySteps(steps, result, 0)
+	require.True(t, result.Failed(gatewayStepExGateway))
+	require.Equal(t, 1+gatewayRetrySteps, calls[gatewayStepExGateway])
+}
+
+func TestRetryGatewayStepsRecovered(t *testing.T) {
+	var attempts int
+	steps := []gatewayStepFunc{
+		{gatewayStepIPSet, func() error { return nil }},
+		{gatewayStepICGateway, func() error {
+			if attempts++; attempts < 2 {
+				return errors.New("ovs-vsctl timeout")
+			}
+			return nil
+		}},
+	}
+
+	result := retryGatewaySteps(steps, runGatewaySteps(steps), time.Millisecond)
+	require.Empty(t, result.FailedSteps())
+	require.Equal(t, 2, attempts)
+}
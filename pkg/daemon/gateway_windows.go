@@ -30,6 +30,11 @@ func (c *Controller) gcIPSet() error {
 	return nil
 }
 
+func (c *Controller) TeardownGateway() error {
+	// nothing to do on Windows
+	return nil
+}
+
 func (c *Controller) addEgressConfig(subnet *kubeovnv1.Subnet, ip string) error {
 	// nothing to do on Windows
 	return nil
@@ -64,12 +69,12 @@ func (c *Controller) setExGateway() error {
 
 		externalBrReady := false
 		// if external nic already attached into another bridge
-		if existBr, err := ovs.Exec("port-to-br", linkName); err == nil {
+		if existBr, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, "port-to-br", linkName); err == nil {
 			if existBr == externalBridge {
 				externalBrReady = true
 			} else {
 				klog.Infof("external bridge should change from %s to %s, delete external bridge %s", existBr, externalBridge, existBr)
-				if _, err := ovs.Exec(ovs.IfExists, "del-br", existBr); err != nil {
+				if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, ovs.IfExists, "del-br", existBr); err != nil {
 					err = fmt.Errorf("failed to del external br %s, %v", existBr, err)
 					klog.Error(err)
 					return err
@@ -78,7 +83,7 @@ func (c *Controller) setExGateway() error {
 		}
 
 		if !externalBrReady {
-			if _, err := ovs.Exec(
+			if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout,
 				ovs.MayExist, "add-br", externalBridge, "--",
 				ovs.MayExist, "add-port", externalBridge, linkName,
 			); err != nil {
@@ -86,7 +91,7 @@ func (c *Controller) setExGateway() error {
 				klog.Error(err)
 			}
 		}
-		if err = addOvnMapping("ovn-bridge-mappings", c.config.ExternalGatewaySwitch, externalBridge, true); err != nil {
+		if err = addOvnMapping(context.Background(), "ovn-bridge-mappings", c.config.ExternalGatewaySwitch, externalBridge, true); err != nil {
 			klog.Error(err)
 			return err
 		}
@@ -107,7 +112,7 @@ func (c *Controller) setExGateway() error {
 
 		for _, pn := range providerNetworks {
 			// if external nic already attached into another bridge
-			if existBr, err := ovs.Exec("port-to-br", pn.Spec.DefaultInterface); err == nil {
+			if existBr, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout, "port-to-br", pn.Spec.DefaultInterface); err == nil {
 				if existBr == externalBridge {
 					// delete switch after related provider network not exist
 					return nil
@@ -129,8 +134,16 @@ func (c *Controller) setExGateway() error {
 		}
 
 		if !keepExternalSubnet {
+			kept, err := c.removeExGatewayNic(externalBridge)
+			if err != nil {
+				klog.Error(err)
+				return err
+			}
+			if kept {
+				return nil
+			}
 			klog.Infof("delete external bridge %s", externalBridge)
-			if _, err := ovs.Exec(
+			if _, err := ovs.ExecWithTimeout(gatewayOvsExecTimeout,
 				ovs.IfExists, "del-br", externalBridge); err != nil {
 				err = fmt.Errorf("failed to disable external gateway, %v", err)
 				klog.Error(err)
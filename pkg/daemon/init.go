@@ -138,7 +138,7 @@ func (c *Controller) ovsInitProviderNetwork(provider, nic string, trunks []strin
 }
 
 func (c *Controller) ovsCleanProviderNetwork(provider string) error {
-	mappings, err := getOvnMappings("ovn-bridge-mappings")
+	mappings, err := getOvnMappings(context.Background(), "ovn-bridge-mappings")
 	if err != nil {
 		klog.Error(err)
 		return err
@@ -208,9 +208,9 @@ func (c *Controller) ovsCleanProviderNetwork(provider string) error {
 		}
 	}
 
-	if err := removeOvnMapping("ovn-chassis-mac-mappings", provider); err != nil {
+	if err := removeOvnMapping(context.Background(), "ovn-chassis-mac-mappings", provider); err != nil {
 		klog.Error(err)
 		return err
 	}
-	return removeOvnMapping("ovn-bridge-mappings", provider)
+	return removeOvnMapping(context.Background(), "ovn-bridge-mappings", provider)
 }
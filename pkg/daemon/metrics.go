@@ -68,6 +68,29 @@ var (
 		},
 	)
 
+	metricIPSetApplyDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ipset_apply_duration_seconds",
+			Help:    "The latency seconds of applying the updates of the managed ipsets",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{
+			"hostname",
+			"protocol",
+			"set",
+		},
+	)
+
+	metricIPSetApplyFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ipset_apply_failures_total",
+			Help: "The count of failures applying the updates of the managed ipsets",
+		}, []string{
+			"hostname",
+			"protocol",
+			"set",
+		},
+	)
+
 	metricIPLocalPortRange = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ip_local_port_range",
 		Help: "value of system parameter /proc/sys/net/ipv4/ip_local_port_range, which should not conflict with the nodeport range",
@@ -149,6 +172,8 @@ func InitMetrics() {
 func registerOvnSubnetGatewayMetrics() {
 	metrics.Registry.MustRegister(metricOvnSubnetGatewayPacketBytes)
 	metrics.Registry.MustRegister(metricOvnSubnetGatewayPackets)
+	metrics.Registry.MustRegister(metricIPSetApplyDuration)
+	metrics.Registry.MustRegister(metricIPSetApplyFailures)
 }
 
 func registerSystemParameterMetrics() {
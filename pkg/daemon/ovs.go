@@ -1,8 +1,10 @@
 package daemon
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -179,8 +181,8 @@ func encodeOvnMappings(mappings map[string]string) string {
 	return strings.Join(fields, ",")
 }
 
-func getOvnMappings(name string) (map[string]string, error) {
-	output, err := ovs.Exec(ovs.IfExists, "get", "open", ".", "external-ids:"+name)
+func getOvnMappings(ctx context.Context, name string) (map[string]string, error) {
+	output, err := ovs.ExecContext(ctx, ovs.IfExists, "get", "open", ".", "external-ids:"+name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get %s, %w: %q", name, err, output)
 	}
@@ -188,13 +190,13 @@ func getOvnMappings(name string) (map[string]string, error) {
 	return decodeOvnMappings(output), nil
 }
 
-func setOvnMappings(name string, mappings map[string]string) error {
+func setOvnMappings(ctx context.Context, name string, mappings map[string]string) error {
 	var err error
 	var output string
 	if s := encodeOvnMappings(mappings); len(s) == 0 {
-		output, err = ovs.Exec(ovs.IfExists, "remove", "open", ".", "external-ids", name)
+		output, err = ovs.ExecContext(ctx, ovs.IfExists, "remove", "open", ".", "external-ids", name)
 	} else {
-		output, err = ovs.Exec("set", "open", ".", fmt.Sprintf("external-ids:%s=%s", name, s))
+		output, err = ovs.ExecContext(ctx, "set", "open", ".", fmt.Sprintf("external-ids:%s=%s", name, s))
 	}
 	if err != nil {
 		return fmt.Errorf("failed to set %s, %w: %q", name, err, output)
@@ -203,8 +205,8 @@ func setOvnMappings(name string, mappings map[string]string) error {
 	return nil
 }
 
-func addOvnMapping(name, key, value string, overwrite bool) error {
-	mappings, err := getOvnMappings(name)
+func addOvnMapping(ctx context.Context, name, key, value string, overwrite bool) error {
+	mappings, err := getOvnMappings(ctx, name)
 	if err != nil {
 		klog.Error(err)
 		return err
@@ -215,11 +217,11 @@ func addOvnMapping(name, key, value string, overwrite bool) error {
 	}
 
 	mappings[key] = value
-	return setOvnMappings(name, mappings)
+	return setOvnMappings(ctx, name, mappings)
 }
 
-func removeOvnMapping(name, key string) error {
-	mappings, err := getOvnMappings(name)
+func removeOvnMapping(ctx context.Context, name, key string) error {
+	mappings, err := getOvnMappings(ctx, name)
 	if err != nil {
 		klog.Error(err)
 		return err
@@ -230,7 +232,46 @@ func removeOvnMapping(name, key string) error {
 	if len(mappings) == length {
 		return nil
 	}
-	return setOvnMappings(name, mappings)
+	return setOvnMappings(ctx, name, mappings)
+}
+
+// pruneOvnBridgeMappings removes the mappings whose bridges do not exist and returns the removed providers
+func pruneOvnBridgeMappings(mappings map[string]string, bridges []string) []string {
+	var pruned []string
+	for provider, bridge := range mappings {
+		if !slices.Contains(bridges, bridge) {
+			delete(mappings, provider)
+			pruned = append(pruned, provider)
+		}
+	}
+	slices.Sort(pruned)
+	return pruned
+}
+
+// reconcileOvnBridgeMappings removes the stale entries of ovn-bridge-mappings for bridges deleted out-of-band,
+// ovn-bridge-mappings is written only if any entry is removed. All the bridges are checked rather than the ones
+// created by Kube-OVN, e.g. the external gateway bridge is not labeled with the vendor
+func reconcileOvnBridgeMappings(ctx context.Context) error {
+	mappings, err := getOvnMappings(ctx, "ovn-bridge-mappings")
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	output, err := ovs.ExecContext(ctx, "list-br")
+	if err != nil {
+		klog.Errorf("failed to list OVS bridges: %v", err)
+		return err
+	}
+	bridges := strings.Fields(output)
+	if pruned := pruneOvnBridgeMappings(mappings, bridges); len(pruned) != 0 {
+		klog.Infof("remove ovn-bridge-mappings of providers %v whose bridges do not exist", pruned)
+		return setOvnMappings(ctx, "ovn-bridge-mappings", mappings)
+	}
+	return nil
 }
 
 func (c *Controller) configExternalBridge(provider, bridge, nic string, exchangeLinkName, macLearningFallback bool) error {
@@ -271,7 +312,7 @@ func (c *Controller) configExternalBridge(provider, bridge, nic string, exchange
 		}
 	}
 
-	if err = addOvnMapping("ovn-bridge-mappings", provider, bridge, true); err != nil {
+	if err = addOvnMapping(context.Background(), "ovn-bridge-mappings", provider, bridge, true); err != nil {
 		klog.Error(err)
 		return err
 	}
@@ -280,7 +321,7 @@ func (c *Controller) configExternalBridge(provider, bridge, nic string, exchange
 }
 
 func initProviderChassisMac(provider string) error {
-	if err := addOvnMapping("ovn-chassis-mac-mappings", provider, util.GenerateMac(), false); err != nil {
+	if err := addOvnMapping(context.Background(), "ovn-chassis-mac-mappings", provider, util.GenerateMac(), false); err != nil {
 		klog.Error(err)
 		return err
 	}
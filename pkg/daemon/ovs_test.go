@@ -0,0 +1,22 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneOvnBridgeMappings(t *testing.T) {
+	mappings := decodeOvnMappings("external:br-external,provider1:br-provider1,provider2:br-provider2")
+	pruned := pruneOvnBridgeMappings(mappings, []string{"br-int", "br-external", "br-provider2"})
+	require.Equal(t, []string{"provider1"}, pruned)
+	require.Equal(t, map[string]string{"external": "br-external", "provider2": "br-provider2"}, mappings)
+
+	pruned = pruneOvnBridgeMappings(mappings, []string{"br-int", "br-external", "br-provider2"})
+	require.Empty(t, pruned)
+	require.Len(t, mappings, 2)
+
+	pruned = pruneOvnBridgeMappings(mappings, nil)
+	require.Equal(t, []string{"external", "provider2"}, pruned)
+	require.Empty(t, mappings)
+}
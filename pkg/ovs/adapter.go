@@ -14,10 +14,28 @@ var ovsClientRequestLatency = prometheus.NewHistogramVec(
 	[]string{"db", "method", "code"},
 )
 
+var ovsClientStaticRouteThresholdExceeded = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "ovs_client_static_route_threshold_exceeded_total",
+		Help: "The number of times the static route count of a logical router exceeds the threshold",
+	},
+	[]string{"router"},
+)
+
+var ovsClientDanglingStaticRoutes = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "ovs_client_dangling_static_routes_total",
+		Help: "The number of times a static route referenced by a logical router fails to resolve",
+	},
+	[]string{"router"},
+)
+
 func init() {
 	registerOvsClientMetrics()
 }
 
 func registerOvsClientMetrics() {
 	metrics.Registry.MustRegister(ovsClientRequestLatency)
+	metrics.Registry.MustRegister(ovsClientStaticRouteThresholdExceeded)
+	metrics.Registry.MustRegister(ovsClientDanglingStaticRoutes)
 }
@@ -1,6 +1,8 @@
 package ovs
 
 import (
+	"time"
+
 	netv1 "k8s.io/api/networking/v1"
 
 	"github.com/ovn-org/libovsdb/ovsdb"
@@ -181,15 +183,67 @@ type AddressSet interface {
 
 type LogicalRouterStaticRoute interface {
 	AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix string, bfdID *string, externalIDs map[string]string, nexthops ...string) error
+	AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix string, bfdID *string, externalIDs map[string]string, nexthops ...string) error
+	AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix string, bfdIDs, externalIDs map[string]string) error
+	CreateLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string, bfdID *string, externalIDs map[string]string, options ...StaticRouteOption) error
+	RefreshHostnameRoutes(lrName, hostnameKey string) error
+	AddLogicalRouterBlackholeRoute(lrName, routeTable, ipPrefix string, externalIDs map[string]string) error
+	ImportLogicalRouterStaticRoutes(lrName string, routes []*ovnnb.LogicalRouterStaticRoute, strategy string) error
+	ReconcileRoutersStaticRoutes(specs map[string][]*ovnnb.LogicalRouterStaticRoute) (map[string]error, error)
+	TransactRoutersStaticRoutes(changes map[string]RouterStaticRouteChanges) error
+	SummarizeStaticRoutes(lrName, routeTable, nexthop, aggregatePrefix string) error
+	EnsureGatewayNatRoute(lrName, subnetName, cidr, nexthop string) error
+	CheckRouteTableReachable(lrName, routeTable string) (bool, error)
+	ReportUnreachableRouteTables(lrName string) ([]UnreachableRouteTable, error)
 	UpdateLogicalRouterStaticRoute(route *ovnnb.LogicalRouterStaticRoute, fields ...interface{}) error
+	UpdateLogicalRouterStaticRouteForceRecreate(lrName string, route *ovnnb.LogicalRouterStaticRoute, fields ...interface{}) error
 	ClearLogicalRouterStaticRoute(lrName string) error
+	ClearLogicalRouterStaticRoutesByPrefix(lrName, routeTable, policy, ipPrefix string) error
+	DisableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string) error
+	EnableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string) error
 	DeleteLogicalRouterStaticRoute(lrName string, routeTable, policy *string, ipPrefix, nextHop string) error
 	DeleteLogicalRouterStaticRouteByUUID(lrName, uuid string) error
 	DeleteLogicalRouterStaticRouteByExternalIDs(lrName string, externalIDs map[string]string) error
+	DeleteLogicalRouterStaticRoutesByOptions(lrName string, options map[string]string) error
+	DeleteLogicalRouterStaticRoutesWhere(lrName string, predicate func(route *ovnnb.LogicalRouterStaticRoute) bool) error
+	PruneStaticRoutesByGeneration(lrName, generationKey, currentGeneration string) error
+	SweepExpiredStaticRoutes(lrName, expiryKey string) error
 	ListLogicalRouterStaticRoutesByOption(lrName, routeTable, key, value string) ([]*ovnnb.LogicalRouterStaticRoute, error)
 	ListLogicalRouterStaticRoutes(lrName string, routeTable, policy *string, ipPrefix string, externalIDs map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ListLogicalRouterStaticRoutesByExternalIDsAndOptions(lrName string, externalIDs, options map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ListLogicalRouterStaticRoutesOverlapping(lrName, cidr string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ListLogicalRouterStaticRoutesInPrefixRange(lrName, startCIDR, endCIDR string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ListLogicalRouterStaticRoutesByBFDState(lrName, state string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ListUnmanagedStaticRoutes(lrName, ownerKey string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ListAllLogicalRouterStaticRoutesSorted(lrName string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ListAllStaticRoutes() ([]RouterStaticRoute, error)
+	WalkAllStaticRoutes(fn func(route RouterStaticRoute) error) error
+	GetRouterRoutingSnapshot(lrName string) ([]StaticRouteSnapshot, error)
+	ListLogicalRouterStaticRouteRefs(lrName string) ([]StaticRouteRef, error)
+	ExportRouterRoutesDOT(lrName string) (string, error)
+	FormatLogicalRouterStaticRoutes(lrName string) ([]string, error)
+	GetECMPWidths(lrName, routeTable string) (map[string]int, error)
+	GetLogicalRouterStaticRoutesByNexthop(lrName string) (map[string][]*ovnnb.LogicalRouterStaticRoute, error)
 	LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop string) (bool, error)
-	BatchDeleteLogicalRouterStaticRoute(lrName string, staticRoutes []*ovnnb.LogicalRouterStaticRoute) error
+	FindConflictingStaticRoutes(lrName string, proposed *ovnnb.LogicalRouterStaticRoute) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	FindStaticRouteNatConflicts(lrName, tagKey string) ([]StaticRouteNatConflict, error)
+	FindShadowedStaticRoutes(lrName, routeTable string) ([]ShadowedStaticRoutes, error)
+	FindInconsistentBFDRoutes(lrName string, repair bool) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ValidateStaticRoutesBatch(lrName string, routes []*ovnnb.LogicalRouterStaticRoute) (map[int]error, error)
+	GarbageCollectDanglingBFDRoutes(lrName string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	EnableBFDForECMPGroup(lrName, routeTable, policy, ipPrefix string, nexthopBFD map[string]string) error
+	ReplaceGatewayNode(lrName, oldIP, newIP string, newBFD map[string]string) error
+	SetECMPHashMode(lrName, routeTable, policy, ipPrefix, mode string) error
+	ListLogicalRouterStaticRoutesByECMPHashMode(lrName, mode string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	AddLogicalRouterConnectedRoute(lrName, routeTable, ipPrefix, outputPort, nexthop string, externalIDs map[string]string) error
+	ListLogicalRouterStaticRoutesByOutputPort(lrName, outputPort string) ([]*ovnnb.LogicalRouterStaticRoute, error)
+	ReconcileStaticRoutes(lrName string, desired []*ovnnb.LogicalRouterStaticRoute, opts ReconcileOpts) error
+	ApplyDesiredStaticRoutes(lrName string, desired []*ovnnb.LogicalRouterStaticRoute) error
+	MonitorStaticRouteVersions()
+	EnableLogicalRouterCache(ttl time.Duration)
+	StaticRouteVersion() (uint64, error)
+	ListLogicalRouterStaticRoutesChangedSince(lrName string, version uint64) ([]*ovnnb.LogicalRouterStaticRoute, uint64, error)
+	BatchDeleteLogicalRouterStaticRoute(lrName string, staticRoutes []*ovnnb.LogicalRouterStaticRoute) ([]*ovnnb.LogicalRouterStaticRoute, error)
 }
 
 type LogicalRouterPolicy interface {
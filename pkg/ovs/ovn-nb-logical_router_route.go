@@ -1,15 +1,26 @@
 package ovs
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/netip"
 	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/ovn-org/libovsdb/cache"
 	"github.com/ovn-org/libovsdb/client"
 	"github.com/ovn-org/libovsdb/model"
 	"github.com/ovn-org/libovsdb/ovsdb"
 	"github.com/scylladb/go-set/strset"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
@@ -19,6 +30,183 @@ import (
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
+// RouteTableValidator records the route tables registered by each vpc
+type RouteTableValidator struct {
+	mutex  sync.RWMutex
+	tables map[string]set.Set[string]
+}
+
+// NewRouteTableValidator return a route table validator without any registered route table
+func NewRouteTableValidator() *RouteTableValidator {
+	return &RouteTableValidator{tables: make(map[string]set.Set[string])}
+}
+
+// Register set the route tables of the vpc, the main route table is always valid
+func (v *RouteTableValidator) Register(vpc string, routeTables ...string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.tables[vpc] = set.New(routeTables...)
+}
+
+// Unregister remove all route tables of the vpc
+func (v *RouteTableValidator) Unregister(vpc string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	delete(v.tables, vpc)
+}
+
+// Validate check whether the route table is registered by the vpc
+func (v *RouteTableValidator) Validate(vpc, routeTable string) error {
+	if routeTable == util.MainRouteTable {
+		return nil
+	}
+
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	if !v.tables[vpc].Has(routeTable) {
+		return fmt.Errorf("route table %q is not registered by vpc %s", routeTable, vpc)
+	}
+	return nil
+}
+
+// StaticRouteCountWarner warns when the static route count of a logical router exceeds the threshold,
+// at most once per interval for each logical router
+type StaticRouteCountWarner struct {
+	threshold int
+	interval  time.Duration
+	now       func() time.Time
+
+	mutex    sync.Mutex
+	lastWarn map[string]time.Time
+}
+
+// NewStaticRouteCountWarner return a static route count warner
+func NewStaticRouteCountWarner(threshold int, interval time.Duration) *StaticRouteCountWarner {
+	return &StaticRouteCountWarner{
+		threshold: threshold,
+		interval:  interval,
+		now:       time.Now,
+		lastWarn:  make(map[string]time.Time),
+	}
+}
+
+// Check records the metric if the route count exceeds the threshold, and returns whether a warning is logged
+func (w *StaticRouteCountWarner) Check(lrName string, count int) bool {
+	if count <= w.threshold {
+		return false
+	}
+	ovsClientStaticRouteThresholdExceeded.WithLabelValues(lrName).Inc()
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	now := w.now()
+	if last, ok := w.lastWarn[lrName]; ok && now.Sub(last) < w.interval {
+		return false
+	}
+	w.lastWarn[lrName] = now
+	klog.Warningf("logical router %s has %d static routes, exceeding the threshold %d, which may degrade the performance", lrName, count, w.threshold)
+	return true
+}
+
+// LogicalRouterCache caches the logical routers got by the static route methods for a short ttl, so that
+// consecutive operations on the same logical router skip the redundant lookups. The cached logical routers
+// are invalidated on their updates received from the monitor and on the transactions of the static routes
+type LogicalRouterCache struct {
+	ttl time.Duration
+	now func() time.Time
+	get func(lrName string, ignoreNotFound bool) (*ovnnb.LogicalRouter, error)
+
+	mutex   sync.Mutex
+	entries map[string]logicalRouterCacheEntry
+	// generations of the logical routers bumped on invalidation, so that the logical routers
+	// got before a concurrent invalidation are not cached
+	generations map[string]uint64
+	generation  uint64
+}
+
+type logicalRouterCacheEntry struct {
+	lr     *ovnnb.LogicalRouter
+	expiry time.Time
+}
+
+// EnableLogicalRouterCache caches the logical routers got by the static route methods for ttl,
+// and adds a handler to NB libovsdb cache to invalidate the updated logical routers.
+// This function should only be called once.
+func (c *OVNNbClient) EnableLogicalRouterCache(ttl time.Duration) {
+	lrCache := newLogicalRouterCache(ttl, c.GetLogicalRouter)
+	c.logicalRouterCache = lrCache
+	invalidate := func(table string, m model.Model) {
+		if table == ovnnb.LogicalRouterTable {
+			lrCache.Invalidate(m.(*ovnnb.LogicalRouter).Name)
+		}
+	}
+	c.ovsDbClient.Cache().AddEventHandler(&cache.EventHandlerFuncs{
+		AddFunc: invalidate,
+		UpdateFunc: func(table string, oldModel, newModel model.Model) {
+			invalidate(table, oldModel)
+			invalidate(table, newModel)
+		},
+		DeleteFunc: invalidate,
+	})
+}
+
+func newLogicalRouterCache(ttl time.Duration, get func(lrName string, ignoreNotFound bool) (*ovnnb.LogicalRouter, error)) *LogicalRouterCache {
+	return &LogicalRouterCache{
+		ttl:         ttl,
+		now:         time.Now,
+		get:         get,
+		entries:     make(map[string]logicalRouterCacheEntry),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Get returns a copy of the cached logical router, the logical router is got and cached if not cached or expired,
+// and the not found logical routers are not cached
+func (r *LogicalRouterCache) Get(lrName string, ignoreNotFound bool) (*ovnnb.LogicalRouter, error) {
+	r.mutex.Lock()
+	entry, ok := r.entries[lrName]
+	generation, lrGeneration := r.generation, r.generations[lrName]
+	r.mutex.Unlock()
+	if ok && r.now().Before(entry.expiry) {
+		return model.Clone(entry.lr).(*ovnnb.LogicalRouter), nil
+	}
+
+	lr, err := r.get(lrName, ignoreNotFound)
+	if err != nil || lr == nil {
+		return lr, err
+	}
+	r.mutex.Lock()
+	if r.generation == generation && r.generations[lrName] == lrGeneration {
+		r.entries[lrName] = logicalRouterCacheEntry{lr: model.Clone(lr).(*ovnnb.LogicalRouter), expiry: r.now().Add(r.ttl)}
+	}
+	r.mutex.Unlock()
+	return lr, nil
+}
+
+// Invalidate removes the logical router from the cache
+func (r *LogicalRouterCache) Invalidate(lrName string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.entries, lrName)
+	r.generations[lrName]++
+}
+
+// InvalidateAll removes all the logical routers from the cache
+func (r *LogicalRouterCache) InvalidateAll() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	clear(r.entries)
+	r.generation++
+}
+
+// getCachedLogicalRouter gets the logical router from logicalRouterCache if enabled
+func (c *OVNNbClient) getCachedLogicalRouter(lrName string, ignoreNotFound bool) (*ovnnb.LogicalRouter, error) {
+	if c.logicalRouterCache == nil {
+		return c.GetLogicalRouter(lrName, ignoreNotFound)
+	}
+	return c.logicalRouterCache.Get(lrName, ignoreNotFound)
+}
+
 func (c *OVNNbClient) ListLogicalRouterStaticRoutesByOption(lrName, _, key, value string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
 	fnFilter := func(route *ovnnb.LogicalRouterStaticRoute) bool {
 		if len(route.Options) != 0 {
@@ -31,388 +219,3213 @@ func (c *OVNNbClient) ListLogicalRouterStaticRoutesByOption(lrName, _, key, valu
 	return c.listLogicalRouterStaticRoutesByFilter(lrName, fnFilter)
 }
 
-// CreateLogicalRouterStaticRoutes create several logical router static route once
+// CreateLogicalRouterStaticRoute creates a logical router static route with the options applied
+func (c *OVNNbClient) CreateLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string, bfdID *string, externalIDs map[string]string, options ...StaticRouteOption) error {
+	route, err := c.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, bfdID, externalIDs, options...)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	return c.CreateLogicalRouterStaticRoutes(lrName, route)
+}
+
+// CreateLogicalRouterStaticRoutes create several logical router static route once,
+// the routes are committed in ordered chunks if MaxStaticRoutesPerTransaction is exceeded
 func (c *OVNNbClient) CreateLogicalRouterStaticRoutes(lrName string, routes ...*ovnnb.LogicalRouterStaticRoute) error {
 	if len(routes) == 0 {
 		return nil
 	}
 
+	var existing *strset.Set
+	if c.deterministicStaticRouteUUIDs {
+		var err error
+		if existing, err = c.existingStaticRouteNamedUUIDs(lrName, routes); err != nil {
+			klog.Error(err)
+			return err
+		}
+	}
+
 	models := make([]model.Model, 0, len(routes))
+	created := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(routes))
 	routeUUIDs := make([]string, 0, len(routes))
 	for _, route := range routes {
-		if route != nil {
-			models = append(models, model.Model(route))
-			routeUUIDs = append(routeUUIDs, route.UUID)
+		if route == nil {
+			continue
+		}
+		if existing != nil {
+			// skip the routes created by a previous attempt and the duplicate ones of this attempt
+			id := staticRouteNamedUUID(route.RouteTable, staticRoutePolicy(route), route.IPPrefix, route.Nexthop)
+			if existing.Has(id) {
+				klog.V(3).Infof("skip creating existing static route %s via %s of logical router %s", route.IPPrefix, route.Nexthop, lrName)
+				continue
+			}
+			existing.Add(id)
 		}
+		models = append(models, model.Model(route))
+		created = append(created, route)
+		routeUUIDs = append(routeUUIDs, route.UUID)
 	}
-
-	createRoutesOp, err := c.Create(models...)
-	if err != nil {
+	if len(models) == 0 {
+		return nil
+	}
+	if err := c.checkStaticRouteLimit(lrName, len(models)); err != nil {
 		klog.Error(err)
-		return fmt.Errorf("generate operations for creating static routes: %w", err)
+		return err
+	}
+
+	size := c.staticRouteChunkSize(len(models))
+	chunks := staticRouteChunkCount(len(models), size)
+	for i := range chunks {
+		start, end := i*size, min((i+1)*size, len(models))
+		createRoutesOp, err := c.Create(models[start:end]...)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for creating static routes: %w", err)
+		}
+
+		routeAddOp, err := c.LogicalRouterUpdateStaticRouteOp(lrName, routeUUIDs[start:end], ovsdb.MutateOperationInsert)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for adding static routes to logical router %s: %w", lrName, err)
+		}
+
+		ops := make([]ovsdb.Operation, 0, len(createRoutesOp)+len(routeAddOp))
+		ops = append(ops, createRoutesOp...)
+		ops = append(ops, routeAddOp...)
+
+		if err = c.transactStaticRoutes("lr-routes-add", created[start:end], ops); err != nil {
+			klog.Error(err)
+			return fmt.Errorf("add static routes to %s: %w", lrName, staticRouteChunkError(i, chunks, err))
+		}
 	}
 
-	routeAddOp, err := c.LogicalRouterUpdateStaticRouteOp(lrName, routeUUIDs, ovsdb.MutateOperationInsert)
+	return nil
+}
+
+// checkStaticRouteLimit returns ErrStaticRouteLimitExceeded if n more static routes exceed maxStaticRoutesPerRouter
+func (c *OVNNbClient) checkStaticRouteLimit(lrName string, n int) error {
+	if c.maxStaticRoutesPerRouter <= 0 {
+		return nil
+	}
+	lr, err := c.getCachedLogicalRouter(lrName, false)
 	if err != nil {
 		klog.Error(err)
-		return fmt.Errorf("generate operations for adding static routes to logical router %s: %w", lrName, err)
+		return fmt.Errorf("get logical router %s: %w", lrName, err)
+	}
+	if count := len(lr.StaticRoutes); count+n > c.maxStaticRoutesPerRouter {
+		return fmt.Errorf("%w: adding %d static routes to logical router %s with %d static routes exceeds the limit %d",
+			ErrStaticRouteLimitExceeded, n, lrName, count, c.maxStaticRoutesPerRouter)
 	}
+	return nil
+}
+
+// staticRouteChunkSize returns the number of static routes created or deleted in one transaction
+func (c *OVNNbClient) staticRouteChunkSize(n int) int {
+	if c.MaxStaticRoutesPerTransaction > 0 && c.MaxStaticRoutesPerTransaction < n {
+		return c.MaxStaticRoutesPerTransaction
+	}
+	return max(n, 1)
+}
 
-	ops := make([]ovsdb.Operation, 0, len(createRoutesOp)+len(routeAddOp))
-	ops = append(ops, createRoutesOp...)
-	ops = append(ops, routeAddOp...)
+func staticRouteChunkCount(n, size int) int {
+	return (n + size - 1) / size
+}
 
-	if err = c.Transact("lr-routes-add", ops); err != nil {
-		klog.Error(err)
-		return fmt.Errorf("add static routes to %s: %w", lrName, err)
+// staticRouteChunkError annotates err with the failed chunk if the routes are split into several chunks
+func staticRouteChunkError(index, chunks int, err error) error {
+	if chunks <= 1 {
+		return err
+	}
+	return fmt.Errorf("chunk %d/%d: %w", index+1, chunks, err)
+}
+
+// ErrNoStaticRouteNexthop is returned when a static route is added without any nexthop
+var ErrNoStaticRouteNexthop = errors.New("no nexthop is specified for static route")
+
+// ErrStaticRouteNexthopConflict is returned in additive mode if the prefix has nexthops other than the requested ones
+var ErrStaticRouteNexthopConflict = errors.New("static route nexthop conflict")
+
+// ErrStaticRouteLimitExceeded is returned if the static routes of a logical router exceed maxStaticRoutesPerRouter
+var ErrStaticRouteLimitExceeded = errors.New("static route limit exceeded")
+
+// ErrStaticRouteSelfNexthop is returned if rejectSelfNexthopStaticRoutes is set and the nexthop of a static route
+// is an address of a port of the logical router, which routes the traffic back to the router itself
+var ErrStaticRouteSelfNexthop = errors.New("static route nexthop is an address of the router itself")
+
+// ErrNexthopHostnameUnresolved is returned if the hostname nexthop of a static route is not resolved to any address
+// of the ip family of the route
+var ErrNexthopHostnameUnresolved = errors.New("nexthop hostname unresolved")
+
+// ErrMixedBFDECMPGroup is returned if adding static routes results in an ecmp group of both bfd and non-bfd nexthops,
+// which is not supported by ovn
+var ErrMixedBFDECMPGroup = errors.New("mixed bfd and non-bfd nexthops in ecmp group")
+
+// NamedTableDefaultRouteCheck specifies how to handle the default routes added to a route table other than the main one,
+// which are only used by the traffic steered to the route table by policies rather than all the traffic of the router
+type NamedTableDefaultRouteCheck int
+
+const (
+	NamedTableDefaultRouteAllow NamedTableDefaultRouteCheck = iota
+	NamedTableDefaultRouteWarn
+	NamedTableDefaultRouteReject
+)
+
+// ErrDefaultRouteInNamedTable is returned if a default route is added to a route table other than the main one
+// with NamedTableDefaultRouteReject, the caller should add it by a client without the check to confirm
+var ErrDefaultRouteInNamedTable = errors.New("default route in named route table")
+
+// checkNamedTableDefaultRoute checks the default route added to a route table other than the main one
+func (c *OVNNbClient) checkNamedTableDefaultRoute(lrName, routeTable, ipPrefix string) error {
+	if c.namedTableDefaultRouteCheck == NamedTableDefaultRouteAllow || routeTable == util.MainRouteTable {
+		return nil
+	}
+	prefix, err := netip.ParsePrefix(ipPrefix)
+	if err != nil || prefix.Bits() != 0 {
+		return nil
 	}
 
+	if c.namedTableDefaultRouteCheck == NamedTableDefaultRouteReject {
+		return fmt.Errorf("default route %s of logical router %s in route table %q, which is only used by the traffic steered to it: %w", ipPrefix, lrName, routeTable, ErrDefaultRouteInNamedTable)
+	}
+	klog.Warningf("default route %s of logical router %s is added to route table %q, which is only used by the traffic steered to it rather than all the traffic", ipPrefix, lrName, routeTable)
 	return nil
 }
 
-// AddLogicalRouterStaticRoute add a logical router static route
+// AddLogicalRouterStaticRoute add a logical router static route,
+// the existing routes of the prefix with other nexthops are deleted.
+// If no nexthop is specified, ErrNoStaticRouteNexthop is returned if rejectEmptyStaticRouteNexthops is set,
+// otherwise all the routes of the prefix are deleted, use ClearLogicalRouterStaticRoutesByPrefix for the intent.
+// If nexthopHostnameResolver is set, the only nexthop may be a hostname, which is resolved to the ecmp nexthops
+// and stored in the external ids of the routes as ExternalIDNexthopHostname
 func (c *OVNNbClient) AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix string, bfdID *string, externalIDs map[string]string, nexthops ...string) error {
-	if len(policy) == 0 {
-		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	if len(nexthops) == 0 && c.rejectEmptyStaticRouteNexthops {
+		err := fmt.Errorf("%w %s of logical router %s", ErrNoStaticRouteNexthop, ipPrefix, lrName)
+		klog.Error(err)
+		return err
+	}
+	if c.nexthopHostnameResolver != nil && slices.ContainsFunc(nexthops, isNexthopHostname) {
+		if len(nexthops) != 1 {
+			err := fmt.Errorf("hostname nexthop of route %s of logical router %s must be the only nexthop, got %v", ipPrefix, lrName, nexthops)
+			klog.Error(err)
+			return err
+		}
+		hostname := nexthops[0]
+		resolved, err := c.resolveNexthopHostname(ipPrefix, hostname)
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		klog.Infof("nexthop hostname %s of logical router %s route %s is resolved to %v", hostname, lrName, ipPrefix, resolved)
+		externalIDs = maps.Clone(externalIDs)
+		if externalIDs == nil {
+			externalIDs = make(map[string]string, 1)
+		}
+		externalIDs[ExternalIDNexthopHostname] = hostname
+		nexthops = resolved
 	}
+	return c.addLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, bfdID, nil, nil, externalIDs, false, nexthops)
+}
 
-	routes, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+// isNexthopHostname returns whether the nexthop is neither an ip address nor the discard nexthop
+func isNexthopHostname(nexthop string) bool {
+	return nexthop != "" && nexthop != util.StaticRouteDiscardNexthop && net.ParseIP(nexthop) == nil
+}
+
+// resolveNexthopHostname resolves the hostname by nexthopHostnameResolver to the sorted addresses of the ip family of the prefix
+func (c *OVNNbClient) resolveNexthopHostname(ipPrefix, hostname string) ([]string, error) {
+	addrs, err := c.nexthopHostnameResolver(hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve nexthop hostname %s of route %s: %w: %w", hostname, ipPrefix, ErrNexthopHostnameUnresolved, err)
+	}
+
+	nexthops := strset.New()
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && checkStaticRouteNexthopFamily(ipPrefix, ip.String()) == nil {
+			nexthops.Add(ip.String())
+		}
+	}
+	if nexthops.IsEmpty() {
+		return nil, fmt.Errorf("%w: nexthop hostname %s of route %s has no address of the ip family of the route, got %v", ErrNexthopHostnameUnresolved, hostname, ipPrefix, addrs)
+	}
+	result := nexthops.List()
+	slices.Sort(result)
+	return result, nil
+}
+
+// RefreshHostnameRoutes resolves the hostnames stored in the external ids of the static routes of the logical router
+// under hostnameKey, ExternalIDNexthopHostname if empty, again by nexthopHostnameResolver, and converges the ecmp nexthops
+// of each hostname to the addresses in one transaction. The routes of the surviving nexthops are kept with their bfd sessions
+// and options, the new routes copy the external ids and options of a surviving one, and get a bfd session cloned from
+// the one of the surviving route to their own nexthops if the hostname routes are bfd protected. The routes of a hostname
+// failing to resolve are kept unchanged and the error is returned after the other hostnames are refreshed
+func (c *OVNNbClient) RefreshHostnameRoutes(lrName, hostnameKey string) error {
+	if c.nexthopHostnameResolver == nil {
+		return fmt.Errorf("failed to refresh hostname routes of logical router %s: no nexthop hostname resolver", lrName)
+	}
+	if hostnameKey == "" {
+		hostnameKey = ExternalIDNexthopHostname
+	}
+
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.ExternalIDs[hostnameKey] != ""
+	})
 	if err != nil {
 		klog.Error(err)
 		return err
 	}
 
-	existing := strset.New()
-	var toDel []string
+	groups := make(map[string][]*ovnnb.LogicalRouterStaticRoute)
+	var keys []string
 	for _, route := range routes {
-		if slices.Contains(nexthops, route.Nexthop) {
-			existing.Add(route.Nexthop)
-		} else {
-			if route.BFD != nil && bfdID != nil && *route.BFD != *bfdID {
-				continue
-			}
-			toDel = append(toDel, route.UUID)
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix) + "/" + route.ExternalIDs[hostnameKey]
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
 		}
+		groups[key] = append(groups[key], route)
 	}
+	slices.Sort(keys)
+
+	var errs []error
 	var toAdd []*ovnnb.LogicalRouterStaticRoute
-	for _, nexthop := range nexthops {
-		if !existing.Has(nexthop) {
-			route, err := c.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, bfdID, externalIDs)
+	var toDel []string
+	for _, key := range keys {
+		group := groups[key]
+		template := group[0]
+		hostname := template.ExternalIDs[hostnameKey]
+		nexthops, err := c.resolveNexthopHostname(template.IPPrefix, hostname)
+		if err != nil {
+			klog.Error(err)
+			errs = append(errs, err)
+			continue
+		}
+
+		existing := strset.New()
+		var groupDel []string
+		for _, route := range group {
+			if slices.Contains(nexthops, route.Nexthop) {
+				if existing.IsEmpty() {
+					template = route
+				}
+				existing.Add(route.Nexthop)
+			} else {
+				groupDel = append(groupDel, route.UUID)
+			}
+		}
+		var groupAdd []*ovnnb.LogicalRouterStaticRoute
+		for _, nexthop := range nexthops {
+			if existing.Has(nexthop) {
+				continue
+			}
+			options := maps.Clone(template.Options)
+			var bfdID *string
+			if template.BFD != nil {
+				if bfdID, err = c.cloneStaticRouteBFD(*template.BFD, nexthop); err != nil {
+					klog.Error(err)
+					return err
+				}
+			} else {
+				delete(options, util.StaticRouteBfdEcmp)
+			}
+			route, err := c.newLogicalRouterStaticRoute(lrName, template.RouteTable, staticRoutePolicy(template), template.IPPrefix, nexthop, bfdID, maps.Clone(template.ExternalIDs),
+				func(r *ovnnb.LogicalRouterStaticRoute) { r.Options = options })
 			if err != nil {
 				klog.Error(err)
 				return err
 			}
-			toAdd = append(toAdd, route)
+			if route != nil {
+				groupAdd = append(groupAdd, route)
+			}
 		}
+		if err = checkMixedBFDECMPGroup(lrName, template.IPPrefix, group, groupDel, groupAdd); err != nil {
+			klog.Error(err)
+			errs = append(errs, err)
+			continue
+		}
+		if len(groupAdd) != 0 || len(groupDel) != 0 {
+			klog.Infof("nexthop hostname %s of logical router %s route %s is resolved to %v", hostname, lrName, template.IPPrefix, nexthops)
+		}
+		toAdd = append(toAdd, groupAdd...)
+		toDel = append(toDel, groupDel...)
 	}
-	if len(toDel) != 0 {
-		klog.Infof("logical router %s del static routes: %v", lrName, toDel)
-	}
-	ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, toDel, ovsdb.MutateOperationDelete)
-	if err != nil {
+
+	if err = c.commitReconciledStaticRoutes(lrName, toAdd, nil, toDel); err != nil {
 		klog.Error(err)
-		return fmt.Errorf("generate operations for removing static routes from logical router %s: %w", lrName, err)
+		return fmt.Errorf("failed to refresh hostname routes of logical router %s: %w", lrName, err)
 	}
-	if err = c.Transact("lr-route-del", ops); err != nil {
+	return utilerrors.NewAggregate(errs)
+}
+
+// cloneStaticRouteBFD returns the uuid of the bfd session to nexthop on the logical router port of the bfd session bfdID,
+// which is created with the intervals, options and external ids of the bfd session bfdID if it does not exist
+func (c *OVNNbClient) cloneStaticRouteBFD(bfdID, nexthop string) (*string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	source := &ovnnb.BFD{UUID: bfdID}
+	if err := c.Get(ctx, source); err != nil {
 		klog.Error(err)
-		return fmt.Errorf("failed to delete static routes from logical router %s: %w", lrName, err)
+		return nil, fmt.Errorf("failed to get bfd %s: %w", bfdID, err)
 	}
-	if err = c.CreateLogicalRouterStaticRoutes(lrName, toAdd...); err != nil {
+	bfdList, err := c.ListBFDs(source.LogicalPort, nexthop)
+	if err != nil {
 		klog.Error(err)
-		return fmt.Errorf("failed to add static routes to logical router %s: %w", lrName, err)
+		return nil, err
 	}
-	return nil
+	if len(bfdList) == 0 {
+		bfd := &ovnnb.BFD{
+			LogicalPort: source.LogicalPort,
+			DstIP:       nexthop,
+			MinRx:       source.MinRx,
+			MinTx:       source.MinTx,
+			DetectMult:  source.DetectMult,
+			Options:     maps.Clone(source.Options),
+			ExternalIDs: maps.Clone(source.ExternalIDs),
+		}
+		ops, err := c.Create(bfd)
+		if err != nil {
+			klog.Error(err)
+			return nil, fmt.Errorf("failed to generate operations for BFD creation with logical_port=%s and dst_ip=%s: %w", source.LogicalPort, nexthop, err)
+		}
+		if err = c.Transact("bfd-add", ops); err != nil {
+			klog.Error(err)
+			return nil, fmt.Errorf("failed to create BFD with logical_port=%s and dst_ip=%s: %w", source.LogicalPort, nexthop, err)
+		}
+		if bfdList, err = c.ListBFDs(source.LogicalPort, nexthop); err != nil {
+			klog.Error(err)
+			return nil, err
+		}
+		if len(bfdList) == 0 {
+			return nil, fmt.Errorf("BFD with logical_port=%s and dst_ip=%s not found", source.LogicalPort, nexthop)
+		}
+	}
+	return &bfdList[0].UUID, nil
 }
 
-// UpdateLogicalRouterStaticRoute update logical router static route
-func (c *OVNNbClient) UpdateLogicalRouterStaticRoute(route *ovnnb.LogicalRouterStaticRoute, fields ...interface{}) error {
-	if route == nil {
-		return errors.New("route is nil")
-	}
+// LookupNexthopHostname is a NexthopHostnameResolver looking up the hostname by the local resolver
+func LookupNexthopHostname(hostname string) ([]string, error) {
+	return net.LookupHost(hostname)
+}
 
-	op, err := c.ovsDbClient.Where(route).Update(route, fields...)
-	if err != nil {
-		klog.Error(err)
-		return fmt.Errorf("generate operations for updating logical router static route 'policy %s ip_prefix %s': %w", *route.Policy, route.IPPrefix, err)
+// AddLogicalRouterStaticRouteWithBFDs add ecmp logical router static routes of the prefix,
+// each nexthop in bfdIDs is associated with its own bfd session, which must exist
+func (c *OVNNbClient) AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix string, bfdIDs, externalIDs map[string]string) error {
+	if len(bfdIDs) == 0 {
+		return fmt.Errorf("%w %s of logical router %s", ErrNoStaticRouteNexthop, ipPrefix, lrName)
 	}
 
-	if err = c.Transact("net-update", op); err != nil {
-		klog.Error(err)
-		return fmt.Errorf("update logical router static route 'policy %s ip_prefix %s': %w", *route.Policy, route.IPPrefix, err)
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	nexthops := make([]string, 0, len(bfdIDs))
+	for nexthop, bfdID := range bfdIDs {
+		if len(bfdID) == 0 {
+			return fmt.Errorf("no bfd is specified for nexthop %s of static route %s", nexthop, ipPrefix)
+		}
+		if err := c.Get(ctx, &ovnnb.BFD{UUID: bfdID}); err != nil {
+			klog.Error(err)
+			return fmt.Errorf("failed to get bfd %s of nexthop %s: %w", bfdID, nexthop, err)
+		}
+		nexthops = append(nexthops, nexthop)
 	}
+	slices.Sort(nexthops)
 
-	return nil
+	return c.addLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, bfdIDs, nil, externalIDs, false, nexthops)
 }
 
-// DeleteLogicalRouterStaticRoute delete a logical router static route
-func (c *OVNNbClient) DeleteLogicalRouterStaticRoute(lrName string, routeTable, policy *string, ipPrefix, nexthop string) error {
-	if policy == nil || len(*policy) == 0 {
-		policy = ptr.To(ovnnb.LogicalRouterStaticRoutePolicyDstIP)
-	}
-
-	lr, err := c.GetLogicalRouter(lrName, true)
-	if lr == nil && err == nil {
-		return nil
+// ClearLogicalRouterStaticRoutesByPrefix deletes all the routes of the prefix in the route table with the policy
+func (c *OVNNbClient) ClearLogicalRouterStaticRoutesByPrefix(lrName, routeTable, policy, ipPrefix string) error {
+	if len(policy) == 0 {
+		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
 	}
 
-	routes, err := c.ListLogicalRouterStaticRoutes(lrName, routeTable, policy, ipPrefix, nil)
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
 	if err != nil {
 		klog.Error(err)
 		return err
 	}
-
-	// not found, skip
 	if len(routes) == 0 {
 		return nil
 	}
 
-	uuids := make([]string, 0, len(routes))
-	for _, route := range routes {
-		if nexthop == "" || route.Nexthop == nexthop {
-			uuids = append(uuids, route.UUID)
-		}
-	}
-
-	// remove static route from logical router
-	ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, uuids, ovsdb.MutateOperationDelete)
-	if err != nil {
+	klog.Infof("logical router %s clear static routes of prefix %s", lrName, ipPrefix)
+	if err = c.removeLogicalRouterStaticRoutes(lrName, routes); err != nil {
 		klog.Error(err)
-		return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", uuids, lrName, err)
-	}
-	if err = c.Transact("lr-route-del", ops); err != nil {
-		klog.Error(err)
-		return fmt.Errorf("delete static routes %v from logical router %s: %w", uuids, lrName, err)
+		return fmt.Errorf("failed to clear static routes of prefix %s from logical router %s: %w", ipPrefix, lrName, err)
 	}
-
 	return nil
 }
 
-// DeleteLogicalRouterStaticRoute delete a logical router static route
-func (c *OVNNbClient) DeleteLogicalRouterStaticRouteByUUID(lrName, uuid string) error {
-	lr, err := c.GetLogicalRouter(lrName, true)
-	if err != nil {
+// AddLogicalRouterStaticRouteAdditive add a logical router static route without deleting any existing route,
+// ErrStaticRouteNexthopConflict is returned if the prefix has nexthops other than the requested ones
+func (c *OVNNbClient) AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix string, bfdID *string, externalIDs map[string]string, nexthops ...string) error {
+	return c.addLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, bfdID, nil, nil, externalIDs, true, nexthops)
+}
+
+// addLogicalRouterStaticRoute associates the routes with the bfd session of bfdIDs[nexthop] if exists, otherwise bfdID
+func (c *OVNNbClient) addLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix string, bfdID *string, bfdIDs map[string]string, nexthopOptions map[string][]StaticRouteOption, externalIDs map[string]string, additive bool, nexthops []string) error {
+	if len(policy) == 0 {
+		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	}
+
+	if c.routeTableValidator != nil {
+		if err := c.routeTableValidator.Validate(lrName, routeTable); err != nil {
+			klog.Error(err)
+			return err
+		}
+	}
+	if err := c.checkNamedTableDefaultRoute(lrName, routeTable, ipPrefix); err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	existing := strset.New()
+	var toDel, conflicts []string
+	var others int
+	for _, route := range routes {
+		if slices.Contains(nexthops, route.Nexthop) {
+			existing.Add(route.Nexthop)
+		} else {
+			others++
+			if route.BFD != nil && bfdID != nil && *route.BFD != *bfdID {
+				continue
+			}
+			toDel = append(toDel, route.UUID)
+			conflicts = append(conflicts, route.Nexthop)
+		}
+	}
+	var toAdd []*ovnnb.LogicalRouterStaticRoute
+	for _, nexthop := range nexthops {
+		if !existing.Has(nexthop) {
+			routeBFD := bfdID
+			if id, ok := bfdIDs[nexthop]; ok {
+				routeBFD = &id
+			}
+			route, err := c.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, routeBFD, externalIDs, nexthopOptions[nexthop]...)
+			if err != nil {
+				klog.Error(err)
+				return err
+			}
+			toAdd = append(toAdd, route)
+		}
+	}
+	if additive {
+		toDel = nil
+	}
+	if err = checkMixedBFDECMPGroup(lrName, ipPrefix, routes, toDel, toAdd); err != nil {
+		klog.Error(err)
+		return err
+	}
+	if len(toDel) != 0 {
+		klog.Infof("logical router %s del static routes: %v", lrName, toDel)
+	}
+	if c.singleNexthopBFDWithoutEcmp && len(nexthops)+others-len(toDel) == 1 {
+		for _, route := range toAdd {
+			delete(route.Options, util.StaticRouteBfdEcmp)
+		}
+	}
+	if err = c.removeLogicalRouterStaticRouteUUIDs(lrName, toDel); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to delete static routes from logical router %s: %w", lrName, err)
+	}
+	if err = c.CreateLogicalRouterStaticRoutes(lrName, toAdd...); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to add static routes to logical router %s: %w", lrName, err)
+	}
+	if additive && len(conflicts) != 0 {
+		err = fmt.Errorf("%w: logical router %s route %s has nexthops %v other than %v", ErrStaticRouteNexthopConflict, lrName, ipPrefix, conflicts, nexthops)
+		klog.Warning(err)
+		return err
+	}
+	return nil
+}
+
+// checkMixedBFDECMPGroup returns ErrMixedBFDECMPGroup if the ecmp group of the prefix, which consists of the existing
+// routes other than the ones of toDel and the routes of toAdd, has both bfd and non-bfd nexthops
+func checkMixedBFDECMPGroup(lrName, ipPrefix string, existing []*ovnnb.LogicalRouterStaticRoute, toDel []string, toAdd []*ovnnb.LogicalRouterStaticRoute) error {
+	var bfdNexthops, nonBFDNexthops []string
+	for _, route := range slices.Concat(existing, toAdd) {
+		if slices.Contains(toDel, route.UUID) {
+			continue
+		}
+		if route.BFD != nil {
+			bfdNexthops = append(bfdNexthops, route.Nexthop)
+		} else {
+			nonBFDNexthops = append(nonBFDNexthops, route.Nexthop)
+		}
+	}
+	if len(bfdNexthops) != 0 && len(nonBFDNexthops) != 0 {
+		return fmt.Errorf("%w: logical router %s route %s would have bfd nexthops %v and non-bfd nexthops %v", ErrMixedBFDECMPGroup, lrName, ipPrefix, bfdNexthops, nonBFDNexthops)
+	}
+	return nil
+}
+
+// strategies to resolve the conflicts when importing static routes to a prefix which has other nexthops
+const (
+	// StaticRouteImportSkip leaves the existing routes of the prefix unchanged
+	StaticRouteImportSkip = "skip"
+	// StaticRouteImportOverwrite converges the routes of the prefix to the imported ones
+	StaticRouteImportOverwrite = "overwrite"
+	// StaticRouteImportMerge unions the existing and the imported ecmp nexthops of the prefix
+	StaticRouteImportMerge = "merge"
+)
+
+// ImportLogicalRouterStaticRoutes imports the static routes to the logical router, routes with the same route table,
+// policy and ip prefix are imported as an ecmp group, and the conflicts with the existing routes are resolved by strategy
+func (c *OVNNbClient) ImportLogicalRouterStaticRoutes(lrName string, routes []*ovnnb.LogicalRouterStaticRoute, strategy string) error {
+	if strategy != StaticRouteImportSkip && strategy != StaticRouteImportOverwrite && strategy != StaticRouteImportMerge {
+		err := fmt.Errorf("unknown strategy %q to import static routes to logical router %s", strategy, lrName)
+		klog.Error(err)
+		return err
+	}
+
+	var keys []string
+	groups := make(map[string][]*ovnnb.LogicalRouterStaticRoute)
+	for _, route := range routes {
+		if route == nil {
+			continue
+		}
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], route)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		routeTable, policy, ipPrefix := group[0].RouteTable, staticRoutePolicy(group[0]), group[0].IPPrefix
+		nexthops := strset.New()
+		bfdIDs := make(map[string]string)
+		nexthopOptions := make(map[string][]StaticRouteOption, len(group))
+		for _, route := range group {
+			nexthops.Add(route.Nexthop)
+			if route.BFD != nil {
+				bfdIDs[route.Nexthop] = *route.BFD
+			}
+			nexthopOptions[route.Nexthop] = []StaticRouteOption{withImportedStaticRoute(route)}
+		}
+
+		existing, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		existingNexthops := strset.New()
+		for _, route := range existing {
+			existingNexthops.Add(route.Nexthop)
+		}
+		if existingNexthops.IsEqual(nexthops) {
+			continue
+		}
+
+		additive := false
+		if !existingNexthops.IsEmpty() {
+			switch strategy {
+			case StaticRouteImportSkip:
+				klog.Infof("skip importing static route %s with nexthops %v to logical router %s which has nexthops %v", ipPrefix, nexthops.List(), lrName, existingNexthops.List())
+				continue
+			case StaticRouteImportMerge:
+				additive = true
+			}
+		}
+
+		list := nexthops.List()
+		slices.Sort(list)
+		err = c.addLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, bfdIDs, nexthopOptions, nil, additive, list)
+		if err != nil && (!additive || !errors.Is(err, ErrStaticRouteNexthopConflict)) {
+			klog.Error(err)
+			return fmt.Errorf("failed to import static route %s to logical router %s: %w", ipPrefix, lrName, err)
+		}
+	}
+
+	return nil
+}
+
+// maxStaticRouteReconcileWorkers is the max number of logical routers whose static routes are reconciled in parallel
+const maxStaticRouteReconcileWorkers = 8
+
+// ReconcileRoutersStaticRoutes reconciles the static routes of several logical routers in parallel,
+// the results of the routers are returned with an aggregate error of the failed ones
+func (c *OVNNbClient) ReconcileRoutersStaticRoutes(specs map[string][]*ovnnb.LogicalRouterStaticRoute) (map[string]error, error) {
+	lrNames := slices.Sorted(maps.Keys(specs))
+	results := make(map[string]error, len(lrNames))
+	if len(lrNames) == 0 {
+		return results, nil
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan string)
+	for range min(len(lrNames), maxStaticRouteReconcileWorkers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for lrName := range queue {
+				err := c.reconcileLogicalRouterStaticRoutes(lrName, specs[lrName])
+				mutex.Lock()
+				results[lrName] = err
+				mutex.Unlock()
+			}
+		}()
+	}
+	for _, lrName := range lrNames {
+		queue <- lrName
+	}
+	close(queue)
+	wg.Wait()
+
+	var errs []error
+	for _, lrName := range lrNames {
+		if results[lrName] != nil {
+			errs = append(errs, fmt.Errorf("logical router %s: %w", lrName, results[lrName]))
+		}
+	}
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		klog.Error(err)
+		return results, fmt.Errorf("failed to reconcile static routes of %d logical routers: %w", len(errs), err)
+	}
+	return results, nil
+}
+
+// reconcileLogicalRouterStaticRoutes converges the routes of each route table, policy and ip prefix in routes
+// to the requested nexthops in one transaction, the routes of other prefixes are left unchanged
+func (c *OVNNbClient) reconcileLogicalRouterStaticRoutes(lrName string, routes []*ovnnb.LogicalRouterStaticRoute) error {
+	existing, err := c.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	keys, desired := strset.New(), strset.New()
+	var toAdd []*ovnnb.LogicalRouterStaticRoute
+	for _, route := range routes {
+		if route == nil {
+			continue
+		}
+		if c.routeTableValidator != nil {
+			if err = c.routeTableValidator.Validate(lrName, route.RouteTable); err != nil {
+				klog.Error(err)
+				return err
+			}
+		}
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)
+		if desired.Has(key + "/" + route.Nexthop) {
+			continue
+		}
+		keys.Add(key)
+		desired.Add(key + "/" + route.Nexthop)
+
+		options := maps.Clone(route.Options)
+		newRoute, err := c.newLogicalRouterStaticRoute(lrName, route.RouteTable, staticRoutePolicy(route), route.IPPrefix, route.Nexthop, route.BFD, route.ExternalIDs,
+			func(r *ovnnb.LogicalRouterStaticRoute) { r.Options = options })
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		if newRoute != nil {
+			toAdd = append(toAdd, newRoute)
+		}
+	}
+
+	var toDel []string
+	for _, route := range existing {
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)
+		if keys.Has(key) && !desired.Has(key+"/"+route.Nexthop) {
+			toDel = append(toDel, route.UUID)
+		}
+	}
+	return c.commitReconciledStaticRoutes(lrName, toAdd, nil, toDel)
+}
+
+// ReconcileOpts restricts the static routes reconciled by ReconcileStaticRoutes
+type ReconcileOpts struct {
+	// ProtectBFDRoutes keeps the undesired routes associated with bfd sessions
+	ProtectBFDRoutes bool
+	// RouteTable restricts the reconciliation to the route table if not nil
+	RouteTable *string
+	// OwnerExternalIDs restricts the reconciliation to the routes with the external ids, an empty value matches
+	// any non-empty value of the key. The external ids with non-empty values are also set to the created routes
+	OwnerExternalIDs map[string]string
+}
+
+// ReconcileStaticRoutes converges the static routes of the logical router within the scope of opts to desired
+// in one transaction, the undesired routes in the scope are deleted unless protected, and the missing ones are created
+func (c *OVNNbClient) ReconcileStaticRoutes(lrName string, desired []*ovnnb.LogicalRouterStaticRoute, opts ReconcileOpts) error {
+	existing, err := c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return (opts.RouteTable == nil || route.RouteTable == *opts.RouteTable) && staticRouteMapMatch(route.ExternalIDs, opts.OwnerExternalIDs)
+	})
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	wanted := strset.New()
+	var toAdd []*ovnnb.LogicalRouterStaticRoute
+	for _, route := range desired {
+		if route == nil {
+			continue
+		}
+		if opts.RouteTable != nil && route.RouteTable != *opts.RouteTable {
+			return fmt.Errorf("desired static route %s via %s of route table %q is out of the reconciled route table %q", route.IPPrefix, route.Nexthop, route.RouteTable, *opts.RouteTable)
+		}
+		if c.routeTableValidator != nil {
+			if err = c.routeTableValidator.Validate(lrName, route.RouteTable); err != nil {
+				klog.Error(err)
+				return err
+			}
+		}
+		id := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix) + "/" + route.Nexthop
+		if wanted.Has(id) {
+			continue
+		}
+		wanted.Add(id)
+
+		externalIDs := maps.Clone(route.ExternalIDs)
+		for k, v := range opts.OwnerExternalIDs {
+			if v == "" {
+				continue
+			}
+			if externalIDs == nil {
+				externalIDs = make(map[string]string, len(opts.OwnerExternalIDs))
+			}
+			externalIDs[k] = v
+		}
+		options := maps.Clone(route.Options)
+		newRoute, err := c.newLogicalRouterStaticRoute(lrName, route.RouteTable, staticRoutePolicy(route), route.IPPrefix, route.Nexthop, route.BFD, externalIDs,
+			func(r *ovnnb.LogicalRouterStaticRoute) { r.Options = options })
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		if newRoute != nil {
+			toAdd = append(toAdd, newRoute)
+		}
+	}
+
+	var toDel []string
+	for _, route := range existing {
+		if wanted.Has(createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix) + "/" + route.Nexthop) {
+			continue
+		}
+		if opts.ProtectBFDRoutes && route.BFD != nil {
+			klog.Infof("keep undesired static route %s via %s of logical router %s associated with bfd %s", route.IPPrefix, route.Nexthop, lrName, *route.BFD)
+			continue
+		}
+		toDel = append(toDel, route.UUID)
+	}
+	return c.commitReconciledStaticRoutes(lrName, toAdd, nil, toDel)
+}
+
+// ApplyDesiredStaticRoutes converges all the static routes of the logical router to desired with the minimal mutations
+// in one transaction, which is the whole router analog of AddLogicalRouterStaticRoute. The missing routes are created,
+// the undesired ones of any route table and prefix are deleted, and the bfd, options, output port and external ids of
+// the existing routes are updated in place if they differ from the desired ones, so the unchanged routes are not touched
+func (c *OVNNbClient) ApplyDesiredStaticRoutes(lrName string, desired []*ovnnb.LogicalRouterStaticRoute) error {
+	existing, err := c.listLogicalRouterStaticRoutesByFilter(lrName, nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	existingRoutes := make(map[string]*ovnnb.LogicalRouterStaticRoute, len(existing))
+	var toDel []string
+	for _, route := range existing {
+		id := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix) + "/" + route.Nexthop
+		if _, ok := existingRoutes[id]; ok {
+			// duplicate of the same identity
+			toDel = append(toDel, route.UUID)
+			continue
+		}
+		existingRoutes[id] = route
+	}
+
+	wanted := strset.New()
+	var toAdd, toUpdate []*ovnnb.LogicalRouterStaticRoute
+	for _, route := range desired {
+		if route == nil {
+			continue
+		}
+		policy := staticRoutePolicy(route)
+		id := createStaticRouteKey(route.RouteTable, policy, route.IPPrefix) + "/" + route.Nexthop
+		if wanted.Has(id) {
+			continue
+		}
+		wanted.Add(id)
+
+		if c.routeTableValidator != nil {
+			if err = c.routeTableValidator.Validate(lrName, route.RouteTable); err != nil {
+				klog.Error(err)
+				return err
+			}
+		}
+		// bfd routes are created with the ecmp symmetric reply option by newLogicalRouterStaticRoute
+		options := maps.Clone(route.Options)
+		if route.BFD != nil {
+			if options == nil {
+				options = make(map[string]string, 1)
+			}
+			options[util.StaticRouteBfdEcmp] = "true"
+		}
+		if current, ok := existingRoutes[id]; ok {
+			if ptr.Equal(current.BFD, route.BFD) && ptr.Equal(current.OutputPort, route.OutputPort) &&
+				maps.Equal(current.Options, options) && maps.Equal(current.ExternalIDs, route.ExternalIDs) {
+				continue
+			}
+			if route.OutputPort != nil && !ptr.Equal(current.OutputPort, route.OutputPort) {
+				if err = c.checkStaticRouteOutputPort(lrName, *route.OutputPort); err != nil {
+					klog.Error(err)
+					return err
+				}
+			}
+			current.BFD, current.OutputPort = route.BFD, route.OutputPort
+			current.Options, current.ExternalIDs = options, maps.Clone(route.ExternalIDs)
+			toUpdate = append(toUpdate, current)
+			continue
+		}
+
+		outputPort := route.OutputPort
+		newRoute, err := c.newLogicalRouterStaticRoute(lrName, route.RouteTable, policy, route.IPPrefix, route.Nexthop, route.BFD, maps.Clone(route.ExternalIDs),
+			func(r *ovnnb.LogicalRouterStaticRoute) { r.Options, r.OutputPort = options, outputPort })
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		if newRoute != nil {
+			toAdd = append(toAdd, newRoute)
+		}
+	}
+
+	for id, route := range existingRoutes {
+		if !wanted.Has(id) {
+			toDel = append(toDel, route.UUID)
+		}
+	}
+	slices.Sort(toDel)
+	return c.commitReconciledStaticRoutes(lrName, toAdd, toUpdate, toDel)
+}
+
+// commitReconciledStaticRoutes creates the routes of toAdd, updates the bfd, options, output port and external ids
+// of the routes of toUpdate and removes the routes of toDel from the logical router in one transaction
+func (c *OVNNbClient) commitReconciledStaticRoutes(lrName string, toAdd, toUpdate []*ovnnb.LogicalRouterStaticRoute, toDel []string) error {
+	if len(toAdd) == 0 && len(toUpdate) == 0 && len(toDel) == 0 {
+		return nil
+	}
+	if n := len(toAdd) - len(toDel); n > 0 {
+		if err := c.checkStaticRouteLimit(lrName, n); err != nil {
+			klog.Error(err)
+			return err
+		}
+	}
+
+	models := make([]model.Model, 0, len(toAdd))
+	uuids := make([]string, 0, len(toAdd))
+	for _, route := range toAdd {
+		models = append(models, model.Model(route))
+		uuids = append(uuids, route.UUID)
+	}
+	ops, err := c.Create(models...)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for creating static routes: %w", err)
+	}
+	addOps, err := c.LogicalRouterUpdateStaticRouteOp(lrName, uuids, ovsdb.MutateOperationInsert)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for adding static routes to logical router %s: %w", lrName, err)
+	}
+	delOps, err := c.LogicalRouterUpdateStaticRouteOp(lrName, toDel, ovsdb.MutateOperationDelete)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", toDel, lrName, err)
+	}
+	ops = append(append(ops, addOps...), delOps...)
+	for _, route := range toUpdate {
+		updateOps, err := c.ovsDbClient.Where(route).Update(route, &route.BFD, &route.Options, &route.OutputPort, &route.ExternalIDs)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for updating static route %s via %s of logical router %s: %w", route.IPPrefix, route.Nexthop, lrName, err)
+		}
+		ops = append(ops, updateOps...)
+	}
+
+	klog.Infof("reconcile static routes of logical router %s: add %d, update %d, delete %v", lrName, len(toAdd), len(toUpdate), toDel)
+	if err = c.transactStaticRoutes("lr-routes-reconcile", slices.Concat(toAdd, toUpdate, c.getStaticRoutesForHooks(toDel)), ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("reconcile static routes of logical router %s: %w", lrName, err)
+	}
+	return nil
+}
+
+// RouterStaticRouteChanges is the static routes to create in and remove from a logical router
+type RouterStaticRouteChanges struct {
+	Create []*ovnnb.LogicalRouterStaticRoute
+	// Delete is the uuids of the static routes to remove
+	Delete []string
+}
+
+// TransactRoutersStaticRoutes commits the static route changes of several logical routers, keyed by router name,
+// in a single transaction, so that either all or none of the routers are changed, e.g. a route and its symmetric
+// return route on the peer router. The named uuids must be unique in a transaction, so a created route whose uuid
+// is used by another created route, e.g. the same deterministic uuid in two routers, is created with a new one
+func (c *OVNNbClient) TransactRoutersStaticRoutes(changes map[string]RouterStaticRouteChanges) error {
+	lrNames := slices.Sorted(maps.Keys(changes))
+	uuids := strset.New()
+	var ops []ovsdb.Operation
+	var routes []*ovnnb.LogicalRouterStaticRoute
+	for _, lrName := range lrNames {
+		change := changes[lrName]
+		toAdd := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(change.Create))
+		for _, route := range change.Create {
+			if route == nil {
+				continue
+			}
+			if uuids.Has(route.UUID) {
+				renamed := *route
+				renamed.UUID = ovsclient.NamedUUID()
+				route = &renamed
+			}
+			uuids.Add(route.UUID)
+			toAdd = append(toAdd, route)
+		}
+		if len(toAdd) == 0 && len(change.Delete) == 0 {
+			continue
+		}
+		if n := len(toAdd) - len(change.Delete); n > 0 {
+			if err := c.checkStaticRouteLimit(lrName, n); err != nil {
+				klog.Error(err)
+				return err
+			}
+		}
+
+		models := make([]model.Model, 0, len(toAdd))
+		addUUIDs := make([]string, 0, len(toAdd))
+		for _, route := range toAdd {
+			models = append(models, model.Model(route))
+			addUUIDs = append(addUUIDs, route.UUID)
+		}
+		createOps, err := c.Create(models...)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for creating static routes of logical router %s: %w", lrName, err)
+		}
+		addOps, err := c.LogicalRouterUpdateStaticRouteOp(lrName, addUUIDs, ovsdb.MutateOperationInsert)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for adding static routes to logical router %s: %w", lrName, err)
+		}
+		delOps, err := c.LogicalRouterUpdateStaticRouteOp(lrName, change.Delete, ovsdb.MutateOperationDelete)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", change.Delete, lrName, err)
+		}
+		ops = slices.Concat(ops, createOps, addOps, delOps)
+		routes = slices.Concat(routes, toAdd, c.getStaticRoutesForHooks(change.Delete))
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	klog.Infof("commit static route changes of logical routers %v in one transaction", lrNames)
+	if err := c.transactStaticRoutes("lr-routes-multi-router", routes, ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("commit static route changes of logical routers %v: %w", lrNames, err)
+	}
+	return nil
+}
+
+// SummarizeStaticRoutes replaces the dst-ip host routes of the route table within aggregatePrefix with one aggregate route
+// in a single transaction, all the host routes must go to the nexthop without bfd, and the aggregate route inherits
+// the external ids shared by them. An error is returned if the aggregate prefix has a route to another nexthop
+func (c *OVNNbClient) SummarizeStaticRoutes(lrName, routeTable, nexthop, aggregatePrefix string) error {
+	aggregate, err := netip.ParsePrefix(aggregatePrefix)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("invalid aggregate prefix %s: %w", aggregatePrefix, err)
+	}
+	aggregate = aggregate.Masked()
+
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "", nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	var hostRoutes []*ovnnb.LogicalRouterStaticRoute
+	var externalIDs map[string]string
+	aggregateExists := false
+	for _, route := range routes {
+		prefix, err := netip.ParsePrefix(maskedIPPrefix(route.IPPrefix))
+		if err != nil {
+			continue
+		}
+		if prefix == aggregate {
+			if route.Nexthop != nexthop {
+				return fmt.Errorf("aggregate prefix %s of logical router %s conflicts with the route to nexthop %s", aggregatePrefix, lrName, route.Nexthop)
+			}
+			aggregateExists = true
+			continue
+		}
+		if !prefix.IsSingleIP() || !aggregate.Contains(prefix.Addr()) {
+			continue
+		}
+		if route.Nexthop != nexthop {
+			return fmt.Errorf("host route %s of logical router %s goes to nexthop %s other than %s", route.IPPrefix, lrName, route.Nexthop, nexthop)
+		}
+		if route.BFD != nil {
+			return fmt.Errorf("host route %s of logical router %s is associated with bfd %s", route.IPPrefix, lrName, *route.BFD)
+		}
+		if hostRoutes == nil {
+			externalIDs = maps.Clone(route.ExternalIDs)
+		} else {
+			maps.DeleteFunc(externalIDs, func(k, v string) bool { return route.ExternalIDs[k] != v })
+		}
+		hostRoutes = append(hostRoutes, route)
+	}
+	if len(hostRoutes) == 0 {
+		return fmt.Errorf("no host route of logical router %s is within the aggregate prefix %s", lrName, aggregatePrefix)
+	}
+
+	uuids := make([]string, 0, len(hostRoutes))
+	for _, route := range hostRoutes {
+		uuids = append(uuids, route.UUID)
+	}
+	ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, uuids, ovsdb.MutateOperationDelete)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", uuids, lrName, err)
+	}
+	hookRoutes := slices.Clone(hostRoutes)
+	if !aggregateExists {
+		if err = c.checkStaticRouteLimit(lrName, 1-len(hostRoutes)); err != nil {
+			klog.Error(err)
+			return err
+		}
+		route, err := c.newLogicalRouterStaticRoute(lrName, routeTable, policy, aggregate.String(), nexthop, nil, externalIDs)
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		createOps, err := c.Create(route)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for creating static route %s: %w", aggregate, err)
+		}
+		addOps, err := c.LogicalRouterUpdateStaticRouteOp(lrName, []string{route.UUID}, ovsdb.MutateOperationInsert)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for adding static route %s to logical router %s: %w", aggregate, lrName, err)
+		}
+		ops = append(append(createOps, addOps...), ops...)
+		hookRoutes = append(hookRoutes, route)
+	}
+
+	klog.Infof("summarize %d host routes of logical router %s into %s via %s", len(hostRoutes), lrName, aggregate, nexthop)
+	if err = c.transactStaticRoutes("lr-route-summarize", hookRoutes, ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("summarize static routes of logical router %s into %s: %w", lrName, aggregate, err)
+	}
+
+	return nil
+}
+
+// AddLogicalRouterBlackholeRoute add a dst-ip static route which discards the matched packets
+func (c *OVNNbClient) AddLogicalRouterBlackholeRoute(lrName, routeTable, ipPrefix string, externalIDs map[string]string) error {
+	return c.AddLogicalRouterStaticRoute(lrName, routeTable, ovnnb.LogicalRouterStaticRoutePolicyDstIP, ipPrefix, nil, externalIDs, util.StaticRouteDiscardNexthop)
+}
+
+// EnsureGatewayNatRoute ensures the src-ip route of the centralized subnet points to the active gateway,
+// and stamps the route as owned by the gateway nat of the daemon
+func (c *OVNNbClient) EnsureGatewayNatRoute(lrName, subnetName, cidr, nexthop string) error {
+	policy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+	routeTable := util.MainRouteTable
+	externalIDs := map[string]string{
+		ExternalIDVendor: util.CniTypeName,
+		ExternalIDOwner:  GatewayNatRouteOwner,
+		"subnet":         subnetName,
+	}
+
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, cidr, nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if len(routes) != 1 || routes[0].Nexthop != nexthop {
+		klog.Infof("point gateway nat route %s of subnet %s on logical router %s to %s", cidr, subnetName, lrName, nexthop)
+		return c.AddLogicalRouterStaticRoute(lrName, routeTable, policy, cidr, nil, externalIDs, nexthop)
+	}
+
+	route := routes[0]
+	if route.ExternalIDs == nil {
+		route.ExternalIDs = make(map[string]string, len(externalIDs))
+	}
+	changed := false
+	for k, v := range externalIDs {
+		if route.ExternalIDs[k] != v {
+			route.ExternalIDs[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err = c.UpdateLogicalRouterStaticRoute(route, &route.ExternalIDs); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to stamp gateway nat route %s of subnet %s: %w", cidr, subnetName, err)
+	}
+	return nil
+}
+
+// CheckRouteTableReachable checks whether the route table is referenced by any port or policy of the logical router,
+// the routes of a route table take effect only for the traffic entering the router from a port whose route_table option
+// is the table, or steered into the table by a policy, so the routes of a route table not referenced never forward
+func (c *OVNNbClient) CheckRouteTableReachable(lrName, routeTable string) (bool, error) {
+	if routeTable == util.MainRouteTable {
+		return true, nil
+	}
+
+	lr, err := c.getCachedLogicalRouter(lrName, false)
+	if err != nil {
+		klog.Error(err)
+		return false, err
+	}
+
+	referenced, err := c.referencedRouteTables(lr)
+	if err != nil {
+		klog.Error(err)
+		return false, err
+	}
+	return referenced.Has(routeTable), nil
+}
+
+// referencedRouteTables returns the route tables referenced by the route_table option of the logical router ports,
+// which is how the subnets select their route tables, and of the logical router policies, the drop policies never
+// steer traffic into a route table
+func (c *OVNNbClient) referencedRouteTables(lr *ovnnb.LogicalRouter) (set.Set[string], error) {
+	ports := set.New(lr.Ports...)
+	portList := make([]*ovnnb.LogicalRouterPort, 0, len(lr.Ports))
+	if err := c.WhereCache(func(lrp *ovnnb.LogicalRouterPort) bool {
+		return ports.Has(lrp.UUID) && lrp.Options["route_table"] != ""
+	}).List(context.Background(), &portList); err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("failed to list ports of logical router %s with route tables: %w", lr.Name, err)
+	}
+
+	policies := set.New(lr.Policies...)
+	policyList := make([]*ovnnb.LogicalRouterPolicy, 0, len(lr.Policies))
+	if err := c.WhereCache(func(policy *ovnnb.LogicalRouterPolicy) bool {
+		return policies.Has(policy.UUID) && policy.Action != ovnnb.LogicalRouterPolicyActionDrop && policy.Options["route_table"] != ""
+	}).List(context.Background(), &policyList); err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("failed to list policies of logical router %s with route tables: %w", lr.Name, err)
+	}
+
+	routeTables := set.New[string]()
+	for _, lrp := range portList {
+		routeTables.Insert(lrp.Options["route_table"])
+	}
+	for _, policy := range policyList {
+		routeTables.Insert(policy.Options["route_table"])
+	}
+	return routeTables, nil
+}
+
+// UnreachableRouteTable is a route table of a logical router with static routes which never take effect
+type UnreachableRouteTable struct {
+	RouteTable string
+	Routes     []*ovnnb.LogicalRouterStaticRoute
+}
+
+// ReportUnreachableRouteTables returns the route tables other than the main one which have static routes but are not
+// referenced by any port or policy of the logical router, sorted by name with the routes sorted like sortStaticRoutes, and
+// logs a warning for each of them. No traffic is looked up in these tables, so their routes are dead. The routes of
+// util.StaticRouteDisabledRouteTable are disabled on purpose and not reported
+func (c *OVNNbClient) ReportUnreachableRouteTables(lrName string) ([]UnreachableRouteTable, error) {
+	lr, err := c.getCachedLogicalRouter(lrName, false)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	referenced, err := c.referencedRouteTables(lr)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	routes, err := c.getLogicalRouterStaticRoutesByUUIDs(lrName, lr.StaticRoutes, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.RouteTable != util.MainRouteTable && route.RouteTable != util.StaticRouteDisabledRouteTable && !referenced.Has(route.RouteTable)
+	})
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	sortStaticRoutes(routes)
+
+	var report []UnreachableRouteTable
+	for _, route := range routes {
+		if len(report) == 0 || report[len(report)-1].RouteTable != route.RouteTable {
+			report = append(report, UnreachableRouteTable{RouteTable: route.RouteTable})
+		}
+		report[len(report)-1].Routes = append(report[len(report)-1].Routes, route)
+	}
+	for _, table := range report {
+		klog.Warningf("route table %s of logical router %s has %d static routes but is not referenced by any port or policy", table.RouteTable, lrName, len(table.Routes))
+	}
+	return report, nil
+}
+
+// FindConflictingStaticRoutes returns the existing routes of the logical router which conflict with the proposed route.
+// Routes with the same route table, policy and ip prefix form an ecmp group in ovn, and a route conflicts with the proposed one
+// if it has the same nexthop, or it mixes bfd and non-bfd nexthops, or it mixes symmetric and non-symmetric ecmp
+func (c *OVNNbClient) FindConflictingStaticRoutes(lrName string, proposed *ovnnb.LogicalRouterStaticRoute) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	if proposed == nil {
+		return nil, errors.New("the proposed static route is required")
+	}
+
+	policy := staticRoutePolicy(proposed)
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		if (proposed.UUID != "" && route.UUID == proposed.UUID) ||
+			route.RouteTable != proposed.RouteTable ||
+			staticRoutePolicy(route) != policy ||
+			route.IPPrefix != proposed.IPPrefix {
+			return false
+		}
+		return staticRoutesConflict(route, proposed)
+	})
+}
+
+// staticRoutesConflict returns whether the two routes of the same prefix can not be in the same ecmp group
+func staticRoutesConflict(a, b *ovnnb.LogicalRouterStaticRoute) bool {
+	return a.Nexthop == b.Nexthop ||
+		(a.BFD == nil) != (b.BFD == nil) ||
+		a.Options[util.StaticRouteBfdEcmp] != b.Options[util.StaticRouteBfdEcmp]
+}
+
+// ShadowedStaticRoutes is a prefix of a route table whose routes conflict with each other
+type ShadowedStaticRoutes struct {
+	Policy   string
+	IPPrefix string
+	Routes   []*ovnnb.LogicalRouterStaticRoute
+}
+
+// FindShadowedStaticRoutes returns the prefixes of the route table whose routes can not be in the same ecmp group,
+// which are matched unpredictably. Prefixes are compared after masked, and overlapping prefixes of different lengths are not reported
+func (c *OVNNbClient) FindShadowedStaticRoutes(lrName, routeTable string) ([]ShadowedStaticRoutes, error) {
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, nil, "", nil)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	type groupKey struct{ policy, ipPrefix string }
+	groups := make(map[groupKey][]*ovnnb.LogicalRouterStaticRoute)
+	for _, route := range routes {
+		key := groupKey{staticRoutePolicy(route), maskedIPPrefix(route.IPPrefix)}
+		groups[key] = append(groups[key], route)
+	}
+
+	var result []ShadowedStaticRoutes
+	for key, routes := range groups {
+		if !slices.ContainsFunc(routes, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+			return slices.ContainsFunc(routes, func(other *ovnnb.LogicalRouterStaticRoute) bool {
+				return other != route && staticRoutesConflict(route, other)
+			})
+		}) {
+			continue
+		}
+		slices.SortFunc(routes, func(a, b *ovnnb.LogicalRouterStaticRoute) int {
+			return cmp.Or(strings.Compare(a.Nexthop, b.Nexthop), strings.Compare(a.IPPrefix, b.IPPrefix))
+		})
+		result = append(result, ShadowedStaticRoutes{Policy: key.policy, IPPrefix: key.ipPrefix, Routes: routes})
+	}
+	slices.SortFunc(result, func(a, b ShadowedStaticRoutes) int {
+		return cmp.Or(strings.Compare(a.Policy, b.Policy), compareIPPrefix(a.IPPrefix, b.IPPrefix))
+	})
+	return result, nil
+}
+
+// StaticRouteNatConflict is a static route whose ip prefix overlaps the logical ip of a nat entry
+type StaticRouteNatConflict struct {
+	Route *ovnnb.LogicalRouterStaticRoute
+	Nat   *ovnnb.NAT
+}
+
+// FindStaticRouteNatConflicts returns the static routes tagged with the external id key, e.g. the routes feeding
+// a load balancer vip, whose ip prefixes overlap the logical ips of the snat or dnat_and_snat entries of the logical router
+func (c *OVNNbClient) FindStaticRouteNatConflicts(lrName, tagKey string) ([]StaticRouteNatConflict, error) {
+	if len(tagKey) == 0 {
+		return nil, fmt.Errorf("the tag key is required to find static routes conflicting with nat of logical router %s", lrName)
+	}
+
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.ExternalIDs[tagKey] != ""
+	})
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return nil, nil
+	}
+
+	nats, err := c.listLogicalRouterNatByFilter(lrName, func(nat *ovnnb.NAT) bool {
+		return nat.Type == ovnnb.NATTypeSNAT || nat.Type == ovnnb.NATTypeDNATAndSNAT
+	})
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	var conflicts []StaticRouteNatConflict
+	for _, route := range routes {
+		routePrefix, err := netip.ParsePrefix(maskedIPPrefix(route.IPPrefix))
+		if err != nil {
+			continue
+		}
+		for _, nat := range nats {
+			natPrefix, err := netip.ParsePrefix(maskedIPPrefix(nat.LogicalIP))
+			if err != nil || !routePrefix.Overlaps(natPrefix) {
+				continue
+			}
+			klog.Warningf("static route %s via %s of logical router %s tagged with %s overlaps %s %s -> %s",
+				route.IPPrefix, route.Nexthop, lrName, tagKey, nat.Type, nat.LogicalIP, nat.ExternalIP)
+			conflicts = append(conflicts, StaticRouteNatConflict{Route: route, Nat: nat})
+		}
+	}
+	slices.SortFunc(conflicts, func(a, b StaticRouteNatConflict) int {
+		return cmp.Or(compareIPPrefix(maskedIPPrefix(a.Route.IPPrefix), maskedIPPrefix(b.Route.IPPrefix)),
+			strings.Compare(a.Route.Nexthop, b.Route.Nexthop),
+			compareIPPrefix(maskedIPPrefix(a.Nat.LogicalIP), maskedIPPrefix(b.Nat.LogicalIP)),
+			strings.Compare(a.Nat.ExternalIP, b.Nat.ExternalIP))
+	})
+	return conflicts, nil
+}
+
+// maskedIPPrefix returns the masked ip prefix, an ip address is regarded as a host prefix
+func maskedIPPrefix(ipPrefix string) string {
+	if prefix, err := netip.ParsePrefix(ipPrefix); err == nil {
+		return prefix.Masked().String()
+	}
+	if addr, err := netip.ParseAddr(ipPrefix); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()).String()
+	}
+	return ipPrefix
+}
+
+// FindInconsistentBFDRoutes returns the static routes of the logical router which have bfd set without
+// the bfd ecmp option or have the option without bfd, which may be created by older versions or out-of-band.
+// If repair is true, the option of the inconsistent routes is fixed according to the bfd column in one transaction
+func (c *OVNNbClient) FindInconsistentBFDRoutes(lrName string, repair bool) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	// the bfd routes of single nexthop prefixes are consistent without the option if singleNexthopBFDWithoutEcmp is set
+	routeCounts := make(map[string]int)
+	if c.singleNexthopBFDWithoutEcmp {
+		all, err := c.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		if err != nil {
+			klog.Error(err)
+			return nil, err
+		}
+		for _, route := range all {
+			routeCounts[createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)]++
+		}
+	}
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		ecmp := route.Options[util.StaticRouteBfdEcmp] == "true"
+		if route.BFD == nil || ecmp {
+			return route.BFD == nil && ecmp
+		}
+		return routeCounts[createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)] != 1
+	})
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	if !repair || len(routes) == 0 {
+		return routes, nil
+	}
+
+	ops := make([]ovsdb.Operation, 0, len(routes))
+	for _, route := range routes {
+		repaired := *route
+		repaired.Options = maps.Clone(route.Options)
+		if repaired.BFD != nil {
+			if repaired.Options == nil {
+				repaired.Options = make(map[string]string, 1)
+			}
+			repaired.Options[util.StaticRouteBfdEcmp] = "true"
+		} else {
+			delete(repaired.Options, util.StaticRouteBfdEcmp)
+		}
+
+		op, err := c.ovsDbClient.Where(&repaired).Update(&repaired, &repaired.Options)
+		if err != nil {
+			klog.Error(err)
+			return nil, fmt.Errorf("generate operations for repairing bfd option of static route %s: %w", route.UUID, err)
+		}
+		ops = append(ops, op...)
+	}
+
+	if err = c.transactStaticRoutes("lr-route-update", routes, ops); err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("repair bfd option of static routes of logical router %s: %w", lrName, err)
+	}
+
+	return routes, nil
+}
+
+// ErrDuplicateStaticRoute is returned if a batch has several static routes of the same route table, policy, ip prefix and nexthop
+var ErrDuplicateStaticRoute = errors.New("duplicate static route")
+
+// ValidateStaticRoutesBatch validates the static routes to be added to the logical router as a whole without changing
+// anything, e.g. by admission controllers before a large batch is applied. The errors of the invalid routes are returned
+// keyed by their indexes in routes, each joins all the violations of the route: invalid ip prefix, nexthop or policy,
+// nexthop family mismatch, nonexistent bfd session or one monitoring another ip, inconsistent bfd ecmp option,
+// duplicate routes in the batch and ecmp groups mixing bfd and non-bfd nexthops with the existing routes.
+// A route of the batch via the nexthop of an existing route of the same prefix is validated as a replacement of it
+func (c *OVNNbClient) ValidateStaticRoutesBatch(lrName string, routes []*ovnnb.LogicalRouterStaticRoute) (map[int]error, error) {
+	existingRoutes, err := c.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	existing := make(map[string][]*ovnnb.LogicalRouterStaticRoute)
+	for _, route := range existingRoutes {
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)
+		existing[key] = append(existing[key], route)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	violations := make(map[int][]error)
+	batch := make(map[string][]int)
+	seen := make(map[string]int)
+	for i, route := range routes {
+		if route == nil {
+			violations[i] = append(violations[i], errors.New("route is nil"))
+			continue
+		}
+
+		policy := staticRoutePolicy(route)
+		if policy != ovnnb.LogicalRouterStaticRoutePolicyDstIP && policy != ovnnb.LogicalRouterStaticRoutePolicySrcIP {
+			violations[i] = append(violations[i], fmt.Errorf("invalid policy %q", policy))
+		}
+		if _, err := netip.ParsePrefix(route.IPPrefix); err != nil {
+			if _, err = netip.ParseAddr(route.IPPrefix); err != nil {
+				violations[i] = append(violations[i], fmt.Errorf("invalid ip prefix %q", route.IPPrefix))
+			}
+		}
+		if route.Nexthop != "" && route.Nexthop != util.StaticRouteDiscardNexthop {
+			if _, err := netip.ParseAddr(route.Nexthop); err != nil {
+				violations[i] = append(violations[i], fmt.Errorf("invalid nexthop %q", route.Nexthop))
+			} else if policy == ovnnb.LogicalRouterStaticRoutePolicyDstIP {
+				if err = checkStaticRouteNexthopFamily(route.IPPrefix, route.Nexthop); err != nil {
+					violations[i] = append(violations[i], err)
+				}
+			}
+		}
+		if route.BFD != nil {
+			bfd := &ovnnb.BFD{UUID: *route.BFD}
+			if err := c.Get(ctx, bfd); err != nil {
+				violations[i] = append(violations[i], fmt.Errorf("failed to get bfd %s: %w", *route.BFD, err))
+			} else if bfd.DstIP != route.Nexthop {
+				violations[i] = append(violations[i], fmt.Errorf("bfd %s monitors %s instead of nexthop %s", bfd.UUID, bfd.DstIP, route.Nexthop))
+			}
+		}
+
+		key := createStaticRouteKey(route.RouteTable, policy, route.IPPrefix)
+		if j, ok := seen[key+"-"+route.Nexthop]; ok {
+			violations[i] = append(violations[i], fmt.Errorf("%w: same as route %d of route table %q policy %s ip_prefix %s nexthop %s",
+				ErrDuplicateStaticRoute, j, route.RouteTable, policy, route.IPPrefix, route.Nexthop))
+			continue
+		}
+		seen[key+"-"+route.Nexthop] = i
+		batch[key] = append(batch[key], i)
+	}
+
+	for key, indexes := range batch {
+		toAdd := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(indexes))
+		nexthops := strset.NewWithSize(len(indexes))
+		for _, i := range indexes {
+			toAdd = append(toAdd, routes[i])
+			nexthops.Add(routes[i].Nexthop)
+		}
+		var toDel []string
+		for _, route := range existing[key] {
+			if nexthops.Has(route.Nexthop) {
+				toDel = append(toDel, route.UUID)
+			}
+		}
+		groupErr := checkMixedBFDECMPGroup(lrName, toAdd[0].IPPrefix, existing[key], toDel, toAdd)
+		ecmp := !c.singleNexthopBFDWithoutEcmp || len(existing[key])-len(toDel)+len(toAdd) != 1
+		for _, i := range indexes {
+			route := routes[i]
+			if groupErr != nil {
+				violations[i] = append(violations[i], groupErr)
+			}
+			if option := route.Options[util.StaticRouteBfdEcmp] == "true"; route.BFD == nil && option {
+				violations[i] = append(violations[i], fmt.Errorf("option %s is set without bfd", util.StaticRouteBfdEcmp))
+			} else if route.BFD != nil && ecmp && !option {
+				violations[i] = append(violations[i], fmt.Errorf("option %s is not set for bfd %s", util.StaticRouteBfdEcmp, *route.BFD))
+			}
+		}
+	}
+
+	report := make(map[int]error, len(violations))
+	for i, errs := range violations {
+		report[i] = errors.Join(errs...)
+	}
+	return report, nil
+}
+
+// ecmp hash modes of static routes
+const (
+	// ECMPHashModeL3 hashes the source and destination ip addresses
+	ECMPHashModeL3 = "l3"
+	// ECMPHashModeL4 hashes the transport protocol and ports besides the ip addresses
+	ECMPHashModeL4 = "l4"
+)
+
+// ecmpHashFields maps the ecmp hash modes to the hash fields written to the static route option
+var ecmpHashFields = map[string]string{
+	ECMPHashModeL3: "ip_src,ip_dst",
+	ECMPHashModeL4: "ip_src,ip_dst,ip_proto,tp_src,tp_dst",
+}
+
+// SetECMPHashMode sets the ecmp hash fields option of mode to all the nexthop routes of the ecmp group
+// in a single transaction, the option is removed if mode is empty
+func (c *OVNNbClient) SetECMPHashMode(lrName, routeTable, policy, ipPrefix, mode string) error {
+	fields, ok := ecmpHashFields[mode]
+	if !ok && mode != "" {
+		return fmt.Errorf("invalid ecmp hash mode %q, which must be one of %v", mode, slices.Sorted(maps.Keys(ecmpHashFields)))
+	}
+	if len(policy) == 0 {
+		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	}
+
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("no static route of route table %q policy %s ip_prefix %s is found in logical router %s", routeTable, policy, ipPrefix, lrName)
+	}
+
+	ops := make([]ovsdb.Operation, 0, len(routes))
+	updatedRoutes := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(routes))
+	for _, route := range routes {
+		if route.Options[util.StaticRouteEcmpHashFields] == fields {
+			continue
+		}
+
+		updated := *route
+		updated.Options = maps.Clone(route.Options)
+		if fields != "" {
+			if updated.Options == nil {
+				updated.Options = make(map[string]string, 1)
+			}
+			updated.Options[util.StaticRouteEcmpHashFields] = fields
+		} else {
+			delete(updated.Options, util.StaticRouteEcmpHashFields)
+		}
+
+		op, err := c.ovsDbClient.Where(&updated).Update(&updated, &updated.Options)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for setting ecmp hash mode of static route %s: %w", route.UUID, err)
+		}
+		ops = append(ops, op...)
+		updatedRoutes = append(updatedRoutes, &updated)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	klog.Infof("set ecmp hash mode %q of static route %s of logical router %s: route_table %q policy %s", mode, ipPrefix, lrName, routeTable, policy)
+	if err = c.transactStaticRoutes("lr-route-update", updatedRoutes, ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("set ecmp hash mode of static route %s of logical router %s: %w", ipPrefix, lrName, err)
+	}
+
+	return nil
+}
+
+// ListLogicalRouterStaticRoutesByECMPHashMode list the static routes of the logical router with the ecmp hash mode
+func (c *OVNNbClient) ListLogicalRouterStaticRoutesByECMPHashMode(lrName, mode string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	fields, ok := ecmpHashFields[mode]
+	if !ok {
+		return nil, fmt.Errorf("invalid ecmp hash mode %q, which must be one of %v", mode, slices.Sorted(maps.Keys(ecmpHashFields)))
+	}
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.Options[util.StaticRouteEcmpHashFields] == fields
+	})
+}
+
+// AddLogicalRouterConnectedRoute add a directly connected logical router static route of the prefix, which is reached
+// via the output port rather than a gateway. The nexthop must be empty or an ipv6 link-local address reached via the port
+func (c *OVNNbClient) AddLogicalRouterConnectedRoute(lrName, routeTable, ipPrefix, outputPort, nexthop string, externalIDs map[string]string) error {
+	if outputPort == "" {
+		return fmt.Errorf("no output port is specified for connected route %s of logical router %s", ipPrefix, lrName)
+	}
+	if nexthop != "" {
+		if ip := net.ParseIP(nexthop); ip == nil || ip.To4() != nil || !ip.IsLinkLocalUnicast() {
+			return fmt.Errorf("nexthop %s of connected route %s is not an ipv6 link-local address", nexthop, ipPrefix)
+		}
+	}
+	if c.routeTableValidator != nil {
+		if err := c.routeTableValidator.Validate(lrName, routeTable); err != nil {
+			klog.Error(err)
+			return err
+		}
+	}
+
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	existing, err := c.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, true)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if existing != nil {
+		if port := ptr.Deref(existing.OutputPort, ""); port != outputPort {
+			return fmt.Errorf("connected route %s of logical router %s exists via output port %q rather than %s", ipPrefix, lrName, port, outputPort)
+		}
+		return nil
+	}
+
+	route, err := c.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, externalIDs,
+		func(route *ovnnb.LogicalRouterStaticRoute) { route.OutputPort = &outputPort })
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if err = c.CreateLogicalRouterStaticRoutes(lrName, route); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to add connected route %s via %s to logical router %s: %w", ipPrefix, outputPort, lrName, err)
+	}
+	return nil
+}
+
+// ListLogicalRouterStaticRoutesByOutputPort list the static routes of the logical router via the output port
+func (c *OVNNbClient) ListLogicalRouterStaticRoutesByOutputPort(lrName, outputPort string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.OutputPort != nil && *route.OutputPort == outputPort
+	})
+}
+
+// staticRouteVersions records the versions of the static routes changed since monitored. The version is a counter of
+// the static route changes received from the ovsdb monitor, since the ovsdb transaction ids are uuids without order
+type staticRouteVersions struct {
+	mutex    sync.Mutex
+	version  uint64
+	versions map[string]uint64
+}
+
+func (v *staticRouteVersions) update(table string, m model.Model, deleted bool) {
+	if table != ovnnb.LogicalRouterStaticRouteTable {
+		return
+	}
+	uuid := m.(*ovnnb.LogicalRouterStaticRoute).UUID
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.version++
+	if deleted {
+		delete(v.versions, uuid)
+	} else {
+		v.versions[uuid] = v.version
+	}
+}
+
+// MonitorStaticRouteVersions will add a handler to NB libovsdb cache to record the versions of the changed static routes.
+// The versions are local to the client and start from 0, the routes which have not changed since monitored are of version 0,
+// and all the routes are changed again after the cache is repopulated on reconnection.
+// This function should only be called once.
+func (c *OVNNbClient) MonitorStaticRouteVersions() {
+	versions := &staticRouteVersions{versions: make(map[string]uint64)}
+	c.staticRouteVersions = versions
+	c.ovsDbClient.Cache().AddEventHandler(&cache.EventHandlerFuncs{
+		AddFunc: func(table string, model model.Model) {
+			versions.update(table, model, false)
+		},
+		UpdateFunc: func(table string, _, newModel model.Model) {
+			versions.update(table, newModel, false)
+		},
+		DeleteFunc: func(table string, model model.Model) {
+			versions.update(table, model, true)
+		},
+	})
+}
+
+// StaticRouteVersion returns the current version of the static routes recorded since MonitorStaticRouteVersions is called
+func (c *OVNNbClient) StaticRouteVersion() (uint64, error) {
+	if c.staticRouteVersions == nil {
+		return 0, errors.New("static route versions are not monitored")
+	}
+	c.staticRouteVersions.mutex.Lock()
+	defer c.staticRouteVersions.mutex.Unlock()
+	return c.staticRouteVersions.version, nil
+}
+
+// ListLogicalRouterStaticRoutesChangedSince lists the static routes of the logical router created or updated after the version,
+// and returns the current version which is taken before listing, so that a route changed during listing is returned again
+// in the next call with the returned version. The deleted routes are not returned since they are gone from the logical router
+func (c *OVNNbClient) ListLogicalRouterStaticRoutesChangedSince(lrName string, version uint64) ([]*ovnnb.LogicalRouterStaticRoute, uint64, error) {
+	if c.staticRouteVersions == nil {
+		return nil, 0, errors.New("static route versions are not monitored")
+	}
+	c.staticRouteVersions.mutex.Lock()
+	current := c.staticRouteVersions.version
+	changed := strset.New()
+	for uuid, v := range c.staticRouteVersions.versions {
+		if v > version {
+			changed.Add(uuid)
+		}
+	}
+	c.staticRouteVersions.mutex.Unlock()
+
+	if changed.IsEmpty() {
+		return nil, current, nil
+	}
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return changed.Has(route.UUID)
+	})
+	if err != nil {
+		klog.Error(err)
+		return nil, 0, err
+	}
+	return routes, current, nil
+}
+
+// EnableBFDForECMPGroup associates each nexthop route of the existing ecmp group with the bfd session of nexthopBFD[nexthop]
+// and sets the bfd ecmp option in a single transaction, without recreating the routes.
+// Every nexthop of the group must be given a bfd session, which must exist
+func (c *OVNNbClient) EnableBFDForECMPGroup(lrName, routeTable, policy, ipPrefix string, nexthopBFD map[string]string) error {
+	if len(policy) == 0 {
+		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	}
+
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("no static route of route table %q policy %s ip_prefix %s is found in logical router %s", routeTable, policy, ipPrefix, lrName)
+	}
+
+	nexthops := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		if len(nexthopBFD[route.Nexthop]) == 0 {
+			return fmt.Errorf("no bfd is specified for nexthop %s of static route %s", route.Nexthop, ipPrefix)
+		}
+		nexthops[route.Nexthop] = true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	for nexthop, bfdID := range nexthopBFD {
+		if !nexthops[nexthop] {
+			return fmt.Errorf("nexthop %s is not in the ecmp group of static route %s", nexthop, ipPrefix)
+		}
+		if err = c.Get(ctx, &ovnnb.BFD{UUID: bfdID}); err != nil {
+			klog.Error(err)
+			return fmt.Errorf("failed to get bfd %s of nexthop %s: %w", bfdID, nexthop, err)
+		}
+	}
+
+	// a single nexthop bfd route does not need the bfd ecmp option if singleNexthopBFDWithoutEcmp is set
+	ecmp := !c.singleNexthopBFDWithoutEcmp || len(routes) != 1
+	ops := make([]ovsdb.Operation, 0, len(routes))
+	updatedRoutes := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(routes))
+	for _, route := range routes {
+		bfdID := nexthopBFD[route.Nexthop]
+		if route.BFD != nil && *route.BFD == bfdID && (route.Options[util.StaticRouteBfdEcmp] == "true") == ecmp {
+			continue
+		}
+
+		updated := *route
+		updated.BFD = &bfdID
+		updated.Options = maps.Clone(route.Options)
+		if ecmp {
+			if updated.Options == nil {
+				updated.Options = make(map[string]string, 1)
+			}
+			updated.Options[util.StaticRouteBfdEcmp] = "true"
+		} else {
+			delete(updated.Options, util.StaticRouteBfdEcmp)
+		}
+
+		op, err := c.ovsDbClient.Where(&updated).Update(&updated, &updated.BFD, &updated.Options)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for enabling bfd of static route %s: %w", route.UUID, err)
+		}
+		ops = append(ops, op...)
+		updatedRoutes = append(updatedRoutes, &updated)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	klog.Infof("enable bfd for ecmp group of logical router %s: route_table %q policy %s ip_prefix %s nexthop bfds %v", lrName, routeTable, policy, ipPrefix, nexthopBFD)
+	if err = c.transactStaticRoutes("lr-route-update", updatedRoutes, ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("enable bfd for ecmp group of static route %s of logical router %s: %w", ipPrefix, lrName, err)
+	}
+
+	return nil
+}
+
+// ReplaceGatewayNode moves the static routes of all the route tables and prefixes of the logical router from nexthop oldIP
+// to newIP in place, e.g. when a gateway node is replaced. The bfd session of a moved route is replaced with newBFD keyed
+// by the old bfd session, which must be given for every bfd session of the moved routes and must exist. The routes of
+// each route table, policy and ip prefix are moved in one transaction, and a route is removed instead of moved if
+// the prefix already has a route via newIP
+func (c *OVNNbClient) ReplaceGatewayNode(lrName, oldIP, newIP string, newBFD map[string]string) error {
+	oldAddr, err := netip.ParseAddr(oldIP)
+	if err != nil {
+		return fmt.Errorf("invalid old gateway ip %q: %w", oldIP, err)
+	}
+	newAddr, err := netip.ParseAddr(newIP)
+	if err != nil {
+		return fmt.Errorf("invalid new gateway ip %q: %w", newIP, err)
+	}
+	if oldAddr.Is4() != newAddr.Is4() {
+		return fmt.Errorf("the ip family of new gateway ip %s does not match old gateway ip %s", newIP, oldIP)
+	}
+	if oldAddr == newAddr {
+		return nil
+	}
+	oldIP, newIP = oldAddr.String(), newAddr.String()
+
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	groups := make(map[string][]*ovnnb.LogicalRouterStaticRoute)
+	replaced := strset.New()
+	for _, route := range routes {
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)
+		switch route.Nexthop {
+		case oldIP:
+			groups[key] = append(groups[key], route)
+		case newIP:
+			replaced.Add(key)
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	// check the bfd sessions of all the routes before any of them is moved
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	checked := strset.New()
+	for _, group := range groups {
+		for _, route := range group {
+			if route.BFD == nil || checked.Has(*route.BFD) {
+				continue
+			}
+			bfdID := newBFD[*route.BFD]
+			if len(bfdID) == 0 {
+				return fmt.Errorf("no new bfd is specified for bfd %s of static route %s via %s", *route.BFD, route.IPPrefix, oldIP)
+			}
+			if err = c.Get(ctx, &ovnnb.BFD{UUID: bfdID}); err != nil {
+				klog.Error(err)
+				return fmt.Errorf("failed to get new bfd %s of bfd %s: %w", bfdID, *route.BFD, err)
+			}
+			checked.Add(*route.BFD)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		var ops []ovsdb.Operation
+		var updatedRoutes []*ovnnb.LogicalRouterStaticRoute
+		var removed []string
+		for _, route := range groups[key] {
+			if replaced.Has(key) {
+				removed = append(removed, route.UUID)
+				updatedRoutes = append(updatedRoutes, route)
+				continue
+			}
+
+			updated := *route
+			updated.Nexthop = newIP
+			if route.BFD != nil {
+				bfdID := newBFD[*route.BFD]
+				updated.BFD = &bfdID
+			}
+			op, err := c.ovsDbClient.Where(&updated).Update(&updated, &updated.Nexthop, &updated.BFD)
+			if err != nil {
+				klog.Error(err)
+				return fmt.Errorf("generate operations for moving static route %s to nexthop %s: %w", route.UUID, newIP, err)
+			}
+			ops = append(ops, op...)
+			updatedRoutes = append(updatedRoutes, &updated)
+		}
+		removeOps, err := c.LogicalRouterUpdateStaticRouteOp(lrName, removed, ovsdb.MutateOperationDelete)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", removed, lrName, err)
+		}
+		ops = append(ops, removeOps...)
+
+		klog.Infof("move static routes %s of logical router %s from nexthop %s to %s", key, lrName, oldIP, newIP)
+		if err = c.transactStaticRoutes("lr-route-update", updatedRoutes, ops); err != nil {
+			klog.Error(err)
+			return fmt.Errorf("move static routes %s of logical router %s from nexthop %s to %s: %w", key, lrName, oldIP, newIP, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateLogicalRouterStaticRoute update logical router static route
+func (c *OVNNbClient) UpdateLogicalRouterStaticRoute(route *ovnnb.LogicalRouterStaticRoute, fields ...interface{}) error {
+	if route == nil {
+		return errors.New("route is nil")
+	}
+
+	op, err := c.ovsDbClient.Where(route).Update(route, fields...)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for updating logical router static route 'policy %s ip_prefix %s': %w", *route.Policy, route.IPPrefix, err)
+	}
+
+	if err = c.transactStaticRoutes("net-update", []*ovnnb.LogicalRouterStaticRoute{route}, op); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("update logical router static route 'policy %s ip_prefix %s': %w", *route.Policy, route.IPPrefix, err)
+	}
+
+	return nil
+}
+
+// UpdateLogicalRouterStaticRouteForceRecreate update logical router static route like UpdateLogicalRouterStaticRoute,
+// but if the policy or ip prefix changed, the route is deleted and recreated with all the fields in a single transaction
+func (c *OVNNbClient) UpdateLogicalRouterStaticRouteForceRecreate(lrName string, route *ovnnb.LogicalRouterStaticRoute, fields ...interface{}) error {
+	if route == nil {
+		return errors.New("route is nil")
+	}
+
+	current, err := c.GetLogicalRouterStaticRouteByUUID(route.UUID)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("get logical router static route %s: %w", route.UUID, err)
+	}
+	if staticRoutePolicy(current) == staticRoutePolicy(route) && current.IPPrefix == route.IPPrefix {
+		return c.UpdateLogicalRouterStaticRoute(route, fields...)
+	}
+
+	newRoute := *route
+	newRoute.UUID = ovsclient.NamedUUID()
+	createOps, err := c.Create(&newRoute)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for creating static route: %w", err)
+	}
+	routeOps, err := c.LogicalRouterOp(lrName, func(lr *ovnnb.LogicalRouter) *model.Mutation {
+		return &model.Mutation{Field: &lr.StaticRoutes, Value: []string{current.UUID}, Mutator: ovsdb.MutateOperationDelete}
+	}, func(lr *ovnnb.LogicalRouter) *model.Mutation {
+		return &model.Mutation{Field: &lr.StaticRoutes, Value: []string{newRoute.UUID}, Mutator: ovsdb.MutateOperationInsert}
+	})
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for replacing static route %s of logical router %s: %w", current.UUID, lrName, err)
+	}
+
+	klog.Infof("recreate static route %s of logical router %s: policy %s ip_prefix %s -> policy %s ip_prefix %s",
+		current.UUID, lrName, staticRoutePolicy(current), current.IPPrefix, staticRoutePolicy(route), route.IPPrefix)
+	if err = c.transactStaticRoutes("lr-route-recreate", []*ovnnb.LogicalRouterStaticRoute{current, &newRoute}, append(createOps, routeOps...)); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("recreate static route %s of logical router %s: %w", current.UUID, lrName, err)
+	}
+
+	return nil
+}
+
+// DisableStaticRoute moves the static route to the reserved route table util.StaticRouteDisabledRouteTable,
+// which is not used by any logical router port, so the route does not forward until it is enabled
+func (c *OVNNbClient) DisableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string) error {
+	if routeTable == util.StaticRouteDisabledRouteTable {
+		return fmt.Errorf("route table %s is reserved for disabled static routes", routeTable)
+	}
+	if len(policy) == 0 {
+		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	}
+
+	route, err := c.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	route.ExternalIDs = maps.Clone(route.ExternalIDs)
+	if route.ExternalIDs == nil {
+		route.ExternalIDs = make(map[string]string, 1)
+	}
+	route.ExternalIDs[ExternalIDDisabledRouteTable] = route.RouteTable
+	route.RouteTable = util.StaticRouteDisabledRouteTable
+
+	klog.Infof("disable static route %s of logical router %s: route_table %q policy %s ip_prefix %s nexthop %s", route.UUID, lrName, routeTable, policy, ipPrefix, nexthop)
+	if err = c.UpdateLogicalRouterStaticRoute(route, &route.RouteTable, &route.ExternalIDs); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to disable static route %s of logical router %s: %w", route.UUID, lrName, err)
+	}
+
+	return nil
+}
+
+// EnableStaticRoute moves the static route disabled by DisableStaticRoute back to its route table
+func (c *OVNNbClient) EnableStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string) error {
+	if len(lrName) == 0 {
+		return errors.New("the logical router name is required")
+	}
+	if len(policy) == 0 {
+		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	}
+
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		if route.RouteTable != util.StaticRouteDisabledRouteTable || staticRoutePolicy(route) != policy || route.IPPrefix != ipPrefix || route.Nexthop != nexthop {
+			return false
+		}
+		table, ok := route.ExternalIDs[ExternalIDDisabledRouteTable]
+		return ok && table == routeTable
+	})
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("not found disabled static route 'route_table %q policy %s ip_prefix %s nexthop %s' of logical router %s", routeTable, policy, ipPrefix, nexthop, lrName)
+	}
+	if len(routes) > 1 {
+		return fmt.Errorf("more than one disabled static route 'route_table %q policy %s ip_prefix %s nexthop %s' in logical router %s", routeTable, policy, ipPrefix, nexthop, lrName)
+	}
+
+	exists, err := c.LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if exists {
+		return fmt.Errorf("static route 'route_table %q policy %s ip_prefix %s nexthop %s' has been added to logical router %s while disabled", routeTable, policy, ipPrefix, nexthop, lrName)
+	}
+
+	route := routes[0]
+	route.ExternalIDs = maps.Clone(route.ExternalIDs)
+	delete(route.ExternalIDs, ExternalIDDisabledRouteTable)
+	route.RouteTable = routeTable
+
+	klog.Infof("enable static route %s of logical router %s: route_table %q policy %s ip_prefix %s nexthop %s", route.UUID, lrName, routeTable, policy, ipPrefix, nexthop)
+	if err = c.UpdateLogicalRouterStaticRoute(route, &route.RouteTable, &route.ExternalIDs); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("failed to enable static route %s of logical router %s: %w", route.UUID, lrName, err)
+	}
+
+	return nil
+}
+
+// DeleteLogicalRouterStaticRoute delete a logical router static route
+func (c *OVNNbClient) DeleteLogicalRouterStaticRoute(lrName string, routeTable, policy *string, ipPrefix, nexthop string) error {
+	if policy == nil || len(*policy) == 0 {
+		policy = ptr.To(ovnnb.LogicalRouterStaticRoutePolicyDstIP)
+	}
+
+	lr, err := c.getCachedLogicalRouter(lrName, true)
+	if lr == nil && err == nil {
+		return nil
+	}
+
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, routeTable, policy, ipPrefix, nil)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	// not found, skip
+	if len(routes) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(routes))
+	deleted := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(routes))
+	for _, route := range routes {
+		if nexthop == "" || route.Nexthop == nexthop {
+			uuids = append(uuids, route.UUID)
+			deleted = append(deleted, route)
+		}
+	}
+
+	// remove static route from logical router
+	ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, uuids, ovsdb.MutateOperationDelete)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", uuids, lrName, err)
+	}
+	if err = c.transactStaticRoutes("lr-route-del", deleted, ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("delete static routes %v from logical router %s: %w", uuids, lrName, err)
+	}
+
+	return nil
+}
+
+// DeleteLogicalRouterStaticRoute delete a logical router static route
+func (c *OVNNbClient) DeleteLogicalRouterStaticRouteByUUID(lrName, uuid string) error {
+	lr, err := c.getCachedLogicalRouter(lrName, true)
+	if err != nil {
+		return err
+	}
+	if lr == nil {
+		return nil
+	}
+
+	// remove static route from logical router
+	ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, []string{uuid}, ovsdb.MutateOperationDelete)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for removing static route %s from logical router %s: %w", uuid, lrName, err)
+	}
+	if err = c.transactStaticRoutes("lr-route-del", c.getStaticRoutesForHooks([]string{uuid}), ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("delete static route %s from logical router %s: %w", uuid, lrName, err)
+	}
+
+	return nil
+}
+
+// DeleteLogicalRouterStaticRouteByExternalIDs delete the logical router static routes whose external ids contain all the given external ids
+func (c *OVNNbClient) DeleteLogicalRouterStaticRouteByExternalIDs(lrName string, externalIDs map[string]string) error {
+	lr, err := c.getCachedLogicalRouter(lrName, true)
+	if err != nil {
+		return err
+	}
+	if lr == nil {
+		return nil
+	}
+
+	routes, err := c.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", externalIDs)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	return c.removeLogicalRouterStaticRoutes(lrName, routes)
+}
+
+// DeleteLogicalRouterStaticRoutesByOptions delete the logical router static routes whose options contain all the given options
+func (c *OVNNbClient) DeleteLogicalRouterStaticRoutesByOptions(lrName string, options map[string]string) error {
+	if len(options) == 0 {
+		err := fmt.Errorf("refuse to delete all static routes of logical router %s with empty options", lrName)
+		klog.Error(err)
+		return err
+	}
+
+	return c.deleteLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		for k, v := range options {
+			if value, ok := route.Options[k]; !ok || value != v {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// DeleteLogicalRouterStaticRoutesWhere delete the logical router static routes matching the predicate in one transaction,
+// or in ordered chunks if MaxStaticRoutesPerTransaction is exceeded, nothing is done if no route matches
+func (c *OVNNbClient) DeleteLogicalRouterStaticRoutesWhere(lrName string, predicate func(route *ovnnb.LogicalRouterStaticRoute) bool) error {
+	if predicate == nil {
+		err := fmt.Errorf("refuse to delete all static routes of logical router %s with nil predicate", lrName)
+		klog.Error(err)
+		return err
+	}
+
+	return c.deleteLogicalRouterStaticRoutesByFilter(lrName, predicate)
+}
+
+// PruneStaticRoutesByGeneration delete the logical router static routes whose generation external id
+// is present and differs from currentGeneration, routes without the generation key are left alone
+func (c *OVNNbClient) PruneStaticRoutesByGeneration(lrName, generationKey, currentGeneration string) error {
+	if generationKey == "" {
+		err := fmt.Errorf("refuse to prune static routes of logical router %s with empty generation key", lrName)
+		klog.Error(err)
+		return err
+	}
+
+	return c.deleteLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		generation, ok := route.ExternalIDs[generationKey]
+		return ok && generation != currentGeneration
+	})
+}
+
+// StaticRouteExpiryExternalIDs returns a copy of the external ids with the expiry time of the route,
+// which is now plus ttl, stamped as the value of expiryKey in RFC 3339 format
+func StaticRouteExpiryExternalIDs(externalIDs map[string]string, expiryKey string, ttl time.Duration) map[string]string {
+	result := maps.Clone(externalIDs)
+	if result == nil {
+		result = make(map[string]string, 1)
+	}
+	result[expiryKey] = time.Now().Add(ttl).UTC().Format(time.RFC3339)
+	return result
+}
+
+// SweepExpiredStaticRoutes delete the logical router static routes whose expiry time stamped in the expiryKey
+// external id has passed in one transaction, routes without the key or with an invalid expiry time are never expired
+func (c *OVNNbClient) SweepExpiredStaticRoutes(lrName, expiryKey string) error {
+	if expiryKey == "" {
+		err := fmt.Errorf("refuse to sweep static routes of logical router %s with empty expiry key", lrName)
+		klog.Error(err)
+		return err
+	}
+
+	now := time.Now()
+	return c.deleteLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		value, ok := route.ExternalIDs[expiryKey]
+		if !ok {
+			return false
+		}
+		expiry, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			klog.Warningf("invalid expiry time %q of static route %s on logical router %s: %v", value, route.UUID, lrName, err)
+			return false
+		}
+		return !expiry.After(now)
+	})
+}
+
+// deleteLogicalRouterStaticRoutesByFilter delete the logical router static routes matching the filter
+func (c *OVNNbClient) deleteLogicalRouterStaticRoutesByFilter(lrName string, filter func(route *ovnnb.LogicalRouterStaticRoute) bool) error {
+	lr, err := c.getCachedLogicalRouter(lrName, true)
+	if err != nil {
+		return err
+	}
+	if lr == nil {
+		return nil
+	}
+
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, filter)
+	if err != nil {
+		klog.Error(err)
 		return err
 	}
-	if lr == nil {
-		return nil
+
+	return c.removeLogicalRouterStaticRoutes(lrName, routes)
+}
+
+// removeLogicalRouterStaticRoutes remove the static routes from logical router in one transaction,
+// or in ordered chunks if MaxStaticRoutesPerTransaction is exceeded
+func (c *OVNNbClient) removeLogicalRouterStaticRoutes(lrName string, routes []*ovnnb.LogicalRouterStaticRoute) error {
+	uuids := make([]string, 0, len(routes))
+	for _, route := range routes {
+		uuids = append(uuids, route.UUID)
+	}
+
+	return c.removeLogicalRouterStaticRouteUUIDs(lrName, uuids)
+}
+
+func (c *OVNNbClient) removeLogicalRouterStaticRouteUUIDs(lrName string, uuids []string) error {
+	size := c.staticRouteChunkSize(len(uuids))
+	chunks := staticRouteChunkCount(len(uuids), size)
+	for i := range chunks {
+		chunk := uuids[i*size : min((i+1)*size, len(uuids))]
+		// remove static route from logical router
+		ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, chunk, ovsdb.MutateOperationDelete)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", chunk, lrName, err)
+		}
+		if err = c.transactStaticRoutes("lr-route-del", c.getStaticRoutesForHooks(chunk), ops); err != nil {
+			klog.Error(err)
+			return fmt.Errorf("delete static routes %v from logical router %s: %w", chunk, lrName, staticRouteChunkError(i, chunks, err))
+		}
+	}
+
+	return nil
+}
+
+// BatchDeleteLogicalRouterStaticRoute batch delete a logical router static route,
+// the requested routes which are not found in the logical router are returned
+func (c *OVNNbClient) BatchDeleteLogicalRouterStaticRoute(lrName string, staticRoutes []*ovnnb.LogicalRouterStaticRoute) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	lr, err := c.getCachedLogicalRouter(lrName, true)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	requested := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(staticRoutes))
+	staticRoutesMap := make(map[string][]*ovnnb.LogicalRouterStaticRoute, len(staticRoutes))
+	for _, route := range staticRoutes {
+		if route == nil {
+			continue
+		}
+
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)
+		staticRoutesMap[key] = append(staticRoutesMap[key], route)
+		requested = append(requested, route)
+	}
+	if lr == nil {
+		return requested, nil
+	}
+
+	routes, err := c.batchListLogicalRouterStaticRoutesForDelete(staticRoutesMap, lr.StaticRoutes)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	found := make(map[*ovnnb.LogicalRouterStaticRoute]bool, len(requested))
+	uuids := make([]string, 0, len(routes))
+	for _, route := range routes {
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)
+		matched := false
+		for _, r := range staticRoutesMap[key] {
+			if r.Nexthop == "" || route.Nexthop == r.Nexthop {
+				found[r] = true
+				matched = true
+			}
+		}
+		if matched {
+			uuids = append(uuids, route.UUID)
+		}
 	}
 
-	// remove static route from logical router
-	ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, []string{uuid}, ovsdb.MutateOperationDelete)
+	notFound := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(requested)-len(found))
+	for _, route := range requested {
+		if !found[route] {
+			notFound = append(notFound, route)
+		}
+	}
+
+	// not found, skip
+	if len(uuids) == 0 {
+		return notFound, nil
+	}
+
+	if err = c.removeLogicalRouterStaticRouteUUIDs(lrName, uuids); err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	return notFound, nil
+}
+
+// ClearLogicalRouterStaticRoute clear static route from logical router once
+func (c *OVNNbClient) ClearLogicalRouterStaticRoute(lrName string) error {
+	lr, err := c.getCachedLogicalRouter(lrName, false)
 	if err != nil {
 		klog.Error(err)
-		return fmt.Errorf("generate operations for removing static route %s from logical router %s: %w", uuid, lrName, err)
+		return fmt.Errorf("get logical router %s: %w", lrName, err)
+	}
+
+	// clear static route
+	routes := c.getStaticRoutesForHooks(lr.StaticRoutes)
+	lr.StaticRoutes = nil
+	ops, err := c.UpdateLogicalRouterOp(lr, &lr.StaticRoutes)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for clear logical router %s static route: %w", lrName, err)
 	}
-	if err = c.Transact("lr-route-del", ops); err != nil {
+	if err = c.transactStaticRoutes("lr-route-clear", routes, ops); err != nil {
 		klog.Error(err)
-		return fmt.Errorf("delete static route %s from logical router %s: %w", uuid, lrName, err)
+		return fmt.Errorf("clear logical router %s static routes: %w", lrName, err)
 	}
 
 	return nil
 }
 
-func (c *OVNNbClient) DeleteLogicalRouterStaticRouteByExternalIDs(lrName string, externalIDs map[string]string) error {
-	lr, err := c.GetLogicalRouter(lrName, true)
+// transactStaticRoutes commits the operations of static routes,
+// calling the preTransact and postTransact hooks around the transaction
+func (c *OVNNbClient) transactStaticRoutes(method string, routes []*ovnnb.LogicalRouterStaticRoute, ops []ovsdb.Operation) error {
+	if c.preTransact != nil {
+		c.preTransact(method, routes)
+	}
+	results, err := c.transact(method, ops)
+	if c.logicalRouterCache != nil {
+		// the static routes of the logical routers may have been mutated even if the transaction fails
+		c.logicalRouterCache.InvalidateAll()
+	}
 	if err != nil {
 		return err
 	}
-	if lr == nil {
+	if c.postTransact == nil && c.staticRouteAuditSink == nil {
 		return nil
 	}
+	uuids := insertedUUIDs(ops, results)
+	committed := committedStaticRoutes(routes, uuids)
+	if c.postTransact != nil {
+		c.postTransact(method, committed)
+	}
+	if c.staticRouteAuditSink != nil {
+		c.auditStaticRoutes(method, committed, ops, uuids)
+	}
+	return nil
+}
 
-	routes, err := c.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", externalIDs)
+// insertedUUIDs returns the uuids of the rows inserted by the committed operations, keyed by their named uuids
+func insertedUUIDs(ops []ovsdb.Operation, results []ovsdb.OperationResult) map[string]string {
+	uuids := make(map[string]string)
+	for i, op := range ops {
+		if op.Op == ovsdb.OperationInsert && op.UUIDName != "" && i < len(results) {
+			uuids[op.UUIDName] = results[i].UUID.GoUUID
+		}
+	}
+	return uuids
+}
+
+// committedStaticRoutes returns copies of the static routes of the committed transaction,
+// whose named uuids are replaced with the uuids of the inserted rows
+func committedStaticRoutes(routes []*ovnnb.LogicalRouterStaticRoute, uuids map[string]string) []*ovnnb.LogicalRouterStaticRoute {
+	committed := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(routes))
+	for _, route := range routes {
+		if uuid, ok := uuids[route.UUID]; ok {
+			inserted := *route
+			inserted.UUID = uuid
+			route = &inserted
+		}
+		committed = append(committed, route)
+	}
+	return committed
+}
+
+// static route operations recorded to staticRouteAuditSink
+const (
+	StaticRouteAuditAdd    = "add"
+	StaticRouteAuditDelete = "delete"
+)
+
+// StaticRouteAuditRecord is the record of a static route added to or deleted from a logical router
+type StaticRouteAuditRecord struct {
+	Time      time.Time
+	Operation string
+	// Method is the method of the transaction, e.g. lr-routes-add
+	Method     string
+	Router     string
+	UUID       string
+	RouteTable string
+	Policy     string
+	IPPrefix   string
+	Nexthop    string
+	// Owner is the value of ExternalIDOwner in the external ids of the route
+	Owner string
+}
+
+// StaticRouteAuditSink records the static routes added and deleted by the static route methods of OVNNbClient,
+// it is called synchronously after each transaction is committed
+type StaticRouteAuditSink interface {
+	RecordStaticRoutes(records []StaticRouteAuditRecord)
+}
+
+// auditStaticRoutes records the routes of the committed transaction added or deleted by the operations to staticRouteAuditSink,
+// the routes inserted are added, and the routes removed from the static_routes column of logical routers are deleted.
+// The named uuids in the operations are resolved by uuids, the uuids of the inserted rows
+func (c *OVNNbClient) auditStaticRoutes(method string, routes []*ovnnb.LogicalRouterStaticRoute, ops []ovsdb.Operation, uuids map[string]string) {
+	resolve := func(uuid string) string {
+		if inserted, ok := uuids[uuid]; ok {
+			return inserted
+		}
+		return uuid
+	}
+	inserted := set.New[string]()
+	// logical router uuids of the static routes added to or removed from them, and the static routes set by updates
+	added, removed := make(map[string]string), make(map[string]string)
+	updated := make(map[string]set.Set[string])
+	for _, op := range ops {
+		if op.Table == ovnnb.LogicalRouterStaticRouteTable && op.Op == ovsdb.OperationInsert {
+			inserted.Insert(resolve(op.UUIDName))
+			continue
+		}
+		if op.Table != ovnnb.LogicalRouterTable {
+			continue
+		}
+		lrUUID := ovsdbConditionUUID(op.Where)
+		switch op.Op {
+		case ovsdb.OperationMutate:
+			for _, mutation := range op.Mutations {
+				if mutation.Column != "static_routes" {
+					continue
+				}
+				for _, uuid := range ovsdbSetUUIDs(mutation.Value) {
+					uuid = resolve(uuid)
+					if mutation.Mutator == ovsdb.MutateOperationInsert {
+						added[uuid] = lrUUID
+					} else if mutation.Mutator == ovsdb.MutateOperationDelete {
+						removed[uuid] = lrUUID
+					}
+				}
+			}
+		case ovsdb.OperationUpdate:
+			if value, ok := op.Row["static_routes"]; ok {
+				staticRoutes := set.New[string]()
+				for _, uuid := range ovsdbSetUUIDs(value) {
+					staticRoutes.Insert(resolve(uuid))
+				}
+				updated[lrUUID] = staticRoutes
+			}
+		}
+	}
+
+	now := time.Now()
+	routerNames := make(map[string]string)
+	records := make([]StaticRouteAuditRecord, 0, len(routes))
+	for _, route := range routes {
+		operation, lrUUID := "", ""
+		if lrUUID = added[route.UUID]; lrUUID != "" || inserted.Has(route.UUID) {
+			operation = StaticRouteAuditAdd
+		} else if lrUUID = removed[route.UUID]; lrUUID != "" {
+			operation = StaticRouteAuditDelete
+		} else {
+			for uuid, staticRoutes := range updated {
+				if !staticRoutes.Has(route.UUID) {
+					operation, lrUUID = StaticRouteAuditDelete, uuid
+					break
+				}
+			}
+		}
+		if operation == "" {
+			continue
+		}
+		if _, ok := routerNames[lrUUID]; !ok && lrUUID != "" {
+			routerNames[lrUUID] = c.logicalRouterNameByUUID(lrUUID)
+		}
+		records = append(records, StaticRouteAuditRecord{
+			Time:       now,
+			Operation:  operation,
+			Method:     method,
+			Router:     routerNames[lrUUID],
+			UUID:       route.UUID,
+			RouteTable: route.RouteTable,
+			Policy:     staticRoutePolicy(route),
+			IPPrefix:   route.IPPrefix,
+			Nexthop:    route.Nexthop,
+			Owner:      route.ExternalIDs[ExternalIDOwner],
+		})
+	}
+	if len(records) != 0 {
+		c.staticRouteAuditSink.RecordStaticRoutes(records)
+	}
+}
+
+// logicalRouterNameByUUID returns the name of the logical router from cache, the uuid is returned if not found
+func (c *OVNNbClient) logicalRouterNameByUUID(uuid string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	lr := &ovnnb.LogicalRouter{UUID: uuid}
+	if err := c.Get(ctx, lr); err != nil {
+		klog.Warningf("failed to get logical router %s for static route audit: %v", uuid, err)
+		return uuid
+	}
+	return lr.Name
+}
+
+// ovsdbConditionUUID returns the uuid matched by the conditions of an operation, if any
+func ovsdbConditionUUID(conditions []ovsdb.Condition) string {
+	for _, condition := range conditions {
+		if condition.Column == "_uuid" && condition.Function == ovsdb.ConditionEqual {
+			if uuid, ok := condition.Value.(ovsdb.UUID); ok {
+				return uuid.GoUUID
+			}
+		}
+	}
+	return ""
+}
+
+// ovsdbSetUUIDs returns the uuids of an ovsdb set or a single uuid value
+func ovsdbSetUUIDs(value any) []string {
+	switch v := value.(type) {
+	case ovsdb.UUID:
+		return []string{v.GoUUID}
+	case ovsdb.OvsSet:
+		uuids := make([]string, 0, len(v.GoSet))
+		for _, elem := range v.GoSet {
+			if uuid, ok := elem.(ovsdb.UUID); ok {
+				uuids = append(uuids, uuid.GoUUID)
+			}
+		}
+		return uuids
+	}
+	return nil
+}
+
+// getStaticRoutesForHooks looks up the static routes of the uuids from cache,
+// it does nothing if neither of the transaction hooks nor the audit sink is set
+func (c *OVNNbClient) getStaticRoutesForHooks(uuids []string) []*ovnnb.LogicalRouterStaticRoute {
+	if c.preTransact == nil && c.postTransact == nil && c.staticRouteAuditSink == nil {
+		return nil
+	}
+	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(uuids))
+	for _, uuid := range uuids {
+		route, err := c.GetLogicalRouterStaticRouteByUUID(uuid)
+		if err != nil {
+			klog.Warningf("failed to get static route %s for transaction hooks: %v", uuid, err)
+			continue
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// GetLogicalRouterStaticRouteByUUID get logical router static route by UUID
+func (c *OVNNbClient) GetLogicalRouterStaticRouteByUUID(uuid string) (*ovnnb.LogicalRouterStaticRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	route := &ovnnb.LogicalRouterStaticRoute{UUID: uuid}
+	if err := c.Get(ctx, route); err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// GetLogicalRouterStaticRoute get logical router static route by some attribute,
+// a static route is uniquely identified by router(lrName), policy and ipPrefix when route is not ecmp
+// a static route is uniquely identified by router(lrName), policy, ipPrefix and nexthop when route is ecmp
+func (c *OVNNbClient) GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string, ignoreNotFound bool) (*ovnnb.LogicalRouterStaticRoute, error) {
+	// this is necessary because may exist same static route in different logical router
+	if len(lrName) == 0 {
+		return nil, errors.New("the logical router name is required")
+	}
+
+	if len(policy) == 0 {
+		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	}
+	fnFilter := func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.RouteTable == routeTable && staticRoutePolicy(route) == policy && route.IPPrefix == ipPrefix && route.Nexthop == nexthop
+	}
+	routeList, err := c.listLogicalRouterStaticRoutesByFilter(lrName, fnFilter)
 	if err != nil {
 		klog.Error(err)
-		return err
+		return nil, fmt.Errorf("get logical router %s static route 'policy %s ip_prefix %s nexthop %s': %w", lrName, policy, ipPrefix, nexthop, err)
+	}
+
+	// not found
+	if len(routeList) == 0 {
+		if ignoreNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("not found logical router %s static route 'policy %s ip_prefix %s nexthop %s'", lrName, policy, ipPrefix, nexthop)
+	}
+
+	if len(routeList) > 1 {
+		return nil, fmt.Errorf("more than one static route 'policy %s ip_prefix %s nexthop %s' in logical router %s", policy, ipPrefix, nexthop, lrName)
+	}
+
+	return routeList[0], nil
+}
+
+// staticRoutePolicy returns the policy of the static route,
+// a route without policy is a dst-ip route, which is the default policy of ovn
+func staticRoutePolicy(route *ovnnb.LogicalRouterStaticRoute) string {
+	if route.Policy == nil {
+		return ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	}
+	return *route.Policy
+}
+
+// ListLogicalRouterStaticRoutes list the static routes of the logical router, a nil route table or policy matches any,
+// and routes without policy are listed as dst-ip routes
+func (c *OVNNbClient) ListLogicalRouterStaticRoutes(lrName string, routeTable, policy *string, ipPrefix string, externalIDs map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	fnFilter := func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		if !staticRouteMapMatch(route.ExternalIDs, externalIDs) {
+			return false
+		}
+
+		if routeTable != nil && route.RouteTable != *routeTable {
+			return false
+		}
+		if policy != nil && staticRoutePolicy(route) != *policy {
+			return false
+		}
+		if ipPrefix != "" && route.IPPrefix != ipPrefix {
+			return false
+		}
+
+		return true
+	}
+
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, fnFilter)
+}
+
+// ListLogicalRouterStaticRoutesByExternalIDsAndOptions list the static routes of the logical router
+// matching both externalIDs and options in one pass, an empty value matches any non-empty value of the key
+func (c *OVNNbClient) ListLogicalRouterStaticRoutesByExternalIDsAndOptions(lrName string, externalIDs, options map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return staticRouteMapMatch(route.ExternalIDs, externalIDs) && staticRouteMapMatch(route.Options, options)
+	})
+}
+
+// ListLogicalRouterStaticRoutesOverlapping list the static routes of the logical router whose ip prefix overlaps cidr,
+// i.e. either contains or is contained by cidr, routes of the other address family or without a valid prefix are skipped
+func (c *OVNNbClient) ListLogicalRouterStaticRoutesOverlapping(lrName, cidr string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("invalid cidr %q: %w", cidr, err)
+	}
+	prefix = prefix.Masked()
+
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		routePrefix, err := netip.ParsePrefix(maskedIPPrefix(route.IPPrefix))
+		return err == nil && routePrefix.Overlaps(prefix)
+	})
+}
+
+// ListLogicalRouterStaticRoutesInPrefixRange list the static routes of the logical router whose ip prefix falls within
+// the address range from the first address of startCIDR to the last address of endCIDR, the addresses are compared
+// numerically, and routes of the other address family or without a valid prefix are skipped
+func (c *OVNNbClient) ListLogicalRouterStaticRoutesInPrefixRange(lrName, startCIDR, endCIDR string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	start, err := netip.ParsePrefix(startCIDR)
+	if err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("invalid start cidr %q: %w", startCIDR, err)
+	}
+	end, err := netip.ParsePrefix(endCIDR)
+	if err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("invalid end cidr %q: %w", endCIDR, err)
+	}
+	if start.Addr().Is4() != end.Addr().Is4() {
+		err = fmt.Errorf("start cidr %s and end cidr %s are of different address families", startCIDR, endCIDR)
+		klog.Error(err)
+		return nil, err
+	}
+	first, last := start.Masked().Addr(), prefixLastAddr(end)
+	if first.Compare(last) > 0 {
+		err = fmt.Errorf("start cidr %s is after end cidr %s", startCIDR, endCIDR)
+		klog.Error(err)
+		return nil, err
+	}
+
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		prefix, err := netip.ParsePrefix(maskedIPPrefix(route.IPPrefix))
+		if err != nil || prefix.Addr().Is4() != first.Is4() {
+			return false
+		}
+		return prefix.Addr().Compare(first) >= 0 && prefixLastAddr(prefix).Compare(last) <= 0
+	})
+}
+
+// prefixLastAddr returns the last address of the prefix
+func prefixLastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// staticRouteMapMatch reports whether the map column of a static route contains all the entries of filter
+func staticRouteMapMatch(column, filter map[string]string) bool {
+	if len(column) < len(filter) {
+		return false
+	}
+	for k, v := range filter {
+		// if only key exist but not value in filter, we should include this route,
+		// it's equal to shell command `ovn-nbctl --columns=xx find logical_router_static_route external_ids:key!=\"\"`
+		if len(v) == 0 {
+			if len(column[k]) == 0 {
+				return false
+			}
+		} else if column[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ListUnmanagedStaticRoutes returns the static routes of the logical router without the owner external id,
+// which may be leftovers or added manually
+func (c *OVNNbClient) ListUnmanagedStaticRoutes(lrName, ownerKey string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	if ownerKey == "" {
+		err := fmt.Errorf("the owner key is required to list unmanaged static routes of logical router %s", lrName)
+		klog.Error(err)
+		return nil, err
+	}
+
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.ExternalIDs[ownerKey] == ""
+	})
+}
+
+// ListLogicalRouterStaticRoutesByBFDState returns the static routes of the logical router associated with the bfd
+// sessions in the state, which is one of up, down, admin_down and init, and is matched case-insensitively with
+// underscores ignored, e.g. AdminDown. Routes without bfd or whose bfd session has no status are excluded
+func (c *OVNNbClient) ListLogicalRouterStaticRoutesByBFDState(lrName, state string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	var status ovnnb.BFDStatus
+	for _, s := range []ovnnb.BFDStatus{ovnnb.BFDStatusUp, ovnnb.BFDStatusDown, ovnnb.BFDStatusAdminDown, ovnnb.BFDStatusInit} {
+		if strings.EqualFold(strings.ReplaceAll(s, "_", ""), strings.ReplaceAll(state, "_", "")) {
+			status = s
+			break
+		}
+	}
+	if status == "" {
+		return nil, fmt.Errorf("invalid bfd state %q", state)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	var bfdList []ovnnb.BFD
+	if err := c.ovsDbClient.WhereCache(func(bfd *ovnnb.BFD) bool {
+		return bfd.Status != nil && *bfd.Status == status
+	}).List(ctx, &bfdList); err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("failed to list bfd sessions in state %s: %w", status, err)
+	}
+	bfdUUIDs := strset.NewWithSize(len(bfdList))
+	for _, bfd := range bfdList {
+		bfdUUIDs.Add(bfd.UUID)
+	}
+
+	return c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.BFD != nil && bfdUUIDs.Has(*route.BFD)
+	})
+}
+
+// GarbageCollectDanglingBFDRoutes finds the static routes of the logical router referencing bfd sessions which no
+// longer exist, e.g. deleted out-of-band, and deletes them if deleteDanglingBFDRoutes is set, or clears their bfd
+// column and the bfd ecmp option in one transaction otherwise. The dangling routes are returned
+func (c *OVNNbClient) GarbageCollectDanglingBFDRoutes(lrName string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	var bfdList []ovnnb.BFD
+	if err := c.ovsDbClient.List(ctx, &bfdList); err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("failed to list bfd sessions: %w", err)
+	}
+	bfdUUIDs := strset.NewWithSize(len(bfdList))
+	for _, bfd := range bfdList {
+		bfdUUIDs.Add(bfd.UUID)
+	}
+
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.BFD != nil && !bfdUUIDs.Has(*route.BFD)
+	})
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return nil, nil
 	}
-	if len(routes) == 0 {
-		return nil
+
+	if c.deleteDanglingBFDRoutes {
+		klog.Infof("delete %d static routes of logical router %s referencing nonexistent bfd sessions", len(routes), lrName)
+		if err = c.removeLogicalRouterStaticRoutes(lrName, routes); err != nil {
+			klog.Error(err)
+			return nil, fmt.Errorf("delete static routes referencing nonexistent bfd sessions of logical router %s: %w", lrName, err)
+		}
+		return routes, nil
 	}
 
-	uuids := make([]string, 0, len(routes))
+	ops := make([]ovsdb.Operation, 0, len(routes))
 	for _, route := range routes {
-		uuids = append(uuids, route.UUID)
-	}
+		cleared := *route
+		cleared.BFD = nil
+		cleared.Options = maps.Clone(route.Options)
+		delete(cleared.Options, util.StaticRouteBfdEcmp)
 
-	// remove static route from logical router
-	ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, uuids, ovsdb.MutateOperationDelete)
-	if err != nil {
-		klog.Error(err)
-		return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", uuids, lrName, err)
+		op, err := c.ovsDbClient.Where(&cleared).Update(&cleared, &cleared.BFD, &cleared.Options)
+		if err != nil {
+			klog.Error(err)
+			return nil, fmt.Errorf("generate operations for clearing bfd of static route %s: %w", route.UUID, err)
+		}
+		ops = append(ops, op...)
 	}
-	if err = c.Transact("lr-route-del", ops); err != nil {
+
+	klog.Infof("clear bfd of %d static routes of logical router %s referencing nonexistent bfd sessions", len(routes), lrName)
+	if err = c.transactStaticRoutes("lr-route-update", routes, ops); err != nil {
 		klog.Error(err)
-		return fmt.Errorf("delete static routes %v from logical router %s: %w", uuids, lrName, err)
+		return nil, fmt.Errorf("clear bfd of static routes of logical router %s: %w", lrName, err)
 	}
 
-	return nil
+	return routes, nil
 }
 
-// BatchDeleteLogicalRouterStaticRoute batch delete a logical router static route
-func (c *OVNNbClient) BatchDeleteLogicalRouterStaticRoute(lrName string, staticRoutes []*ovnnb.LogicalRouterStaticRoute) error {
-	lr, err := c.GetLogicalRouter(lrName, true)
-	if lr == nil && err == nil {
-		return nil
+// GetECMPWidths returns the numbers of distinct nexthops of the prefixes in the route table, keyed by "policy|ipPrefix"
+func (c *OVNNbClient) GetECMPWidths(lrName, routeTable string) (map[string]int, error) {
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.RouteTable == routeTable
+	})
+	if err != nil {
+		klog.Error(err)
+		return nil, err
 	}
 
-	staticRoutesMap := make(map[string]string, len(staticRoutes))
-	for _, route := range staticRoutes {
-		if route == nil {
-			continue
-		}
-		if route.Policy == nil {
-			route.Policy = &ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	nexthops := make(map[string]set.Set[string])
+	for _, route := range routes {
+		key := staticRoutePolicy(route) + "|" + route.IPPrefix
+		if nexthops[key] == nil {
+			nexthops[key] = set.New[string]()
 		}
-
-		staticRoutesMap[createStaticRouteKey(route.RouteTable, *route.Policy, route.IPPrefix)] = route.Nexthop
+		nexthops[key].Insert(route.Nexthop)
 	}
-	routes, err := c.batchListLogicalRouterStaticRoutesForDelete(staticRoutesMap, lr.StaticRoutes)
-	if err != nil {
-		klog.Error(err)
-		return err
+	widths := make(map[string]int, len(nexthops))
+	for key, s := range nexthops {
+		widths[key] = s.Len()
 	}
+	return widths, nil
+}
 
-	// not found, skip
-	if len(routes) == 0 {
-		return nil
+// GetLogicalRouterStaticRoutesByNexthop returns all the static routes of the logical router grouped by nexthop,
+// routes without nexthop are grouped with the discard ones under util.StaticRouteDiscardNexthop
+func (c *OVNNbClient) GetLogicalRouterStaticRoutesByNexthop(lrName string) (map[string][]*ovnnb.LogicalRouterStaticRoute, error) {
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, nil)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
 	}
 
-	uuids := make([]string, 0, len(routes))
+	routesByNexthop := make(map[string][]*ovnnb.LogicalRouterStaticRoute)
 	for _, route := range routes {
-		key := createStaticRouteKey(route.RouteTable, *route.Policy, route.IPPrefix)
-		nexthop, exits := staticRoutesMap[key]
-		if exits && (nexthop == "" || route.Nexthop == nexthop) {
-			uuids = append(uuids, route.UUID)
+		nexthop := route.Nexthop
+		if nexthop == "" {
+			nexthop = util.StaticRouteDiscardNexthop
 		}
+		routesByNexthop[nexthop] = append(routesByNexthop[nexthop], route)
 	}
+	return routesByNexthop, nil
+}
 
-	// remove static route from logical router
-	ops, err := c.LogicalRouterUpdateStaticRouteOp(lrName, uuids, ovsdb.MutateOperationDelete)
+// ListAllLogicalRouterStaticRoutesSorted list the static routes of all the route tables of the logical router,
+// sorted by route table, policy, ip prefix and nexthop, ipv4 prefixes are sorted before ipv6 ones
+func (c *OVNNbClient) ListAllLogicalRouterStaticRoutesSorted(lrName string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, nil)
 	if err != nil {
 		klog.Error(err)
-		return fmt.Errorf("generate operations for removing static routes %v from logical router %s: %w", uuids, lrName, err)
-	}
-	if err = c.Transact("lr-route-del", ops); err != nil {
-		klog.Error(err)
-		return fmt.Errorf("delete static routes %v from logical router %s: %w", uuids, lrName, err)
+		return nil, err
 	}
 
-	return nil
+	sortStaticRoutes(routes)
+	return routes, nil
 }
 
-// ClearLogicalRouterStaticRoute clear static route from logical router once
-func (c *OVNNbClient) ClearLogicalRouterStaticRoute(lrName string) error {
-	lr, err := c.GetLogicalRouter(lrName, false)
-	if err != nil {
+// sortStaticRoutes sorts the static routes by route table, policy, ip prefix and nexthop
+func sortStaticRoutes(routes []*ovnnb.LogicalRouterStaticRoute) {
+	slices.SortFunc(routes, func(a, b *ovnnb.LogicalRouterStaticRoute) int {
+		return cmp.Or(
+			strings.Compare(a.RouteTable, b.RouteTable),
+			strings.Compare(staticRoutePolicy(a), staticRoutePolicy(b)),
+			compareIPPrefix(a.IPPrefix, b.IPPrefix),
+			strings.Compare(a.Nexthop, b.Nexthop),
+		)
+	})
+}
+
+// RouterStaticRoute is a static route annotated with the logical router and the route table it belongs to
+type RouterStaticRoute struct {
+	Router string
+	// RouteTable is empty for the main route table
+	RouteTable string
+	Route      *ovnnb.LogicalRouterStaticRoute
+}
+
+// ListAllStaticRoutes lists the static routes of all the logical routers for a cluster-wide audit,
+// sorted by router name and then like ListAllLogicalRouterStaticRoutesSorted
+func (c *OVNNbClient) ListAllStaticRoutes() ([]RouterStaticRoute, error) {
+	var routes []RouterStaticRoute
+	if err := c.WalkAllStaticRoutes(func(route RouterStaticRoute) error {
+		routes = append(routes, route)
+		return nil
+	}); err != nil {
 		klog.Error(err)
-		return fmt.Errorf("get logical router %s: %w", lrName, err)
+		return nil, err
 	}
+	return routes, nil
+}
 
-	// clear static route
-	lr.StaticRoutes = nil
-	ops, err := c.UpdateLogicalRouterOp(lr, &lr.StaticRoutes)
+// WalkAllStaticRoutes calls fn with the static routes of all the logical routers in the order of ListAllStaticRoutes,
+// only the routes of one router are held at a time to bound the memory in large clusters. Walking stops at the first
+// error returned by fn, which is returned as is
+func (c *OVNNbClient) WalkAllStaticRoutes(fn func(route RouterStaticRoute) error) error {
+	lrList, err := c.ListLogicalRouter(false, nil)
 	if err != nil {
 		klog.Error(err)
-		return fmt.Errorf("generate operations for clear logical router %s static route: %w", lrName, err)
-	}
-	if err = c.Transact("lr-route-clear", ops); err != nil {
-		klog.Error(err)
-		return fmt.Errorf("clear logical router %s static routes: %w", lrName, err)
+		return err
 	}
+	slices.SortFunc(lrList, func(a, b ovnnb.LogicalRouter) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 
+	for _, lr := range lrList {
+		routes, err := c.getLogicalRouterStaticRoutesByUUIDs(lr.Name, lr.StaticRoutes, nil)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("list static routes of logical router %s: %w", lr.Name, err)
+		}
+		sortStaticRoutes(routes)
+		for _, route := range routes {
+			if err = fn(RouterStaticRoute{Router: lr.Name, RouteTable: route.RouteTable, Route: route}); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
-// GetLogicalRouterStaticRouteByUUID get logical router static route by UUID
-func (c *OVNNbClient) GetLogicalRouterStaticRouteByUUID(uuid string) (*ovnnb.LogicalRouterStaticRoute, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
-	defer cancel()
+// StaticRouteSnapshot is a static route normalized for comparison, which has no uuid or reference to other rows
+type StaticRouteSnapshot struct {
+	RouteTable  string
+	Policy      string
+	IPPrefix    string
+	Nexthop     string
+	OutputPort  string
+	Options     map[string]string
+	ExternalIDs map[string]string
+}
 
-	route := &ovnnb.LogicalRouterStaticRoute{UUID: uuid}
-	if err := c.Get(ctx, route); err != nil {
+// GetRouterRoutingSnapshot returns the snapshot of all the static routes of the logical router sorted like
+// ListAllLogicalRouterStaticRoutesSorted, routers with the same routes have deeply equal snapshots regardless of uuids and order
+func (c *OVNNbClient) GetRouterRoutingSnapshot(lrName string) ([]StaticRouteSnapshot, error) {
+	routes, err := c.ListAllLogicalRouterStaticRoutesSorted(lrName)
+	if err != nil {
 		klog.Error(err)
 		return nil, err
 	}
 
-	return route, nil
+	snapshot := make([]StaticRouteSnapshot, 0, len(routes))
+	for _, route := range routes {
+		s := StaticRouteSnapshot{
+			RouteTable: route.RouteTable,
+			Policy:     staticRoutePolicy(route),
+			IPPrefix:   route.IPPrefix,
+			Nexthop:    route.Nexthop,
+			OutputPort: ptr.Deref(route.OutputPort, ""),
+		}
+		if len(route.Options) != 0 {
+			s.Options = maps.Clone(route.Options)
+		}
+		if len(route.ExternalIDs) != 0 {
+			s.ExternalIDs = maps.Clone(route.ExternalIDs)
+		}
+		snapshot = append(snapshot, s)
+	}
+	return snapshot, nil
 }
 
-// GetLogicalRouterStaticRoute get logical router static route by some attribute,
-// a static route is uniquely identified by router(lrName), policy and ipPrefix when route is not ecmp
-// a static route is uniquely identified by router(lrName), policy, ipPrefix and nexthop when route is ecmp
-func (c *OVNNbClient) GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string, ignoreNotFound bool) (*ovnnb.LogicalRouterStaticRoute, error) {
-	// this is necessary because may exist same static route in different logical router
-	if len(lrName) == 0 {
-		return nil, errors.New("the logical router name is required")
-	}
+// StaticRouteRef is the identity of a static route with its uuid, for correlating with the output of ovn-nbctl
+type StaticRouteRef struct {
+	UUID       string
+	RouteTable string
+	Policy     string
+	IPPrefix   string
+	Nexthop    string
+}
 
-	fnFilter := func(route *ovnnb.LogicalRouterStaticRoute) bool {
-		return route.RouteTable == routeTable && route.Policy != nil && *route.Policy == policy && route.IPPrefix == ipPrefix && route.Nexthop == nexthop
-	}
-	routeList, err := c.listLogicalRouterStaticRoutesByFilter(lrName, fnFilter)
+// ListLogicalRouterStaticRouteRefs returns the refs of all the static routes of the logical router sorted by uuid
+func (c *OVNNbClient) ListLogicalRouterStaticRouteRefs(lrName string) ([]StaticRouteRef, error) {
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, nil)
 	if err != nil {
 		klog.Error(err)
-		return nil, fmt.Errorf("get logical router %s static route 'policy %s ip_prefix %s nexthop %s': %w", lrName, policy, ipPrefix, nexthop, err)
-	}
-
-	// not found
-	if len(routeList) == 0 {
-		if ignoreNotFound {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("not found logical router %s static route 'policy %s ip_prefix %s nexthop %s'", lrName, policy, ipPrefix, nexthop)
+		return nil, err
 	}
 
-	if len(routeList) > 1 {
-		return nil, fmt.Errorf("more than one static route 'policy %s ip_prefix %s nexthop %s' in logical router %s", policy, ipPrefix, nexthop, lrName)
+	refs := make([]StaticRouteRef, 0, len(routes))
+	for _, route := range routes {
+		refs = append(refs, StaticRouteRef{
+			UUID:       route.UUID,
+			RouteTable: route.RouteTable,
+			Policy:     staticRoutePolicy(route),
+			IPPrefix:   route.IPPrefix,
+			Nexthop:    route.Nexthop,
+		})
 	}
-
-	return routeList[0], nil
+	slices.SortFunc(refs, func(a, b StaticRouteRef) int { return strings.Compare(a.UUID, b.UUID) })
+	return refs, nil
 }
 
-// ListLogicalRouterStaticRoutes list route which match the given externalIDs
-func (c *OVNNbClient) ListLogicalRouterStaticRoutes(lrName string, routeTable, policy *string, ipPrefix string, externalIDs map[string]string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
-	fnFilter := func(route *ovnnb.LogicalRouterStaticRoute) bool {
-		if len(route.ExternalIDs) < len(externalIDs) {
-			return false
+// ExportRouterRoutesDOT exports the static routes of the logical router as a graphviz dot graph for visualization.
+// The router is linked to the prefixes of each route table and policy, and each prefix is linked to its nexthops,
+// through an ecmp node if there are multiple ones. The edges to the nexthops protected by bfd are labeled with bfd
+func (c *OVNNbClient) ExportRouterRoutesDOT(lrName string) (string, error) {
+	routes, err := c.listLogicalRouterStaticRoutesByFilter(lrName, nil)
+	if err != nil {
+		klog.Error(err)
+		return "", err
+	}
+	sortStaticRoutes(routes)
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "digraph %q {\n", lrName)
+	sb.WriteString("  rankdir=LR;\n")
+	routerID := "router:" + lrName
+	fmt.Fprintf(sb, "  %q [shape=box, label=%q];\n", routerID, lrName)
+
+	nexthops := strset.New()
+	for i := 0; i < len(routes); {
+		policy := staticRoutePolicy(routes[i])
+		key := createStaticRouteKey(routes[i].RouteTable, policy, routes[i].IPPrefix)
+		j := i + 1
+		for j < len(routes) && createStaticRouteKey(routes[j].RouteTable, staticRoutePolicy(routes[j]), routes[j].IPPrefix) == key {
+			j++
 		}
+		group := routes[i:j]
+		i = j
 
-		if len(route.ExternalIDs) != 0 {
-			for k, v := range externalIDs {
-				// if only key exist but not value in externalIDs, we should include this route,
-				// it's equal to shell command `ovn-nbctl --columns=xx find logical_router_static_route external_ids:key!=\"\"`
-				if len(v) == 0 {
-					if len(route.ExternalIDs[k]) == 0 {
-						return false
-					}
-				} else {
-					if route.ExternalIDs[k] != v {
-						return false
-					}
-				}
-			}
+		prefixID := "prefix:" + key
+		label := group[0].IPPrefix
+		if group[0].RouteTable != util.MainRouteTable {
+			label += "\ntable " + group[0].RouteTable
 		}
-
-		if routeTable != nil && route.RouteTable != *routeTable {
-			return false
+		if policy == ovnnb.LogicalRouterStaticRoutePolicySrcIP {
+			label += "\nsrc-ip"
 		}
-		if policy != nil {
-			if route.Policy != nil {
-				if *route.Policy != *policy {
-					return false
-				}
-			} else if *policy != ovnnb.LogicalRouterStaticRoutePolicyDstIP {
-				return false
-			}
+		fmt.Fprintf(sb, "  %q [shape=ellipse, label=%q];\n", prefixID, label)
+		fmt.Fprintf(sb, "  %q -> %q;\n", routerID, prefixID)
+
+		from := prefixID
+		if len(group) > 1 {
+			from = "ecmp:" + key
+			fmt.Fprintf(sb, "  %q [shape=diamond, label=%q];\n", from, fmt.Sprintf("ecmp x%d", len(group)))
+			fmt.Fprintf(sb, "  %q -> %q;\n", prefixID, from)
 		}
-		if ipPrefix != "" && route.IPPrefix != ipPrefix {
-			return false
+		for _, route := range group {
+			nexthop := route.Nexthop
+			if nexthop == "" {
+				nexthop = util.StaticRouteDiscardNexthop
+			}
+			nexthops.Add(nexthop)
+			if route.BFD != nil {
+				fmt.Fprintf(sb, "  %q -> %q [label=\"bfd\", style=bold];\n", from, "nexthop:"+nexthop)
+			} else {
+				fmt.Fprintf(sb, "  %q -> %q;\n", from, "nexthop:"+nexthop)
+			}
 		}
+	}
 
-		return true
+	sortedNexthops := nexthops.List()
+	slices.Sort(sortedNexthops)
+	for _, nexthop := range sortedNexthops {
+		fmt.Fprintf(sb, "  %q [shape=box, style=rounded, label=%q];\n", "nexthop:"+nexthop, nexthop)
 	}
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
 
-	return c.listLogicalRouterStaticRoutesByFilter(lrName, fnFilter)
+// compareIPPrefix compares ip prefixes by address and then prefix length, invalid prefixes are compared as strings after valid ones
+func compareIPPrefix(a, b string) int {
+	prefixA, errA := netip.ParsePrefix(a)
+	prefixB, errB := netip.ParsePrefix(b)
+	if errA != nil || errB != nil {
+		if errA == nil {
+			return -1
+		}
+		if errB == nil {
+			return 1
+		}
+		return strings.Compare(a, b)
+	}
+	return cmp.Or(prefixA.Addr().Compare(prefixB.Addr()), cmp.Compare(prefixA.Bits(), prefixB.Bits()))
 }
 
 func (c *OVNNbClient) LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop string) (bool, error) {
@@ -420,8 +3433,73 @@ func (c *OVNNbClient) LogicalRouterStaticRouteExists(lrName, routeTable, policy,
 	return route != nil, err
 }
 
+// StaticRouteDescriptionExternalIDs returns a copy of the external ids with the human-readable description of the route
+// stored as the value of ExternalIDDescription, which is removed if description is empty. The description is not part
+// of the identity of the route, so the reconciliation does not recreate routes whose descriptions change
+func StaticRouteDescriptionExternalIDs(externalIDs map[string]string, description string) map[string]string {
+	result := maps.Clone(externalIDs)
+	if description == "" {
+		delete(result, ExternalIDDescription)
+		return result
+	}
+	if result == nil {
+		result = make(map[string]string, 1)
+	}
+	result[ExternalIDDescription] = description
+	return result
+}
+
+// StaticRouteOption is an option applied to the static route created by CreateLogicalRouterStaticRoute
+type StaticRouteOption func(route *ovnnb.LogicalRouterStaticRoute)
+
+// WithStaticRouteDescription is a StaticRouteOption to set the description of the route,
+// the external ids passed to CreateLogicalRouterStaticRoute are not modified
+func WithStaticRouteDescription(description string) StaticRouteOption {
+	return func(route *ovnnb.LogicalRouterStaticRoute) {
+		route.ExternalIDs = StaticRouteDescriptionExternalIDs(route.ExternalIDs, description)
+	}
+}
+
+// withImportedStaticRoute is a StaticRouteOption to set the external ids and options of the route to the ones of the imported route
+func withImportedStaticRoute(imported *ovnnb.LogicalRouterStaticRoute) StaticRouteOption {
+	return func(route *ovnnb.LogicalRouterStaticRoute) {
+		route.ExternalIDs = maps.Clone(imported.ExternalIDs)
+		route.Options = maps.Clone(imported.Options)
+	}
+}
+
+// FormatLogicalRouterStaticRoutes formats the static routes of the logical router sorted like
+// ListAllLogicalRouterStaticRoutesSorted, one line for each route followed by its description if any
+func (c *OVNNbClient) FormatLogicalRouterStaticRoutes(lrName string) ([]string, error) {
+	routes, err := c.ListAllLogicalRouterStaticRoutesSorted(lrName)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	lines := make([]string, 0, len(routes))
+	for _, route := range routes {
+		routeTable := route.RouteTable
+		if routeTable == util.MainRouteTable {
+			routeTable = "main"
+		}
+		line := fmt.Sprintf("%s %s %s via %s", routeTable, staticRoutePolicy(route), route.IPPrefix, route.Nexthop)
+		if route.OutputPort != nil {
+			line += " dev " + *route.OutputPort
+		}
+		if route.BFD != nil {
+			line += " bfd"
+		}
+		if description := route.ExternalIDs[ExternalIDDescription]; description != "" {
+			line += fmt.Sprintf(" # %s", description)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
 // newLogicalRouterStaticRoute return logical router static route with basic information
-func (c *OVNNbClient) newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string, bfdID *string, externalIDs map[string]string, options ...func(route *ovnnb.LogicalRouterStaticRoute)) (*ovnnb.LogicalRouterStaticRoute, error) {
+func (c *OVNNbClient) newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop string, bfdID *string, externalIDs map[string]string, options ...StaticRouteOption) (*ovnnb.LogicalRouterStaticRoute, error) {
 	if len(lrName) == 0 {
 		return nil, errors.New("the logical router name is required")
 	}
@@ -430,6 +3508,19 @@ func (c *OVNNbClient) newLogicalRouterStaticRoute(lrName, routeTable, policy, ip
 		policy = ovnnb.LogicalRouterStaticRoutePolicyDstIP
 	}
 
+	if policy == ovnnb.LogicalRouterStaticRoutePolicyDstIP {
+		if err := checkStaticRouteNexthopFamily(ipPrefix, nexthop); err != nil {
+			klog.Error(err)
+			return nil, err
+		}
+	}
+	if c.rejectSelfNexthopStaticRoutes {
+		if err := c.checkStaticRouteSelfNexthop(lrName, ipPrefix, nexthop); err != nil {
+			klog.Error(err)
+			return nil, err
+		}
+	}
+
 	exists, err := c.LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop)
 	if err != nil {
 		klog.Error(err)
@@ -441,8 +3532,12 @@ func (c *OVNNbClient) newLogicalRouterStaticRoute(lrName, routeTable, policy, ip
 		return nil, nil
 	}
 
+	uuid := ovsclient.NamedUUID()
+	if c.deterministicStaticRouteUUIDs {
+		uuid = staticRouteNamedUUID(routeTable, policy, ipPrefix, nexthop)
+	}
 	route := &ovnnb.LogicalRouterStaticRoute{
-		UUID:        ovsclient.NamedUUID(),
+		UUID:        uuid,
 		Policy:      &policy,
 		IPPrefix:    ipPrefix,
 		Nexthop:     nexthop,
@@ -452,6 +3547,12 @@ func (c *OVNNbClient) newLogicalRouterStaticRoute(lrName, routeTable, policy, ip
 	for _, option := range options {
 		option(route)
 	}
+	if route.OutputPort != nil {
+		if err = c.checkStaticRouteOutputPort(lrName, *route.OutputPort); err != nil {
+			klog.Error(err)
+			return nil, err
+		}
+	}
 
 	if bfdID != nil {
 		route.BFD = bfdID
@@ -463,18 +3564,88 @@ func (c *OVNNbClient) newLogicalRouterStaticRoute(lrName, routeTable, policy, ip
 	return route, nil
 }
 
+// checkStaticRouteSelfNexthop returns ErrStaticRouteSelfNexthop if the nexthop is an address of a port of the logical router
+func (c *OVNNbClient) checkStaticRouteSelfNexthop(lrName, ipPrefix, nexthop string) error {
+	nexthopIP, err := netip.ParseAddr(nexthop)
+	if err != nil {
+		return nil
+	}
+	lr, err := c.getCachedLogicalRouter(lrName, false)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	for _, uuid := range lr.Ports {
+		lrp, err := c.GetLogicalRouterPortByUUID(uuid)
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		for _, network := range lrp.Networks {
+			prefix, err := netip.ParsePrefix(network)
+			if err != nil {
+				continue
+			}
+			if prefix.Addr().Unmap() == nexthopIP.Unmap() {
+				return fmt.Errorf("%w: nexthop %s of route %s is the address of port %s of logical router %s", ErrStaticRouteSelfNexthop, nexthop, ipPrefix, lrp.Name, lrName)
+			}
+		}
+	}
+	return nil
+}
+
+// checkStaticRouteOutputPort checks whether the output port is a port of the logical router
+func (c *OVNNbClient) checkStaticRouteOutputPort(lrName, outputPort string) error {
+	lrp, err := c.GetLogicalRouterPort(outputPort, true)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if lrp == nil {
+		return fmt.Errorf("output port %s of logical router %s does not exist", outputPort, lrName)
+	}
+	lr, err := c.getCachedLogicalRouter(lrName, false)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if !slices.Contains(lr.Ports, lrp.UUID) {
+		return fmt.Errorf("output port %s is not a port of logical router %s", outputPort, lrName)
+	}
+	return nil
+}
+
+// staticRouteNamedUUID derives the named uuid of a static route from its route table, policy, ip prefix and nexthop.
+// Routes of the same identity share the named uuid regardless of the other columns, so only the first of them is
+// created in a transaction, and different identities collide only if the first 128 bits of their sha256 sums are equal
+func staticRouteNamedUUID(routeTable, policy, ipPrefix, nexthop string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{routeTable, policy, ipPrefix, nexthop}, "\x00")))
+	return "r" + hex.EncodeToString(sum[:16])
+}
+
 func (c *OVNNbClient) listLogicalRouterStaticRoutesByFilter(lrName string, filter func(route *ovnnb.LogicalRouterStaticRoute) bool) ([]*ovnnb.LogicalRouterStaticRoute, error) {
-	lr, err := c.GetLogicalRouter(lrName, false)
+	lr, err := c.getCachedLogicalRouter(lrName, false)
 	if err != nil {
 		klog.Error(err)
 		return nil, err
 	}
+	if c.StaticRouteCountWarner != nil {
+		c.StaticRouteCountWarner.Check(lrName, len(lr.StaticRoutes))
+	}
+
+	return c.getLogicalRouterStaticRoutesByUUIDs(lrName, lr.StaticRoutes, filter)
+}
 
-	routeList := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(lr.StaticRoutes))
-	for _, uuid := range lr.StaticRoutes {
+// getLogicalRouterStaticRoutesByUUIDs returns the static routes of the uuids which match the filter,
+// the uuids which fail to resolve are skipped and counted as dangling references of the logical router
+func (c *OVNNbClient) getLogicalRouterStaticRoutesByUUIDs(lrName string, uuids []string, filter func(route *ovnnb.LogicalRouterStaticRoute) bool) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+	routeList := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(uuids))
+	for _, uuid := range uuids {
 		route, err := c.GetLogicalRouterStaticRouteByUUID(uuid)
 		if err != nil {
 			if errors.Is(err, client.ErrNotFound) {
+				klog.V(4).Infof("skip dangling static route %s of logical router %s", uuid, lrName)
+				ovsClientDanglingStaticRoutes.WithLabelValues(lrName).Inc()
 				continue
 			}
 			klog.Error(err)
@@ -488,14 +3659,56 @@ func (c *OVNNbClient) listLogicalRouterStaticRoutesByFilter(lrName string, filte
 	return routeList, nil
 }
 
+// staticRouteIdentity is the route table, policy, ip prefix and nexthop identifying a static route
+type staticRouteIdentity struct {
+	routeTable, policy, ipPrefix, nexthop string
+}
+
+// existingStaticRouteNamedUUIDs returns the named uuids derived by staticRouteNamedUUID of the static routes
+// of the logical router which have the same identities as the given routes
+func (c *OVNNbClient) existingStaticRouteNamedUUIDs(lrName string, routes []*ovnnb.LogicalRouterStaticRoute) (*strset.Set, error) {
+	identities := make(map[staticRouteIdentity]bool, len(routes))
+	for _, route := range routes {
+		if route != nil {
+			identities[staticRouteIdentity{route.RouteTable, staticRoutePolicy(route), route.IPPrefix, route.Nexthop}] = true
+		}
+	}
+
+	lr, err := c.getCachedLogicalRouter(lrName, false)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	lrStaticRouteSet := set.New(lr.StaticRoutes...)
+	fnFilter := func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return identities[staticRouteIdentity{route.RouteTable, staticRoutePolicy(route), route.IPPrefix, route.Nexthop}] &&
+			lrStaticRouteSet.Has(route.UUID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	routeList := make([]*ovnnb.LogicalRouterStaticRoute, 0)
+	if err = c.ovsDbClient.WhereCache(fnFilter).List(ctx, &routeList); err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("failed to list existing static routes of logical router %s: %w", lrName, err)
+	}
+
+	existing := strset.NewWithSize(len(routeList))
+	for _, route := range routeList {
+		existing.Add(staticRouteNamedUUID(route.RouteTable, staticRoutePolicy(route), route.IPPrefix, route.Nexthop))
+	}
+	return existing, nil
+}
+
 // batchListLogicalRouterStaticRoutesForDelete batch list route which match the given condition when need delete static route
-func (c *OVNNbClient) batchListLogicalRouterStaticRoutesForDelete(staticRoutes map[string]string, lrStaticRoute []string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
+func (c *OVNNbClient) batchListLogicalRouterStaticRoutesForDelete(staticRoutes map[string][]*ovnnb.LogicalRouterStaticRoute, lrStaticRoute []string) ([]*ovnnb.LogicalRouterStaticRoute, error) {
 	lrStaticRouteSet := set.New(lrStaticRoute...)
 	fnFilter := func(route *ovnnb.LogicalRouterStaticRoute) bool {
 		if !lrStaticRouteSet.Has(route.UUID) {
 			return false
 		}
-		key := createStaticRouteKey(route.RouteTable, *route.Policy, route.IPPrefix)
+		key := createStaticRouteKey(route.RouteTable, staticRoutePolicy(route), route.IPPrefix)
 		_, exists := staticRoutes[key]
 		return exists
 	}
@@ -506,12 +3719,29 @@ func (c *OVNNbClient) batchListLogicalRouterStaticRoutesForDelete(staticRoutes m
 	routeList := make([]*ovnnb.LogicalRouterStaticRoute, 0)
 	if err := c.ovsDbClient.WhereCache(fnFilter).List(ctx, &routeList); err != nil {
 		klog.Error(err)
-		return nil, fmt.Errorf("batch list logical staric router %v lr staric route %v route: %w", staticRoutes, lrStaticRoute, err)
+		return nil, fmt.Errorf("batch list logical staric router %v lr staric route %v route: %w", slices.Collect(maps.Keys(staticRoutes)), lrStaticRoute, err)
 	}
 
 	return routeList, nil
 }
 
+// checkStaticRouteNexthopFamily checks whether the nexthop has the same ip family as the ip prefix,
+// a route without nexthop (blackhole) or with a non-ip nexthop (e.g. discard) is always allowed
+func checkStaticRouteNexthopFamily(ipPrefix, nexthop string) error {
+	if nexthop == "" {
+		return nil
+	}
+	nexthopIP := net.ParseIP(nexthop)
+	prefixIP := net.ParseIP(strings.Split(ipPrefix, "/")[0])
+	if nexthopIP == nil || prefixIP == nil {
+		return nil
+	}
+	if (nexthopIP.To4() != nil) != (prefixIP.To4() != nil) {
+		return fmt.Errorf("the ip family of nexthop %s does not match ip prefix %s", nexthop, ipPrefix)
+	}
+	return nil
+}
+
 func createStaticRouteKey(routeTable, policy, ipPrefix string) string {
 	return fmt.Sprintf("%s-%s-%s", routeTable, policy, ipPrefix)
 }
@@ -1,11 +1,21 @@
 package ovs
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 
+	ovsclient "github.com/kubeovn/kube-ovn/pkg/ovsdb/client"
 	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
@@ -152,6 +162,18 @@ func (suite *OvnClientTestSuite) testAddLogicalRouterStaticRoute() {
 		require.Error(t, err)
 	})
 
+	t.Run("nexthop family mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		ipPrefix := "192.168.90.0/24"
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, "fd00:100:64::1")
+		require.ErrorContains(t, err, "does not match ip prefix")
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+		require.NoError(t, err)
+		require.Empty(t, routes)
+	})
+
 	t.Run("bfd id mismatch", func(t *testing.T) {
 		t.Parallel()
 
@@ -174,6 +196,64 @@ func (suite *OvnClientTestSuite) testAddLogicalRouterStaticRoute() {
 		require.NoError(t, err)
 		require.Len(t, finalRoutes, 1)
 	})
+
+	t.Run("additive mode", func(t *testing.T) {
+		t.Parallel()
+
+		ipPrefix := "192.168.110.0/24"
+		err := nbClient.AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix, nil, nil, "192.168.110.1")
+		require.NoError(t, err)
+
+		err = nbClient.AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix, nil, nil, "192.168.110.1", "192.168.110.2")
+		require.NoError(t, err)
+
+		err = nbClient.AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, ipPrefix, nil, nil, "192.168.110.3")
+		require.ErrorIs(t, err, ErrStaticRouteNexthopConflict)
+		require.ErrorContains(t, err, "192.168.110.1")
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+		require.NoError(t, err)
+		nexthops := make([]string, 0, len(routes))
+		for _, route := range routes {
+			nexthops = append(nexthops, route.Nexthop)
+		}
+		require.ElementsMatch(t, []string{"192.168.110.1", "192.168.110.2", "192.168.110.3"}, nexthops)
+
+		// the default mode still converges to the requested nexthops
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, "192.168.110.3")
+		require.NoError(t, err)
+		routes, err = nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Equal(t, "192.168.110.3", routes[0].Nexthop)
+	})
+
+	t.Run("route table validator", func(t *testing.T) {
+		t.Parallel()
+
+		validator := NewRouteTableValidator()
+		validator.Register(lrName, "test-rtb")
+		client := *nbClient
+		client.routeTableValidator = validator
+
+		ipPrefix := "192.168.100.0/24"
+		nexthop := "192.168.100.1"
+		err := client.AddLogicalRouterStaticRoute(lrName, "unregistered-rtb", policy, ipPrefix, nil, nil, nexthop)
+		require.ErrorContains(t, err, "is not registered by vpc")
+
+		err = client.AddLogicalRouterStaticRoute("other-lr", "test-rtb", policy, ipPrefix, nil, nil, nexthop)
+		require.ErrorContains(t, err, "is not registered by vpc")
+
+		err = client.AddLogicalRouterStaticRoute(lrName, "test-rtb", policy, ipPrefix, nil, nil, nexthop)
+		require.NoError(t, err)
+
+		validator.Unregister(lrName)
+		err = client.AddLogicalRouterStaticRoute(lrName, "test-rtb", policy, ipPrefix, nil, nil, nexthop)
+		require.ErrorContains(t, err, "is not registered by vpc")
+
+		err = client.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, policy, ipPrefix, nil, nil, nexthop)
+		require.NoError(t, err)
+	})
 }
 
 func (suite *OvnClientTestSuite) testDeleteLogicalRouterStaticRouteByUUID() {
@@ -285,620 +365,4443 @@ func (suite *OvnClientTestSuite) testDeleteLogicalRouterStaticRouteByExternalIDs
 	require.Len(t, routes, 0)
 }
 
-func (suite *OvnClientTestSuite) testDeleteLogicalRouterStaticRoute() {
+func (suite *OvnClientTestSuite) testAddLogicalRouterStaticRouteWithBFDs() {
 	t := suite.T()
 	t.Parallel()
 
 	nbClient := suite.ovnNBClient
-	lrName := "test-del-route-lr"
+	lrName := "test-add-route-with-bfds-lr"
+	lrpName := "test-add-route-with-bfds-lrp"
 	routeTable := util.MainRouteTable
-	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	policy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+	ipPrefix := "10.110.0.0/16"
 
 	err := nbClient.CreateLogicalRouter(lrName)
 	require.NoError(t, err)
 
-	t.Run("normal route", func(t *testing.T) {
-		ipPrefix := "192.168.30.0/24"
-		nexthop := "192.168.30.1"
-
-		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
-		require.NoError(t, err)
-
-		lr, err := nbClient.GetLogicalRouter(lrName, false)
+	bfdIDs := make(map[string]string)
+	for _, nexthop := range []string{"192.168.110.1", "192.168.110.2", "192.168.110.3"} {
+		bfd, err := nbClient.CreateBFD(lrpName, nexthop, 100, 100, 3, nil)
 		require.NoError(t, err)
+		bfdIDs[nexthop] = bfd.UUID
+	}
 
-		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
-		require.NoError(t, err)
-		require.Contains(t, lr.StaticRoutes, route.UUID)
+	err = nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix, bfdIDs, nil)
+	require.NoError(t, err)
 
-		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthop)
-		require.NoError(t, err)
+	routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+	require.NoError(t, err)
+	require.Len(t, routes, len(bfdIDs))
+	for _, route := range routes {
+		require.NotNil(t, route.BFD)
+		require.Equal(t, bfdIDs[route.Nexthop], *route.BFD)
+		require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
+	}
 
-		lr, err = nbClient.GetLogicalRouter(lrName, false)
+	t.Run("remove nexthop", func(t *testing.T) {
+		delete(bfdIDs, "192.168.110.3")
+		err := nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix, bfdIDs, nil)
 		require.NoError(t, err)
-		require.Empty(t, lr.StaticRoutes)
-
-		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
-		require.ErrorContains(t, err, "not found")
 
-		// delete non-exist route
-		ipPrefix = "192.168.40.0/24"
-		nexthop = "192.168.40.1"
-		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthop)
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
 		require.NoError(t, err)
+		require.Len(t, routes, len(bfdIDs))
+		for _, route := range routes {
+			require.Equal(t, bfdIDs[route.Nexthop], *route.BFD)
+		}
 	})
 
-	t.Run("delete static route for non-exist logical router", func(t *testing.T) {
-		ipPrefix := "192.168.30.0/24"
-		nexthop := "192.168.30.1"
+	t.Run("bfd not found", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, "10.111.0.0/16", map[string]string{"192.168.111.1": "00000000-0000-0000-0000-000000000000"}, nil)
+		require.ErrorContains(t, err, "failed to get bfd")
 
-		err := nbClient.DeleteLogicalRouterStaticRoute("non-exist-lrName", &routeTable, nil, ipPrefix, nexthop)
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "10.111.0.0/16", nil)
 		require.NoError(t, err)
+		require.Empty(t, routes)
 	})
 
-	t.Run("ecmp policy route", func(t *testing.T) {
-		ipPrefix := "192.168.40.0/24"
-		nexthops := []string{"192.168.50.1", "192.168.60.1"}
+	t.Run("no nexthop", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, "10.112.0.0/16", nil, nil)
+		require.ErrorContains(t, err, "no nexthop")
+		err = nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, "10.112.0.0/16", map[string]string{"192.168.112.1": ""}, nil)
+		require.ErrorContains(t, err, "no bfd")
+	})
+}
 
-		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthops...)
-		require.NoError(t, err)
+func (suite *OvnClientTestSuite) testFindConflictingStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
 
-		lr, err := nbClient.GetLogicalRouter(lrName, false)
-		require.NoError(t, err)
+	nbClient := suite.ovnNBClient
+	lrName := "test-find-conflicting-routes-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefix := "10.120.0.0/16"
 
-		for _, nexthop := range nexthops {
-			route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
-			require.NoError(t, err)
-			require.Contains(t, lr.StaticRoutes, route.UUID)
-		}
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, "192.168.120.1", "192.168.120.2")
+	require.NoError(t, err)
 
-		/* delete first route */
-		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthops[0])
+	t.Run("bfd and non-bfd nexthops", func(t *testing.T) {
+		proposed, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, "192.168.120.3", ptr.To("test-bfd-id"), nil)
 		require.NoError(t, err)
-
-		lr, err = nbClient.GetLogicalRouter(lrName, false)
+		routes, err := nbClient.FindConflictingStaticRoutes(lrName, proposed)
 		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		require.ElementsMatch(t, []string{"192.168.120.1", "192.168.120.2"}, []string{routes[0].Nexthop, routes[1].Nexthop})
+	})
 
-		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[0], false)
-		require.ErrorContains(t, err, `not found logical router test-del-route-lr static route 'policy dst-ip ip_prefix 192.168.40.0/24 nexthop 192.168.50.1'`)
+	t.Run("ecmp nexthop", func(t *testing.T) {
+		proposed := &ovnnb.LogicalRouterStaticRoute{RouteTable: routeTable, IPPrefix: ipPrefix, Nexthop: "192.168.120.3"}
+		routes, err := nbClient.FindConflictingStaticRoutes(lrName, proposed)
+		require.NoError(t, err)
+		require.Empty(t, routes)
+	})
 
-		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[1], false)
+	t.Run("duplicate nexthop", func(t *testing.T) {
+		proposed := &ovnnb.LogicalRouterStaticRoute{RouteTable: routeTable, Policy: &policy, IPPrefix: ipPrefix, Nexthop: "192.168.120.1"}
+		routes, err := nbClient.FindConflictingStaticRoutes(lrName, proposed)
 		require.NoError(t, err)
-		require.ElementsMatch(t, []string{route.UUID}, lr.StaticRoutes)
+		require.Len(t, routes, 1)
+		require.Equal(t, "192.168.120.1", routes[0].Nexthop)
 
-		/* delete second route */
-		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthops[1])
+		// the proposed route itself is not a conflict
+		routes, err = nbClient.FindConflictingStaticRoutes(lrName, routes[0])
 		require.NoError(t, err)
+		require.Empty(t, routes)
+	})
 
-		lr, err = nbClient.GetLogicalRouter(lrName, false)
+	t.Run("other route table", func(t *testing.T) {
+		proposed := &ovnnb.LogicalRouterStaticRoute{RouteTable: "test-rtb", IPPrefix: ipPrefix, Nexthop: "192.168.120.1", BFD: ptr.To("test-bfd-id")}
+		routes, err := nbClient.FindConflictingStaticRoutes(lrName, proposed)
 		require.NoError(t, err)
-		require.Empty(t, lr.StaticRoutes)
+		require.Empty(t, routes)
+	})
 
-		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[1], false)
-		require.ErrorContains(t, err, `not found logical router test-del-route-lr static route 'policy dst-ip ip_prefix 192.168.40.0/24 nexthop 192.168.60.1'`)
+	t.Run("nil route", func(t *testing.T) {
+		_, err := nbClient.FindConflictingStaticRoutes(lrName, nil)
+		require.ErrorContains(t, err, "the proposed static route is required")
 	})
 }
 
-func (suite *OvnClientTestSuite) testClearLogicalRouterStaticRoute() {
+func (suite *OvnClientTestSuite) testGetLogicalRouterStaticRoutesByNexthop() {
 	t := suite.T()
 	t.Parallel()
 
 	nbClient := suite.ovnNBClient
-	lrName := "test-clear-route-lr"
+	lrName := "test-get-routes-by-nexthop-lr"
 	routeTable := util.MainRouteTable
-	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-	ipPrefixes := []string{"192.168.30.0/24", "192.168.40.0/24"}
-	nexthops := []string{"192.168.30.1", "192.168.40.1"}
-	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0, 5)
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
 
 	err := nbClient.CreateLogicalRouter(lrName)
 	require.NoError(t, err)
 
-	for i, ipPrefix := range ipPrefixes {
-		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[i], nil, nil)
-		require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.0.0.0/8", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.1.0.0/16", nil, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterBlackholeRoute(lrName, routeTable, "10.2.0.0/16", nil)
+	require.NoError(t, err)
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, &ovnnb.LogicalRouterStaticRoute{
+		UUID:       ovsclient.NamedUUID(),
+		Policy:     &dstIP,
+		RouteTable: routeTable,
+		IPPrefix:   "10.3.0.0/16",
+	})
+	require.NoError(t, err)
 
-		routes = append(routes, route)
+	routes, err := nbClient.GetLogicalRouterStaticRoutesByNexthop(lrName)
+	require.NoError(t, err)
+	prefixes := make(map[string][]string, len(routes))
+	for nexthop, nexthopRoutes := range routes {
+		for _, route := range nexthopRoutes {
+			prefixes[nexthop] = append(prefixes[nexthop], route.IPPrefix)
+		}
 	}
+	require.Len(t, prefixes, 3)
+	require.ElementsMatch(t, []string{"10.0.0.0/8", "10.1.0.0/16"}, prefixes["192.168.0.1"])
+	require.ElementsMatch(t, []string{"10.1.0.0/16"}, prefixes["192.168.0.2"])
+	require.ElementsMatch(t, []string{"10.2.0.0/16", "10.3.0.0/16"}, prefixes[util.StaticRouteDiscardNexthop])
 
-	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, append(routes, nil)...)
+	_, err = nbClient.GetLogicalRouterStaticRoutesByNexthop("test-get-routes-by-nexthop-non-existent-lr")
+	require.Error(t, err)
+}
+
+func (suite *OvnClientTestSuite) testGetRouterRoutingSnapshot() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName1, lrName2 := "test-routing-snapshot-lr1", "test-routing-snapshot-lr2"
+	dstIP, srcIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP, ovnnb.LogicalRouterStaticRoutePolicySrcIP
+	externalIDs := map[string]string{"vendor": util.CniTypeName}
+
+	type route struct {
+		routeTable, policy, ipPrefix string
+		externalIDs                  map[string]string
+		nexthops                     []string
+	}
+	routes := []route{
+		{util.MainRouteTable, dstIP, "0.0.0.0/0", externalIDs, []string{"192.168.0.254"}},
+		{util.MainRouteTable, dstIP, "10.100.0.0/16", nil, []string{"192.168.0.2", "192.168.0.3"}},
+		{"rtb-a", srcIP, "10.16.0.0/16", externalIDs, []string{"192.168.0.1"}},
+		{"rtb-a", dstIP, "fd00::/64", nil, []string{"fd00::1"}},
+	}
+	for i, lrName := range []string{lrName1, lrName2} {
+		err := nbClient.CreateLogicalRouter(lrName)
+		require.NoError(t, err)
+		for j := range routes {
+			r := routes[j]
+			if i == 1 {
+				// add the routes and nexthops of the second router in reverse order
+				r = routes[len(routes)-1-j]
+				r.nexthops = slices.Clone(r.nexthops)
+				slices.Reverse(r.nexthops)
+			}
+			err = nbClient.AddLogicalRouterStaticRoute(lrName, r.routeTable, r.policy, r.ipPrefix, nil, r.externalIDs, r.nexthops...)
+			require.NoError(t, err)
+		}
+	}
+
+	snapshot1, err := nbClient.GetRouterRoutingSnapshot(lrName1)
+	require.NoError(t, err)
+	snapshot2, err := nbClient.GetRouterRoutingSnapshot(lrName2)
 	require.NoError(t, err)
+	require.Len(t, snapshot1, 5)
+	require.Equal(t, snapshot1, snapshot2)
+	require.Equal(t, StaticRouteSnapshot{
+		RouteTable:  util.MainRouteTable,
+		Policy:      dstIP,
+		IPPrefix:    "0.0.0.0/0",
+		Nexthop:     "192.168.0.254",
+		ExternalIDs: externalIDs,
+	}, snapshot1[0])
+
+	// drift of external ids
+	lrRoute, err := nbClient.GetLogicalRouterStaticRoute(lrName2, "rtb-a", dstIP, "fd00::/64", "fd00::1", false)
+	require.NoError(t, err)
+	lrRoute.ExternalIDs = map[string]string{"vendor": util.CniTypeName}
+	err = nbClient.UpdateLogicalRouterStaticRoute(lrRoute, &lrRoute.ExternalIDs)
+	require.NoError(t, err)
+	snapshot2, err = nbClient.GetRouterRoutingSnapshot(lrName2)
+	require.NoError(t, err)
+	require.NotEqual(t, snapshot1, snapshot2)
 
-	lr, err := nbClient.GetLogicalRouter(lrName, false)
+	_, err = nbClient.GetRouterRoutingSnapshot("test-routing-snapshot-non-existent-lr")
+	require.Error(t, err)
+}
+
+func (suite *OvnClientTestSuite) testListAllLogicalRouterStaticRoutesSorted() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-sorted-routes-lr"
+	dstIP, srcIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP, ovnnb.LogicalRouterStaticRoutePolicySrcIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
 	require.NoError(t, err)
-	require.Len(t, lr.StaticRoutes, 2)
 
-	err = nbClient.ClearLogicalRouterStaticRoute(lrName)
+	for _, route := range []struct {
+		routeTable, policy, ipPrefix string
+		nexthops                     []string
+	}{
+		{"rtb-b", dstIP, "fd00:10::/64", []string{"fd00::2"}},
+		{"rtb-b", dstIP, "10.0.0.0/8", []string{"192.168.0.1"}},
+		{"rtb-a", srcIP, "10.16.0.0/16", []string{"192.168.0.1"}},
+		{"rtb-a", dstIP, "10.100.0.0/16", []string{"192.168.0.3", "192.168.0.2"}},
+		{"rtb-a", dstIP, "10.20.0.0/16", []string{"192.168.0.1"}},
+		{"rtb-a", dstIP, "10.20.0.0/24", []string{"192.168.0.1"}},
+		{util.MainRouteTable, dstIP, "fd00::/64", []string{"fd00::1"}},
+		{util.MainRouteTable, dstIP, "0.0.0.0/0", []string{"192.168.0.254"}},
+	} {
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, route.routeTable, route.policy, route.ipPrefix, nil, nil, route.nexthops...)
+		require.NoError(t, err)
+	}
+
+	routes, err := nbClient.ListAllLogicalRouterStaticRoutesSorted(lrName)
 	require.NoError(t, err)
+	result := make([]string, 0, len(routes))
+	for _, route := range routes {
+		result = append(result, strings.Join([]string{route.RouteTable, *route.Policy, route.IPPrefix, route.Nexthop}, " "))
+	}
+	require.Equal(t, []string{
+		" dst-ip 0.0.0.0/0 192.168.0.254",
+		" dst-ip fd00::/64 fd00::1",
+		"rtb-a dst-ip 10.20.0.0/16 192.168.0.1",
+		"rtb-a dst-ip 10.20.0.0/24 192.168.0.1",
+		"rtb-a dst-ip 10.100.0.0/16 192.168.0.2",
+		"rtb-a dst-ip 10.100.0.0/16 192.168.0.3",
+		"rtb-a src-ip 10.16.0.0/16 192.168.0.1",
+		"rtb-b dst-ip 10.0.0.0/8 192.168.0.1",
+		"rtb-b dst-ip fd00:10::/64 fd00::2",
+	}, result)
+
+	require.Negative(t, compareIPPrefix("10.0.0.0/8", "invalid"))
+	require.Positive(t, compareIPPrefix("invalid", "fd00::/64"))
+	require.Zero(t, compareIPPrefix("invalid", "invalid"))
+
+	_, err = nbClient.ListAllLogicalRouterStaticRoutesSorted("test-list-sorted-routes-non-existent-lr")
+	require.Error(t, err)
+}
 
-	for _, ipPrefix := range ipPrefixes {
-		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, "", false)
-		require.ErrorContains(t, err, "not found")
+func (suite *OvnClientTestSuite) testFindShadowedStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-find-shadowed-routes-lr"
+	routeTable := "test-rtb"
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	bfd, err := nbClient.CreateBFD("test-find-shadowed-routes-lrp", "192.168.0.2", 100, 100, 3, nil)
+	require.NoError(t, err)
+
+	newRoute := func(routeTable, ipPrefix, nexthop string, bfdID *string) *ovnnb.LogicalRouterStaticRoute {
+		return &ovnnb.LogicalRouterStaticRoute{
+			UUID:       ovsclient.NamedUUID(),
+			Policy:     &dstIP,
+			RouteTable: routeTable,
+			IPPrefix:   ipPrefix,
+			Nexthop:    nexthop,
+			BFD:        bfdID,
+		}
 	}
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName,
+		// overlapping prefixes of different lengths
+		newRoute(routeTable, "10.0.0.0/8", "192.168.0.1", nil),
+		newRoute(routeTable, "10.0.0.0/16", "192.168.0.2", nil),
+		// ecmp group
+		newRoute(routeTable, "10.1.0.0/16", "192.168.0.1", nil),
+		newRoute(routeTable, "10.1.0.0/16", "192.168.0.2", nil),
+		// exact duplicate prefix with bfd and non-bfd nexthops
+		newRoute(routeTable, "10.2.0.0/16", "192.168.0.1", nil),
+		newRoute(routeTable, "10.2.0.1/16", "192.168.0.2", &bfd.UUID),
+		// same prefix in another route table
+		newRoute(util.MainRouteTable, "10.2.0.0/16", "192.168.0.3", &bfd.UUID),
+	)
+	require.NoError(t, err)
 
-	lr, err = nbClient.GetLogicalRouter(lrName, false)
+	shadowed, err := nbClient.FindShadowedStaticRoutes(lrName, routeTable)
 	require.NoError(t, err)
-	require.Empty(t, lr.StaticRoutes)
+	require.Len(t, shadowed, 1)
+	require.Equal(t, dstIP, shadowed[0].Policy)
+	require.Equal(t, "10.2.0.0/16", shadowed[0].IPPrefix)
+	require.Len(t, shadowed[0].Routes, 2)
+	require.Equal(t, "192.168.0.1", shadowed[0].Routes[0].Nexthop)
+	require.Equal(t, "192.168.0.2", shadowed[0].Routes[1].Nexthop)
+
+	shadowed, err = nbClient.FindShadowedStaticRoutes(lrName, util.MainRouteTable)
+	require.NoError(t, err)
+	require.Empty(t, shadowed)
 
-	// clear logical router static route for non-exist logical router
-	err = nbClient.ClearLogicalRouterStaticRoute("non-exist-lrName")
-	require.ErrorContains(t, err, "not found logical router")
+	require.Equal(t, "10.0.0.1/32", maskedIPPrefix("10.0.0.1"))
+	require.Equal(t, "fd00::/64", maskedIPPrefix("fd00::1/64"))
+	require.Equal(t, "invalid", maskedIPPrefix("invalid"))
 }
 
-func (suite *OvnClientTestSuite) testGetLogicalRouterStaticRoute() {
+func (suite *OvnClientTestSuite) testFindInconsistentBFDRoutes() {
 	t := suite.T()
 	t.Parallel()
 
 	nbClient := suite.ovnNBClient
-	lrName := "test_get_route_lr"
+	lrName := "test-find-inconsistent-bfd-routes-lr"
 	routeTable := util.MainRouteTable
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
 
 	err := nbClient.CreateLogicalRouter(lrName)
 	require.NoError(t, err)
+	bfd, err := nbClient.CreateBFD("test-find-inconsistent-bfd-routes-lrp", "192.168.0.1", 100, 100, 3, nil)
+	require.NoError(t, err)
 
-	t.Run("normal route", func(t *testing.T) {
-		t.Parallel()
-		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-		ipPrefix := "192.168.30.0/24"
-		nexthop := "192.168.30.1"
+	// consistent bfd and non-bfd routes
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.0.0.0/16", &bfd.UUID, nil, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.1.0.0/16", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+	// bfd without the option and the option without bfd
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName,
+		&ovnnb.LogicalRouterStaticRoute{
+			UUID:       ovsclient.NamedUUID(),
+			Policy:     &dstIP,
+			RouteTable: routeTable,
+			IPPrefix:   "10.2.0.0/16",
+			Nexthop:    "192.168.0.1",
+			BFD:        &bfd.UUID,
+		},
+		&ovnnb.LogicalRouterStaticRoute{
+			UUID:       ovsclient.NamedUUID(),
+			Policy:     &dstIP,
+			RouteTable: routeTable,
+			IPPrefix:   "10.3.0.0/16",
+			Nexthop:    "192.168.0.1",
+			Options:    map[string]string{util.StaticRouteBfdEcmp: "true", "foo": "bar"},
+		},
+	)
+	require.NoError(t, err)
 
-		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+	t.Run("detect inconsistent routes", func(t *testing.T) {
+		routes, err := nbClient.FindInconsistentBFDRoutes(lrName, false)
 		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		prefixes := []string{routes[0].IPPrefix, routes[1].IPPrefix}
+		require.ElementsMatch(t, []string{"10.2.0.0/16", "10.3.0.0/16"}, prefixes)
 
-		t.Run("found route", func(t *testing.T) {
-			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
-			require.NoError(t, err)
-		})
-
-		t.Run("policy is different", func(t *testing.T) {
-			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, ovnnb.LogicalRouterStaticRoutePolicySrcIP, ipPrefix, nexthop, false)
-			require.ErrorContains(t, err, "not found")
-		})
-
-		t.Run("ip_prefix is different", func(t *testing.T) {
-			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "192.168.30.10", nexthop, false)
-			require.ErrorContains(t, err, "not found")
-		})
-
-		t.Run("logical router name is different", func(t *testing.T) {
-			_, err := nbClient.GetLogicalRouterStaticRoute(lrName+"x", routeTable, policy, ipPrefix, nexthop, false)
-			require.ErrorContains(t, err, "not found")
-		})
+		routes, err = nbClient.FindInconsistentBFDRoutes(lrName, false)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
 	})
 
-	t.Run("ecmp route", func(t *testing.T) {
-		t.Parallel()
-		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-		ipPrefix := "192.168.40.0/24"
-		nexthop := "192.168.40.1"
+	t.Run("repair inconsistent routes", func(t *testing.T) {
+		routes, err := nbClient.FindInconsistentBFDRoutes(lrName, true)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
 
-		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+		routes, err = nbClient.FindInconsistentBFDRoutes(lrName, false)
 		require.NoError(t, err)
+		require.Empty(t, routes)
 
-		t.Run("found route", func(t *testing.T) {
-			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
-			require.NoError(t, err)
-		})
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.2.0.0/16", "192.168.0.1", false)
+		require.NoError(t, err)
+		require.Equal(t, bfd.UUID, *route.BFD)
+		require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
 
-		t.Run("nexthop is different", func(t *testing.T) {
-			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop+"1", false)
-			require.ErrorContains(t, err, "not found")
-		})
+		route, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.3.0.0/16", "192.168.0.1", false)
+		require.NoError(t, err)
+		require.Nil(t, route.BFD)
+		require.Equal(t, map[string]string{"foo": "bar"}, route.Options)
+	})
+
+	t.Run("non-existent logical router", func(t *testing.T) {
+		_, err := nbClient.FindInconsistentBFDRoutes("test-non-existent-lr", true)
+		require.Error(t, err)
 	})
 }
 
-func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutes() {
+func (suite *OvnClientTestSuite) testListUnmanagedStaticRoutes() {
 	t := suite.T()
 	t.Parallel()
 
 	nbClient := suite.ovnNBClient
-	lrName := "test-list-routes-filters-lr"
+	lrName := "test-list-unmanaged-routes-lr"
 	routeTable := util.MainRouteTable
 	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0)
 
 	err := nbClient.CreateLogicalRouter(lrName)
 	require.NoError(t, err)
 
-	// Create test routes with different external IDs
-	testData := []struct {
-		ipPrefix    string
-		nexthop     string
-		externalIDs map[string]string
-	}{
-		{"192.168.60.0/24", "192.168.60.1", map[string]string{"key1": "value1", "key2": "value2"}},
-		{"192.168.70.0/24", "192.168.70.1", map[string]string{"key1": "value1"}},
-		{"192.168.80.0/24", "192.168.80.1", map[string]string{"key2": "value2"}},
-		{"192.168.90.0/24", "192.168.90.1", map[string]string{}},
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, map[string]string{ExternalIDOwner: "foo"}, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, map[string]string{ExternalIDVendor: util.CniTypeName}, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.2.0/24", nil, map[string]string{ExternalIDOwner: ""}, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.3.0/24", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+
+	routes, err := nbClient.ListUnmanagedStaticRoutes(lrName, ExternalIDOwner)
+	require.NoError(t, err)
+	prefixes := make([]string, 0, len(routes))
+	for _, route := range routes {
+		prefixes = append(prefixes, route.IPPrefix)
+	}
+	require.ElementsMatch(t, []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"}, prefixes)
+
+	routes, err = nbClient.ListUnmanagedStaticRoutes(lrName, ExternalIDVendor)
+	require.NoError(t, err)
+	require.Len(t, routes, 4)
+
+	_, err = nbClient.ListUnmanagedStaticRoutes(lrName, "")
+	require.ErrorContains(t, err, "owner key is required")
+
+	_, err = nbClient.ListUnmanagedStaticRoutes("test-list-unmanaged-routes-non-existent-lr", ExternalIDOwner)
+	require.Error(t, err)
+}
+
+func (suite *OvnClientTestSuite) testGetECMPWidths() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-get-ecmp-widths-lr"
+	routeTable := util.MainRouteTable
+	dstIP, srcIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP, ovnnb.LogicalRouterStaticRoutePolicySrcIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.0.0.0/8", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.1.0.0/16", nil, nil, "192.168.0.1", "192.168.0.2", "192.168.0.3")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, srcIP, "10.1.0.0/16", nil, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "fd00::/64", nil, nil, "fd00::1", "fd00::2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, "test-rtb", dstIP, "10.0.0.0/8", nil, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+
+	widths, err := nbClient.GetECMPWidths(lrName, routeTable)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		"dst-ip|10.0.0.0/8":  1,
+		"dst-ip|10.1.0.0/16": 3,
+		"src-ip|10.1.0.0/16": 2,
+		"dst-ip|fd00::/64":   2,
+	}, widths)
+
+	widths, err = nbClient.GetECMPWidths(lrName, "test-rtb")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"dst-ip|10.0.0.0/8": 2}, widths)
+
+	widths, err = nbClient.GetECMPWidths(lrName, "non-existent-rtb")
+	require.NoError(t, err)
+	require.Empty(t, widths)
+
+	_, err = nbClient.GetECMPWidths("test-get-ecmp-widths-non-existent-lr", routeTable)
+	require.Error(t, err)
+}
+
+func (suite *OvnClientTestSuite) testImportLogicalRouterStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	newRoute := func(ipPrefix, nexthop string) *ovnnb.LogicalRouterStaticRoute {
+		return &ovnnb.LogicalRouterStaticRoute{
+			Policy:      &policy,
+			RouteTable:  routeTable,
+			IPPrefix:    ipPrefix,
+			Nexthop:     nexthop,
+			ExternalIDs: map[string]string{"vendor": util.CniTypeName},
+		}
+	}
+	imported := []*ovnnb.LogicalRouterStaticRoute{
+		newRoute("10.0.0.0/24", "192.168.0.2"),
+		newRoute("10.0.0.0/24", "192.168.0.3"),
+		newRoute("10.0.1.0/24", "192.168.0.1"),
+	}
+	nexthops := func(t *testing.T, lrName, ipPrefix string) []string {
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+		require.NoError(t, err)
+		result := make([]string, 0, len(routes))
+		for _, route := range routes {
+			result = append(result, route.Nexthop)
+		}
+		return result
+	}
+
+	for strategy, expected := range map[string][]string{
+		StaticRouteImportSkip:      {"192.168.0.1", "192.168.0.2"},
+		StaticRouteImportOverwrite: {"192.168.0.2", "192.168.0.3"},
+		StaticRouteImportMerge:     {"192.168.0.1", "192.168.0.2", "192.168.0.3"},
+	} {
+		t.Run(strategy, func(t *testing.T) {
+			lrName := "test-import-lr-routes-" + strategy
+			err := nbClient.CreateLogicalRouter(lrName)
+			require.NoError(t, err)
+			err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, nil, "192.168.0.1", "192.168.0.2")
+			require.NoError(t, err)
+
+			err = nbClient.ImportLogicalRouterStaticRoutes(lrName, imported, strategy)
+			require.NoError(t, err)
+			require.ElementsMatch(t, expected, nexthops(t, lrName, "10.0.0.0/24"))
+			require.ElementsMatch(t, []string{"192.168.0.1"}, nexthops(t, lrName, "10.0.1.0/24"))
+
+			route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", "192.168.0.1", false)
+			require.NoError(t, err)
+			require.Equal(t, util.CniTypeName, route.ExternalIDs["vendor"])
+
+			// importing again changes nothing
+			err = nbClient.ImportLogicalRouterStaticRoutes(lrName, imported, strategy)
+			require.NoError(t, err)
+			require.ElementsMatch(t, expected, nexthops(t, lrName, "10.0.0.0/24"))
+		})
+	}
+
+	t.Run("per nexthop columns", func(t *testing.T) {
+		lrName := "test-import-lr-routes-columns"
+		err := nbClient.CreateLogicalRouter(lrName)
+		require.NoError(t, err)
+
+		// each route of the ecmp group is imported with its own external ids and options
+		route1, route2 := newRoute("10.0.2.0/24", "192.168.0.1"), newRoute("10.0.2.0/24", "192.168.0.2")
+		route1.ExternalIDs = map[string]string{"vendor": util.CniTypeName, "nexthop": "1"}
+		route1.Options = map[string]string{"origin": "connected"}
+		route2.ExternalIDs = map[string]string{"vendor": util.CniTypeName, "nexthop": "2"}
+		route2.Options = map[string]string{"origin": "static"}
+		err = nbClient.ImportLogicalRouterStaticRoutes(lrName, []*ovnnb.LogicalRouterStaticRoute{route1, route2}, StaticRouteImportOverwrite)
+		require.NoError(t, err)
+		for _, expected := range []*ovnnb.LogicalRouterStaticRoute{route1, route2} {
+			route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, expected.IPPrefix, expected.Nexthop, false)
+			require.NoError(t, err)
+			require.Equal(t, expected.ExternalIDs, route.ExternalIDs)
+			require.Equal(t, expected.Options, route.Options)
+		}
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		err := nbClient.ImportLogicalRouterStaticRoutes("test-import-lr-routes-unknown", imported, "replace")
+		require.ErrorContains(t, err, "unknown strategy")
+	})
+}
+
+func (suite *OvnClientTestSuite) testGetLogicalRouterStaticRoutesByUUIDs() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-get-routes-by-uuids-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+
+	lr, err := nbClient.GetLogicalRouter(lrName, false)
+	require.NoError(t, err)
+	require.Len(t, lr.StaticRoutes, 2)
+
+	routes, err := nbClient.getLogicalRouterStaticRoutesByUUIDs(lrName, lr.StaticRoutes, nil)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	require.Zero(t, testutil.ToFloat64(ovsClientDanglingStaticRoutes.WithLabelValues(lrName)))
+
+	dangling := ovsclient.NamedUUID()
+	routes, err = nbClient.getLogicalRouterStaticRoutesByUUIDs(lrName, append([]string{dangling}, lr.StaticRoutes...), func(route *ovnnb.LogicalRouterStaticRoute) bool {
+		return route.Nexthop == "192.168.0.1"
+	})
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	require.Equal(t, "192.168.0.1", routes[0].Nexthop)
+	require.Equal(t, float64(1), testutil.ToFloat64(ovsClientDanglingStaticRoutes.WithLabelValues(lrName)))
+
+	_, err = nbClient.getLogicalRouterStaticRoutesByUUIDs(lrName, []string{dangling, dangling}, nil)
+	require.NoError(t, err)
+	require.Equal(t, float64(3), testutil.ToFloat64(ovsClientDanglingStaticRoutes.WithLabelValues(lrName)))
+}
+
+func (suite *OvnClientTestSuite) testAddLogicalRouterBlackholeRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-add-blackhole-route-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	owner := map[string]string{"owner": "test-owner"}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterBlackholeRoute(lrName, routeTable, "10.100.0.0/16", owner)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterBlackholeRoute(lrName, routeTable, "fd00:100::/64", owner)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.101.0.0/16", nil, map[string]string{"owner": "other"}, "192.168.0.1")
+	require.NoError(t, err)
+
+	routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "", owner)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	for _, route := range routes {
+		require.Equal(t, util.StaticRouteDiscardNexthop, route.Nexthop)
+		require.Equal(t, owner, route.ExternalIDs)
+	}
+
+	err = nbClient.DeleteLogicalRouterStaticRouteByExternalIDs(lrName, owner)
+	require.NoError(t, err)
+
+	routes, err = nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	require.Equal(t, "10.101.0.0/16", routes[0].IPPrefix)
+}
+
+func (suite *OvnClientTestSuite) testEnsureGatewayNatRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-ensure-gateway-nat-route-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+	subnetName := "test-gateway-nat-subnet"
+	cidr := "10.120.0.0/16"
+	owned := map[string]string{ExternalIDOwner: GatewayNatRouteOwner, "subnet": subnetName}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	checkRoute := func(nexthop string) {
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, cidr, nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Equal(t, nexthop, routes[0].Nexthop)
+		for k, v := range owned {
+			require.Equal(t, v, routes[0].ExternalIDs[k])
+		}
+	}
+
+	t.Run("create route", func(t *testing.T) {
+		err := nbClient.EnsureGatewayNatRoute(lrName, subnetName, cidr, "100.64.0.2")
+		require.NoError(t, err)
+		checkRoute("100.64.0.2")
+
+		err = nbClient.EnsureGatewayNatRoute(lrName, subnetName, cidr, "100.64.0.2")
+		require.NoError(t, err)
+		checkRoute("100.64.0.2")
+	})
+
+	t.Run("repoint route to new active gateway", func(t *testing.T) {
+		err := nbClient.EnsureGatewayNatRoute(lrName, subnetName, cidr, "100.64.0.3")
+		require.NoError(t, err)
+		checkRoute("100.64.0.3")
+	})
+
+	t.Run("stamp existing route", func(t *testing.T) {
+		ipPrefix := "10.121.0.0/16"
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, map[string]string{"foo": "bar"}, "100.64.0.4")
+		require.NoError(t, err)
+
+		err = nbClient.EnsureGatewayNatRoute(lrName, subnetName, ipPrefix, "100.64.0.4")
+		require.NoError(t, err)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, "100.64.0.4", false)
+		require.NoError(t, err)
+		require.Equal(t, "bar", route.ExternalIDs["foo"])
+		require.Equal(t, GatewayNatRouteOwner, route.ExternalIDs[ExternalIDOwner])
+	})
+}
+
+func (suite *OvnClientTestSuite) testCheckRouteTableReachable() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	failedNbClient := suite.failedOvnNBClient
+	lrName := "test-check-route-table-reachable-lr"
+	lrpName := "test-check-route-table-reachable-lrp"
+	otherLrName := "test-check-route-table-reachable-other-lr"
+
+	// addPolicy adds the policy steering the matched traffic into the route table
+	addPolicy := func(lrName string, priority int, match, action, routeTable string) {
+		err := nbClient.AddLogicalRouterPolicy(lrName, priority, match, action, []string{"192.168.130.254"}, nil, nil)
+		require.NoError(t, err)
+		policies, err := nbClient.GetLogicalRouterPolicy(lrName, priority, match, false)
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		policies[0].Options = map[string]string{"route_table": routeTable}
+		err = nbClient.UpdateLogicalRouterPolicy(policies[0], &policies[0].Options)
+		require.NoError(t, err)
+	}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.CreateLogicalRouterPort(lrName, lrpName, "00:11:22:37:af:70", []string{"192.168.130.1/24"})
+	require.NoError(t, err)
+	addPolicy(lrName, 31000, "ip4.src == 10.130.1.0/24", ovnnb.LogicalRouterPolicyActionReroute, "rtb1")
+	addPolicy(lrName, 31000, "ip4.src == 10.130.4.0/24", ovnnb.LogicalRouterPolicyActionDrop, "rtb4")
+	err = nbClient.CreateLogicalRouter(otherLrName)
+	require.NoError(t, err)
+	addPolicy(otherLrName, 31000, "ip4.src == 10.130.2.0/24", ovnnb.LogicalRouterPolicyActionReroute, "rtb2")
+	// the subnet of the port selects its route table by the route_table option of the port
+	err = nbClient.UpdateLogicalRouterPortOptions(lrpName, map[string]string{"route_table": "rtb5"})
+	require.NoError(t, err)
+
+	t.Run("main route table", func(t *testing.T) {
+		reachable, err := nbClient.CheckRouteTableReachable(lrName, util.MainRouteTable)
+		require.NoError(t, err)
+		require.True(t, reachable)
+	})
+
+	t.Run("referenced route table", func(t *testing.T) {
+		reachable, err := nbClient.CheckRouteTableReachable(lrName, "rtb1")
+		require.NoError(t, err)
+		require.True(t, reachable)
+
+		// referenced by a port only
+		reachable, err = nbClient.CheckRouteTableReachable(lrName, "rtb5")
+		require.NoError(t, err)
+		require.True(t, reachable)
+	})
+
+	t.Run("orphaned route table", func(t *testing.T) {
+		reachable, err := nbClient.CheckRouteTableReachable(lrName, "rtb3")
+		require.NoError(t, err)
+		require.False(t, reachable)
+
+		// referenced by a policy of another logical router
+		reachable, err = nbClient.CheckRouteTableReachable(lrName, "rtb2")
+		require.NoError(t, err)
+		require.False(t, reachable)
+
+		// referenced by a drop policy, which never steers traffic into the table
+		reachable, err = nbClient.CheckRouteTableReachable(lrName, "rtb4")
+		require.NoError(t, err)
+		require.False(t, reachable)
+	})
+
+	t.Run("non-existent logical router", func(t *testing.T) {
+		_, err := nbClient.CheckRouteTableReachable("test-non-existent-lr", "rtb1")
+		require.Error(t, err)
+	})
+
+	t.Run("failed client", func(t *testing.T) {
+		_, err := failedNbClient.CheckRouteTableReachable(lrName, "rtb1")
+		require.Error(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testDeleteLogicalRouterStaticRoutesByOptions() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-del-lr-route-by-options"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	bfdID := "test-bfd-id"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.2.0.0/24", &bfdID, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.2.1.0/24", &bfdID, nil, "192.168.0.1")
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.2.2.0/24", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+
+	t.Run("refuse empty options", func(t *testing.T) {
+		err := nbClient.DeleteLogicalRouterStaticRoutesByOptions(lrName, nil)
+		require.ErrorContains(t, err, "empty options")
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 4)
+	})
+
+	t.Run("no matched options", func(t *testing.T) {
+		err := nbClient.DeleteLogicalRouterStaticRoutesByOptions(lrName, map[string]string{util.StaticRouteBfdEcmp: "false"})
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 4)
+	})
+
+	t.Run("delete all bfd ecmp routes", func(t *testing.T) {
+		err := nbClient.DeleteLogicalRouterStaticRoutesByOptions(lrName, map[string]string{util.StaticRouteBfdEcmp: "true"})
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Equal(t, "1.2.2.0/24", routes[0].IPPrefix)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{routes[0].UUID}, lr.StaticRoutes)
+	})
+
+	t.Run("non-existent logical router", func(t *testing.T) {
+		err := nbClient.DeleteLogicalRouterStaticRoutesByOptions("test-non-existent-lr", map[string]string{util.StaticRouteBfdEcmp: "true"})
+		require.NoError(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testPruneStaticRoutesByGeneration() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-prune-lr-route-by-generation"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	generationKey := "generation"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.3.0.0/24", nil, map[string]string{generationKey: "1"}, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.3.1.0/24", nil, map[string]string{generationKey: "2"}, "192.168.0.1")
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.3.2.0/24", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+
+	t.Run("refuse empty generation key", func(t *testing.T) {
+		err := nbClient.PruneStaticRoutesByGeneration(lrName, "", "2")
+		require.ErrorContains(t, err, "empty generation key")
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 4)
+	})
+
+	t.Run("prune old generation routes", func(t *testing.T) {
+		err := nbClient.PruneStaticRoutesByGeneration(lrName, generationKey, "2")
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		prefixes := []string{routes[0].IPPrefix, routes[1].IPPrefix}
+		require.ElementsMatch(t, []string{"1.3.1.0/24", "1.3.2.0/24"}, prefixes)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{routes[0].UUID, routes[1].UUID}, lr.StaticRoutes)
+	})
+
+	t.Run("no stale routes", func(t *testing.T) {
+		err := nbClient.PruneStaticRoutesByGeneration(lrName, generationKey, "2")
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+	})
+
+	t.Run("non-existent logical router", func(t *testing.T) {
+		err := nbClient.PruneStaticRoutesByGeneration("test-non-existent-lr", generationKey, "2")
+		require.NoError(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testSweepExpiredStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-sweep-expired-lr-route"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	expiryKey := "expiry"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	externalIDs := map[string]string{"vendor": util.CniTypeName}
+	expired := StaticRouteExpiryExternalIDs(externalIDs, expiryKey, -time.Minute)
+	require.Len(t, externalIDs, 1)
+	require.Equal(t, util.CniTypeName, expired["vendor"])
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.4.0.0/24", nil, expired, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.4.1.0/24", nil, StaticRouteExpiryExternalIDs(nil, expiryKey, time.Hour), "192.168.0.1")
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.4.2.0/24", nil, map[string]string{expiryKey: "invalid"}, "192.168.0.1")
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "1.4.3.0/24", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+
+	t.Run("refuse empty expiry key", func(t *testing.T) {
+		err := nbClient.SweepExpiredStaticRoutes(lrName, "")
+		require.ErrorContains(t, err, "empty expiry key")
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 5)
+	})
+
+	t.Run("sweep expired routes", func(t *testing.T) {
+		err := nbClient.SweepExpiredStaticRoutes(lrName, expiryKey)
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		prefixes := make([]string, 0, len(routes))
+		for _, route := range routes {
+			prefixes = append(prefixes, route.IPPrefix)
+		}
+		require.ElementsMatch(t, []string{"1.4.1.0/24", "1.4.2.0/24", "1.4.3.0/24"}, prefixes)
+	})
+
+	t.Run("non-existent logical router", func(t *testing.T) {
+		err := nbClient.SweepExpiredStaticRoutes("test-non-existent-lr", expiryKey)
+		require.NoError(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testDeleteLogicalRouterStaticRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-del-route-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("normal route", func(t *testing.T) {
+		ipPrefix := "192.168.30.0/24"
+		nexthop := "192.168.30.1"
+
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+		require.NoError(t, err)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+		require.NoError(t, err)
+		require.Contains(t, lr.StaticRoutes, route.UUID)
+
+		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthop)
+		require.NoError(t, err)
+
+		lr, err = nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, lr.StaticRoutes)
+
+		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+		require.ErrorContains(t, err, "not found")
+
+		// delete non-exist route
+		ipPrefix = "192.168.40.0/24"
+		nexthop = "192.168.40.1"
+		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthop)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete static route for non-exist logical router", func(t *testing.T) {
+		ipPrefix := "192.168.30.0/24"
+		nexthop := "192.168.30.1"
+
+		err := nbClient.DeleteLogicalRouterStaticRoute("non-exist-lrName", &routeTable, nil, ipPrefix, nexthop)
+		require.NoError(t, err)
+	})
+
+	t.Run("ecmp policy route", func(t *testing.T) {
+		ipPrefix := "192.168.40.0/24"
+		nexthops := []string{"192.168.50.1", "192.168.60.1"}
+
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthops...)
+		require.NoError(t, err)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+
+		for _, nexthop := range nexthops {
+			route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+			require.NoError(t, err)
+			require.Contains(t, lr.StaticRoutes, route.UUID)
+		}
+
+		/* delete first route */
+		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthops[0])
+		require.NoError(t, err)
+
+		lr, err = nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+
+		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[0], false)
+		require.ErrorContains(t, err, `not found logical router test-del-route-lr static route 'policy dst-ip ip_prefix 192.168.40.0/24 nexthop 192.168.50.1'`)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[1], false)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{route.UUID}, lr.StaticRoutes)
+
+		/* delete second route */
+		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthops[1])
+		require.NoError(t, err)
+
+		lr, err = nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, lr.StaticRoutes)
+
+		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[1], false)
+		require.ErrorContains(t, err, `not found logical router test-del-route-lr static route 'policy dst-ip ip_prefix 192.168.40.0/24 nexthop 192.168.60.1'`)
+	})
+}
+
+func (suite *OvnClientTestSuite) testClearLogicalRouterStaticRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-clear-route-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefixes := []string{"192.168.30.0/24", "192.168.40.0/24"}
+	nexthops := []string{"192.168.30.1", "192.168.40.1"}
+	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0, 5)
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	for i, ipPrefix := range ipPrefixes {
+		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[i], nil, nil)
+		require.NoError(t, err)
+
+		routes = append(routes, route)
+	}
+
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, append(routes, nil)...)
+	require.NoError(t, err)
+
+	lr, err := nbClient.GetLogicalRouter(lrName, false)
+	require.NoError(t, err)
+	require.Len(t, lr.StaticRoutes, 2)
+
+	err = nbClient.ClearLogicalRouterStaticRoute(lrName)
+	require.NoError(t, err)
+
+	for _, ipPrefix := range ipPrefixes {
+		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, "", false)
+		require.ErrorContains(t, err, "not found")
+	}
+
+	lr, err = nbClient.GetLogicalRouter(lrName, false)
+	require.NoError(t, err)
+	require.Empty(t, lr.StaticRoutes)
+
+	// clear logical router static route for non-exist logical router
+	err = nbClient.ClearLogicalRouterStaticRoute("non-exist-lrName")
+	require.ErrorContains(t, err, "not found logical router")
+}
+
+func (suite *OvnClientTestSuite) testGetLogicalRouterStaticRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test_get_route_lr"
+	routeTable := util.MainRouteTable
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("normal route", func(t *testing.T) {
+		t.Parallel()
+		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+		ipPrefix := "192.168.30.0/24"
+		nexthop := "192.168.30.1"
+
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+		require.NoError(t, err)
+
+		t.Run("found route", func(t *testing.T) {
+			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+			require.NoError(t, err)
+		})
+
+		t.Run("policy is different", func(t *testing.T) {
+			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, ovnnb.LogicalRouterStaticRoutePolicySrcIP, ipPrefix, nexthop, false)
+			require.ErrorContains(t, err, "not found")
+		})
+
+		t.Run("ip_prefix is different", func(t *testing.T) {
+			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "192.168.30.10", nexthop, false)
+			require.ErrorContains(t, err, "not found")
+		})
+
+		t.Run("logical router name is different", func(t *testing.T) {
+			_, err := nbClient.GetLogicalRouterStaticRoute(lrName+"x", routeTable, policy, ipPrefix, nexthop, false)
+			require.ErrorContains(t, err, "not found")
+		})
+	})
+
+	t.Run("ecmp route", func(t *testing.T) {
+		t.Parallel()
+		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+		ipPrefix := "192.168.40.0/24"
+		nexthop := "192.168.40.1"
+
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+		require.NoError(t, err)
+
+		t.Run("found route", func(t *testing.T) {
+			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+			require.NoError(t, err)
+		})
+
+		t.Run("nexthop is different", func(t *testing.T) {
+			_, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop+"1", false)
+			require.ErrorContains(t, err, "not found")
+		})
+	})
+
+	t.Run("route without policy", func(t *testing.T) {
+		t.Parallel()
+		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+		ipPrefix := "192.168.50.0/24"
+		nexthop := "192.168.50.1"
+
+		err := nbClient.CreateLogicalRouterStaticRoutes(lrName, &ovnnb.LogicalRouterStaticRoute{
+			UUID:       ovsclient.NamedUUID(),
+			IPPrefix:   ipPrefix,
+			Nexthop:    nexthop,
+			RouteTable: routeTable,
+		})
+		require.NoError(t, err)
+
+		t.Run("found as dst-ip route", func(t *testing.T) {
+			route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+			require.NoError(t, err)
+			require.Nil(t, route.Policy)
+			route, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, "", ipPrefix, nexthop, false)
+			require.NoError(t, err)
+			require.Nil(t, route.Policy)
+			exists, err := nbClient.LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, nexthop)
+			require.NoError(t, err)
+			require.True(t, exists)
+		})
+
+		t.Run("not found as src-ip route", func(t *testing.T) {
+			exists, err := nbClient.LogicalRouterStaticRouteExists(lrName, routeTable, ovnnb.LogicalRouterStaticRoutePolicySrcIP, ipPrefix, nexthop)
+			require.NoError(t, err)
+			require.False(t, exists)
+		})
+
+		t.Run("adding the dst-ip route creates no duplicate", func(t *testing.T) {
+			err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+			require.NoError(t, err)
+			routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
+			require.NoError(t, err)
+			require.Len(t, routes, 1)
+		})
+	})
+}
+
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-routes-filters-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0)
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	// Create test routes with different external IDs
+	testData := []struct {
+		ipPrefix    string
+		nexthop     string
+		externalIDs map[string]string
+	}{
+		{"192.168.60.0/24", "192.168.60.1", map[string]string{"key1": "value1", "key2": "value2"}},
+		{"192.168.70.0/24", "192.168.70.1", map[string]string{"key1": "value1"}},
+		{"192.168.80.0/24", "192.168.80.1", map[string]string{"key2": "value2"}},
+		{"192.168.90.0/24", "192.168.90.1", map[string]string{}},
 	}
 
 	for _, data := range testData {
 		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, data.ipPrefix, data.nexthop, nil, nil)
 		require.NoError(t, err)
-		route.ExternalIDs = data.externalIDs
-		routes = append(routes, route)
+		route.ExternalIDs = data.externalIDs
+		routes = append(routes, route)
+	}
+
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, routes...)
+	require.NoError(t, err)
+
+	t.Run("filter by external IDs with multiple keys", func(t *testing.T) {
+		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", map[string]string{"key1": "value1", "key2": "value2"})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "192.168.60.0/24", result[0].IPPrefix)
+	})
+
+	t.Run("filter by external IDs key existence only", func(t *testing.T) {
+		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", map[string]string{"key1": ""})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+	})
+
+	t.Run("filter by route table and external IDs", func(t *testing.T) {
+		customTable := "custom"
+		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &customTable, nil, "", map[string]string{"key1": "value1"})
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("filter with non-matching external IDs", func(t *testing.T) {
+		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", map[string]string{"nonexistent": "value"})
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("filter with policy and external IDs", func(t *testing.T) {
+		srcPolicy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, &srcPolicy, "", map[string]string{"key1": "value1"})
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("filter with IP prefix and external IDs", func(t *testing.T) {
+		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "192.168.70.0/24", map[string]string{"key1": "value1"})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "192.168.70.0/24", result[0].IPPrefix)
+	})
+}
+
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutesWithoutPolicy() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-routes-without-policy-lr"
+	routeTable := util.MainRouteTable
+	dstPolicy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	srcPolicy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+	ipPrefix := "192.168.60.0/24"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	noPolicyRoute := &ovnnb.LogicalRouterStaticRoute{
+		UUID:        ovsclient.NamedUUID(),
+		IPPrefix:    ipPrefix,
+		Nexthop:     "192.168.60.1",
+		RouteTable:  routeTable,
+		ExternalIDs: map[string]string{"key": "no-policy"},
+	}
+	srcRoute, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, srcPolicy, ipPrefix, "192.168.60.2", nil, map[string]string{"key": "src-ip"})
+	require.NoError(t, err)
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, noPolicyRoute, srcRoute)
+	require.NoError(t, err)
+
+	customTable := "custom"
+	otherPrefix := "192.168.70.0/24"
+	for _, table := range []*string{nil, &routeTable, &customTable} {
+		for _, policy := range []*string{nil, &dstPolicy, &srcPolicy} {
+			for _, prefix := range []string{"", ipPrefix, otherPrefix} {
+				result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, table, policy, prefix, nil)
+				require.NoError(t, err)
+
+				expected := make([]string, 0, 2)
+				if (table == nil || *table == routeTable) && prefix != otherPrefix {
+					if policy == nil || *policy == dstPolicy {
+						expected = append(expected, "no-policy")
+					}
+					if policy == nil || *policy == srcPolicy {
+						expected = append(expected, "src-ip")
+					}
+				}
+				keys := make([]string, 0, len(result))
+				for _, route := range result {
+					keys = append(keys, route.ExternalIDs["key"])
+				}
+				require.ElementsMatch(t, expected, keys, "route table %q, policy %q, ip prefix %q", ptr.Deref(table, "<nil>"), ptr.Deref(policy, "<nil>"), prefix)
+			}
+		}
+	}
+}
+
+func (suite *OvnClientTestSuite) testNewLogicalRouterStaticRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-new-route-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefix := "192.168.30.0/24"
+	nexthop := "192.168.30.1"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	expect := &ovnnb.LogicalRouterStaticRoute{
+		Policy:   &policy,
+		IPPrefix: ipPrefix,
+		Nexthop:  nexthop,
+		BFD:      nil,
+	}
+
+	route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, nil)
+	require.NoError(t, err)
+	expect.UUID = route.UUID
+	require.Equal(t, expect, route)
+
+	t.Run("with custom options", func(t *testing.T) {
+		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+		ipPrefix := "192.168.100.0/24"
+		nexthop := "192.168.100.1"
+
+		customOption := func(route *ovnnb.LogicalRouterStaticRoute) {
+			route.Options = map[string]string{
+				"ecmp_symmetric_reply": "true",
+				"method":               "redirect",
+			}
+		}
+
+		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, nil, customOption)
+		require.NoError(t, err)
+		require.Equal(t, "true", route.Options["ecmp_symmetric_reply"])
+		require.Equal(t, "redirect", route.Options["method"])
+	})
+
+	t.Run("with multiple options and bfd", func(t *testing.T) {
+		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+		ipPrefix := "192.168.110.0/24"
+		nexthop := "192.168.110.1"
+		bfdID := "test-bfd"
+
+		option1 := func(route *ovnnb.LogicalRouterStaticRoute) {
+			route.ExternalIDs = map[string]string{"key1": "value1"}
+		}
+		option2 := func(route *ovnnb.LogicalRouterStaticRoute) {
+			if route.Options == nil {
+				route.Options = make(map[string]string)
+			}
+			route.Options["test"] = "value"
+		}
+
+		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, &bfdID, nil, option1, option2)
+		require.NoError(t, err)
+		require.Equal(t, "value1", route.ExternalIDs["key1"])
+		require.Equal(t, "value", route.Options["test"])
+		require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
+		require.Equal(t, &bfdID, route.BFD)
+	})
+
+	t.Run("with empty policy and existing route", func(t *testing.T) {
+		ipPrefix := "192.168.120.0/24"
+		nexthop := "192.168.120.1"
+
+		route1, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, "", ipPrefix, nexthop, nil, nil)
+		require.NoError(t, err)
+		err = nbClient.CreateLogicalRouterStaticRoutes(lrName, route1)
+		require.NoError(t, err)
+
+		route2, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, "", ipPrefix, nexthop, nil, nil)
+		require.Nil(t, route2)
+		require.NoError(t, err)
+	})
+
+	t.Run("with bfd but no options", func(t *testing.T) {
+		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+		ipPrefix := "192.168.130.0/24"
+		nexthop := "192.168.130.1"
+		bfdID := "test-bfd-2"
+
+		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, &bfdID, nil)
+		require.NoError(t, err)
+		require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
+		require.Equal(t, &bfdID, route.BFD)
+	})
+
+	t.Run("with mismatched nexthop family", func(t *testing.T) {
+		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, "192.168.140.0/24", "fd00:100:64::1", nil, nil)
+		require.ErrorContains(t, err, "does not match ip prefix")
+		require.Nil(t, route)
+
+		route, err = nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, "fd00:100:64::/64", "192.168.140.1", nil, nil)
+		require.ErrorContains(t, err, "does not match ip prefix")
+		require.Nil(t, route)
+
+		// a blackhole route has no nexthop
+		route, err = nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, "192.168.140.0/24", "", nil, nil)
+		require.NoError(t, err)
+		require.NotNil(t, route)
+	})
+
+	t.Run("with empty logical switch name", func(t *testing.T) {
+		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+		ipPrefix := "192.168.130.0/24"
+		nexthop := "192.168.130.1"
+		bfdID := "test-bfd-2"
+
+		route, err := nbClient.newLogicalRouterStaticRoute("", routeTable, policy, ipPrefix, nexthop, &bfdID, nil)
+		require.ErrorContains(t, err, "the logical router name is required")
+		require.Nil(t, route)
+	})
+}
+
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutesByOption() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-routes-by-option-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefixes := []string{"192.168.30.0/24", "192.168.40.0/24", "192.168.50.0/24"}
+	nexthops := []string{"192.168.30.1", "192.168.40.1", "192.168.50.1"}
+	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0, 5)
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	for i, ipPrefix := range ipPrefixes {
+		var bfdID *string
+		if ipPrefixes[i] == "192.168.30.0/24" {
+			bfdIDValue := "bfd-id"
+			bfdID = &bfdIDValue
+		}
+		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[i], bfdID, nil)
+		require.NoError(t, err)
+
+		routes = append(routes, route)
+	}
+
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, append(routes, nil)...)
+	require.NoError(t, err)
+
+	t.Run("match single route with option", func(t *testing.T) {
+		result, err := nbClient.ListLogicalRouterStaticRoutesByOption(lrName, "", util.StaticRouteBfdEcmp, "true")
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "192.168.30.0/24", result[0].IPPrefix)
+	})
+}
+
+func (suite *OvnClientTestSuite) testUpdateLogicalRouterStaticRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-update-route-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefix := "192.168.30.0/24"
+	nexthop := "192.168.30.1"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("update nil route", func(t *testing.T) {
+		err := nbClient.UpdateLogicalRouterStaticRoute(nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route is nil")
+	})
+
+	t.Run("update route fields", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+		require.NoError(t, err)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+		require.NoError(t, err)
+
+		newPolicy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+		route.Policy = &newPolicy
+		newNexthop := "192.168.30.254"
+		route.Nexthop = newNexthop
+
+		err = nbClient.UpdateLogicalRouterStaticRoute(route, &route.Policy, &route.Nexthop)
+		require.NoError(t, err)
+
+		updatedRoute, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, newPolicy, ipPrefix, newNexthop, false)
+		require.NoError(t, err)
+		require.Equal(t, newPolicy, *updatedRoute.Policy)
+		require.Equal(t, newNexthop, updatedRoute.Nexthop)
+	})
+
+	t.Run("force recreate route", func(t *testing.T) {
+		ipPrefix, nexthop := "192.168.31.0/24", "192.168.31.1"
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, map[string]string{"key": "value"}, nexthop)
+		require.NoError(t, err)
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+		require.NoError(t, err)
+
+		// fields other than policy and ip prefix are updated in place
+		route.Nexthop = "192.168.31.254"
+		err = nbClient.UpdateLogicalRouterStaticRouteForceRecreate(lrName, route, &route.Nexthop)
+		require.NoError(t, err)
+		updatedRoute, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, route.Nexthop, false)
+		require.NoError(t, err)
+		require.Equal(t, route.UUID, updatedRoute.UUID)
+
+		newIPPrefix := "192.168.32.0/24"
+		route.IPPrefix = newIPPrefix
+		err = nbClient.UpdateLogicalRouterStaticRouteForceRecreate(lrName, route, &route.IPPrefix)
+		require.NoError(t, err)
+
+		exists, err := nbClient.LogicalRouterStaticRouteExists(lrName, routeTable, policy, ipPrefix, route.Nexthop)
+		require.NoError(t, err)
+		require.False(t, exists)
+		recreatedRoute, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, newIPPrefix, route.Nexthop, false)
+		require.NoError(t, err)
+		require.NotEqual(t, route.UUID, recreatedRoute.UUID)
+		require.Equal(t, map[string]string{"key": "value"}, recreatedRoute.ExternalIDs)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Contains(t, lr.StaticRoutes, recreatedRoute.UUID)
+		require.NotContains(t, lr.StaticRoutes, route.UUID)
+
+		err = nbClient.UpdateLogicalRouterStaticRouteForceRecreate(lrName, nil)
+		require.ErrorContains(t, err, "route is nil")
+	})
+}
+
+func (suite *OvnClientTestSuite) testGetLogicalRouterStaticRouteEdgeCases() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-get-route-edge-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("empty logical router name", func(t *testing.T) {
+		_, err := nbClient.GetLogicalRouterStaticRoute("", routeTable, policy, "192.168.1.0/24", "192.168.1.1", false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the logical router name is required")
+	})
+
+	t.Run("duplicate routes", func(t *testing.T) {
+		ipPrefix := "192.168.2.0/24"
+		nexthop := "192.168.2.1"
+
+		route1, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, nil)
+		require.NoError(t, err)
+		route2, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, nil)
+		require.NoError(t, err)
+
+		err = nbClient.CreateLogicalRouterStaticRoutes(lrName, route1, route2)
+		require.NoError(t, err)
+
+		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "more than one static route")
+	})
+
+	t.Run("ignore not found true", func(t *testing.T) {
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "192.168.3.0/24", "192.168.3.1", true)
+		require.NoError(t, err)
+		require.Nil(t, route)
+	})
+
+	t.Run("empty route table", func(t *testing.T) {
+		ipPrefix := "192.168.4.0/24"
+		nexthop := "192.168.4.1"
+
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, "", policy, ipPrefix, nil, nil, nexthop)
+		require.NoError(t, err)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, "", policy, ipPrefix, nexthop, false)
+		require.NoError(t, err)
+		require.Equal(t, "", route.RouteTable)
+	})
+}
+
+func (suite *OvnClientTestSuite) testBatchDeleteLogicalRouterStaticRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-batch-del-route-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefix := "192.168.30.0/24"
+	nexthop := "192.168.30.1"
+	staticRouter := &ovnnb.LogicalRouterStaticRoute{
+		Policy:     &policy,
+		IPPrefix:   ipPrefix,
+		Nexthop:    nexthop,
+		RouteTable: routeTable,
+	}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("normal static route", func(t *testing.T) {
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+		require.NoError(t, err)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+		require.NoError(t, err)
+		require.Contains(t, lr.StaticRoutes, route.UUID)
+
+		notFound, err := nbClient.BatchDeleteLogicalRouterStaticRoute(lrName, []*ovnnb.LogicalRouterStaticRoute{staticRouter})
+		require.NoError(t, err)
+		require.Empty(t, notFound)
+
+		lr, err = nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, lr.StaticRoutes)
+
+		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+		require.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("delete non-exist static route", func(t *testing.T) {
+		staticRouter.IPPrefix = "192.168.40.0/24"
+		staticRouter.Nexthop = "192.168.40.1"
+		notFound, err := nbClient.BatchDeleteLogicalRouterStaticRoute(lrName, []*ovnnb.LogicalRouterStaticRoute{staticRouter})
+		require.NoError(t, err)
+		require.Equal(t, []*ovnnb.LogicalRouterStaticRoute{staticRouter}, notFound)
+	})
+
+	t.Run("delete ecmp policy route", func(t *testing.T) {
+		ipPrefix := "192.168.40.0/24"
+		nexthops := []string{"192.168.50.1", "192.168.60.1"}
+		staticRouter.IPPrefix = ipPrefix
+
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthops...)
+		require.NoError(t, err)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+
+		for _, nexthop := range nexthops {
+			route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+			require.NoError(t, err)
+			require.Contains(t, lr.StaticRoutes, route.UUID)
+		}
+
+		/* delete first route */
+		staticRouter.Nexthop = nexthops[0]
+		notFound, err := nbClient.BatchDeleteLogicalRouterStaticRoute(lrName, []*ovnnb.LogicalRouterStaticRoute{staticRouter})
+		require.NoError(t, err)
+		require.Empty(t, notFound)
+
+		lr, err = nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+
+		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[0], false)
+		require.ErrorContains(t, err, `not found logical router test-batch-del-route-lr static route 'policy dst-ip ip_prefix 192.168.40.0/24 nexthop 192.168.50.1'`)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[1], false)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{route.UUID}, lr.StaticRoutes)
+
+		/* delete second route */
+		staticRouter.Nexthop = nexthops[1]
+		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthops[1])
+		require.NoError(t, err)
+
+		lr, err = nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, lr.StaticRoutes)
+
+		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[1], false)
+		require.ErrorContains(t, err, `not found logical router test-batch-del-route-lr static route 'policy dst-ip ip_prefix 192.168.40.0/24 nexthop 192.168.60.1'`)
+	})
+
+	t.Run("delete partially existing static routes", func(t *testing.T) {
+		existing := []string{"192.168.70.0/24", "192.168.80.0/24"}
+		for _, ipPrefix := range existing {
+			err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, "192.168.0.1")
+			require.NoError(t, err)
+		}
+
+		toDel := []*ovnnb.LogicalRouterStaticRoute{
+			{Policy: &policy, IPPrefix: existing[0], Nexthop: "192.168.0.1", RouteTable: routeTable},
+			{Policy: &policy, IPPrefix: "192.168.90.0/24", Nexthop: "192.168.0.1", RouteTable: routeTable},
+			{Policy: &policy, IPPrefix: existing[1], RouteTable: routeTable},
+			{Policy: &policy, IPPrefix: existing[1], Nexthop: "192.168.0.254", RouteTable: routeTable},
+		}
+		notFound, err := nbClient.BatchDeleteLogicalRouterStaticRoute(lrName, toDel)
+		require.NoError(t, err)
+		require.Equal(t, []*ovnnb.LogicalRouterStaticRoute{toDel[1], toDel[3]}, notFound)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, lr.StaticRoutes)
+	})
+
+	t.Run("delete static routes without policy", func(t *testing.T) {
+		ipPrefixes := []string{"192.168.100.0/24", "192.168.101.0/24"}
+		for _, ipPrefix := range ipPrefixes {
+			err = nbClient.CreateLogicalRouterStaticRoutes(lrName, &ovnnb.LogicalRouterStaticRoute{
+				UUID:       ovsclient.NamedUUID(),
+				IPPrefix:   ipPrefix,
+				Nexthop:    "192.168.0.1",
+				RouteTable: routeTable,
+			})
+			require.NoError(t, err)
+		}
+
+		toDel := []*ovnnb.LogicalRouterStaticRoute{
+			{IPPrefix: ipPrefixes[0], Nexthop: "192.168.0.1", RouteTable: routeTable},
+			{Policy: &policy, IPPrefix: ipPrefixes[1], RouteTable: routeTable},
+			{IPPrefix: "192.168.102.0/24", RouteTable: routeTable},
+		}
+		notFound, err := nbClient.BatchDeleteLogicalRouterStaticRoute(lrName, toDel)
+		require.NoError(t, err)
+		require.Equal(t, []*ovnnb.LogicalRouterStaticRoute{toDel[2]}, notFound)
+		require.Nil(t, toDel[0].Policy)
+
+		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, lr.StaticRoutes)
+	})
+
+	t.Run("delete static route for non-exist logical router", func(t *testing.T) {
+		notFound, err := nbClient.BatchDeleteLogicalRouterStaticRoute("non-exist-lrName", []*ovnnb.LogicalRouterStaticRoute{staticRouter})
+		require.NoError(t, err)
+		require.Equal(t, []*ovnnb.LogicalRouterStaticRoute{staticRouter}, notFound)
+	})
+}
+
+func TestStaticRouteCountWarner(t *testing.T) {
+	lrName := "test-route-count-warner-lr"
+	now := time.Now()
+	warner := NewStaticRouteCountWarner(2, time.Minute)
+	warner.now = func() time.Time { return now }
+
+	require.False(t, warner.Check(lrName, 2))
+	require.Zero(t, testutil.ToFloat64(ovsClientStaticRouteThresholdExceeded.WithLabelValues(lrName)))
+
+	require.True(t, warner.Check(lrName, 3))
+	require.Equal(t, float64(1), testutil.ToFloat64(ovsClientStaticRouteThresholdExceeded.WithLabelValues(lrName)))
+
+	// rate limited
+	now = now.Add(30 * time.Second)
+	require.False(t, warner.Check(lrName, 4))
+	require.Equal(t, float64(2), testutil.ToFloat64(ovsClientStaticRouteThresholdExceeded.WithLabelValues(lrName)))
+	require.True(t, warner.Check(lrName+"-other", 3))
+
+	now = now.Add(30 * time.Second)
+	require.True(t, warner.Check(lrName, 4))
+	require.Equal(t, float64(3), testutil.ToFloat64(ovsClientStaticRouteThresholdExceeded.WithLabelValues(lrName)))
+}
+
+func (suite *OvnClientTestSuite) testChunkLogicalRouterStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-chunk-routes-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	externalIDs := map[string]string{"chunk": "true"}
+
+	client := *nbClient
+	client.MaxStaticRoutesPerTransaction = 2
+
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	newRoutes := func(prefixes ...string) []*ovnnb.LogicalRouterStaticRoute {
+		routes := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(prefixes))
+		for _, prefix := range prefixes {
+			route, err := client.newLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.1", nil, externalIDs)
+			require.NoError(t, err)
+			routes = append(routes, route)
+		}
+		return routes
+	}
+
+	t.Run("create routes in chunks", func(t *testing.T) {
+		prefixes := []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24", "10.0.4.0/24"}
+		err := client.CreateLogicalRouterStaticRoutes(lrName, newRoutes(prefixes...)...)
+		require.NoError(t, err)
+
+		lr, err := client.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Len(t, lr.StaticRoutes, len(prefixes))
+	})
+
+	t.Run("report the failed chunk", func(t *testing.T) {
+		routes := newRoutes("10.1.0.0/24", "10.1.1.0/24", "10.1.2.0/24", "10.1.3.0/24", "10.1.4.0/24")
+		// duplicate named uuids fail the second chunk
+		routes[2].UUID = routes[3].UUID
+		err := client.CreateLogicalRouterStaticRoutes(lrName, routes...)
+		require.ErrorContains(t, err, "chunk 2/3")
+
+		// the chunks before the failed one are committed
+		for _, prefix := range []string{"10.1.0.0/24", "10.1.1.0/24"} {
+			exists, err := client.LogicalRouterStaticRouteExists(lrName, routeTable, policy, prefix, "192.168.0.1")
+			require.NoError(t, err)
+			require.True(t, exists)
+		}
+		for _, prefix := range []string{"10.1.2.0/24", "10.1.3.0/24", "10.1.4.0/24"} {
+			exists, err := client.LogicalRouterStaticRouteExists(lrName, routeTable, policy, prefix, "192.168.0.1")
+			require.NoError(t, err)
+			require.False(t, exists)
+		}
+	})
+
+	t.Run("delete routes in chunks", func(t *testing.T) {
+		err := client.DeleteLogicalRouterStaticRouteByExternalIDs(lrName, externalIDs)
+		require.NoError(t, err)
+
+		lr, err := client.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, lr.StaticRoutes)
+	})
+
+	t.Run("routes within the limit are not chunked", func(t *testing.T) {
+		require.Equal(t, 2, client.staticRouteChunkSize(5))
+		require.Equal(t, 1, client.staticRouteChunkSize(1))
+		require.Equal(t, 5, nbClient.staticRouteChunkSize(5))
+		require.Equal(t, 3, staticRouteChunkCount(5, 2))
+		require.Equal(t, 1, staticRouteChunkCount(2, 2))
+	})
+}
+
+func (suite *OvnClientTestSuite) testDisableEnableStaticRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-disable-enable-route-lr"
+	routeTable := "rtb1"
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	prefix := "10.0.0.0/24"
+	externalIDs := map[string]string{ExternalIDVendor: util.CniTypeName}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, externalIDs, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+
+	original, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.1", false)
+	require.NoError(t, err)
+
+	t.Run("disable route", func(t *testing.T) {
+		err := nbClient.DisableStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.1")
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Equal(t, "192.168.0.2", routes[0].Nexthop)
+
+		disabled, err := nbClient.GetLogicalRouterStaticRoute(lrName, util.StaticRouteDisabledRouteTable, policy, prefix, "192.168.0.1", false)
+		require.NoError(t, err)
+		require.Equal(t, original.UUID, disabled.UUID)
+		require.Equal(t, routeTable, disabled.ExternalIDs[ExternalIDDisabledRouteTable])
+
+		err = nbClient.DisableStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.1")
+		require.Error(t, err)
+		err = nbClient.DisableStaticRoute(lrName, util.StaticRouteDisabledRouteTable, policy, prefix, "192.168.0.1")
+		require.ErrorContains(t, err, "is reserved")
+	})
+
+	t.Run("enable route", func(t *testing.T) {
+		err := nbClient.EnableStaticRoute(lrName, util.MainRouteTable, policy, prefix, "192.168.0.1")
+		require.ErrorContains(t, err, "not found disabled static route")
+
+		err = nbClient.EnableStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.1")
+		require.NoError(t, err)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.1", false)
+		require.NoError(t, err)
+		require.Equal(t, original, route)
+
+		err = nbClient.EnableStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.1")
+		require.Error(t, err)
+	})
+
+	t.Run("enable route added again while disabled", func(t *testing.T) {
+		err := nbClient.DisableStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.2")
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, prefix, nil, nil, "192.168.0.2")
+		require.ErrorIs(t, err, ErrStaticRouteNexthopConflict)
+
+		err = nbClient.EnableStaticRoute(lrName, routeTable, policy, prefix, "192.168.0.2")
+		require.ErrorContains(t, err, "while disabled")
+	})
+}
+
+func (suite *OvnClientTestSuite) testSummarizeStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-summarize-routes-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	nexthop := "192.168.0.1"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	for i, prefix := range []string{"10.0.0.0/32", "10.0.0.1", "10.0.0.2/32", "10.0.0.3/32", "10.0.0.4/32"} {
+		externalIDs := map[string]string{ExternalIDVendor: util.CniTypeName, "index": strconv.Itoa(i)}
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, externalIDs, nexthop)
+		require.NoError(t, err)
+	}
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/32", nil, nil, "192.168.0.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.2.0/30", nil, nil, "192.168.0.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.2.1/32", nil, nil, nexthop)
+	require.NoError(t, err)
+
+	t.Run("summarize four host routes into a /30", func(t *testing.T) {
+		err := nbClient.SummarizeStaticRoutes(lrName, routeTable, nexthop, "10.0.0.0/30")
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "", nil)
+		require.NoError(t, err)
+		prefixes := make([]string, 0, len(routes))
+		for _, route := range routes {
+			prefixes = append(prefixes, route.IPPrefix)
+		}
+		require.ElementsMatch(t, []string{"10.0.0.0/30", "10.0.0.4/32", "10.0.1.0/32", "10.0.2.0/30", "10.0.2.1/32"}, prefixes)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/30", nexthop, false)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{ExternalIDVendor: util.CniTypeName}, route.ExternalIDs)
+	})
+
+	t.Run("host route to another nexthop", func(t *testing.T) {
+		err := nbClient.SummarizeStaticRoutes(lrName, routeTable, nexthop, "10.0.1.0/30")
+		require.ErrorContains(t, err, "other than")
+	})
+
+	t.Run("aggregate route to another nexthop", func(t *testing.T) {
+		err := nbClient.SummarizeStaticRoutes(lrName, routeTable, nexthop, "10.0.2.0/30")
+		require.ErrorContains(t, err, "conflicts with")
+
+		exists, err := nbClient.LogicalRouterStaticRouteExists(lrName, routeTable, policy, "10.0.2.1/32", nexthop)
+		require.NoError(t, err)
+		require.True(t, exists)
+	})
+
+	t.Run("no host route", func(t *testing.T) {
+		err := nbClient.SummarizeStaticRoutes(lrName, routeTable, nexthop, "10.0.3.0/30")
+		require.ErrorContains(t, err, "no host route")
+	})
+
+	t.Run("invalid aggregate prefix", func(t *testing.T) {
+		err := nbClient.SummarizeStaticRoutes(lrName, routeTable, nexthop, "10.0.3.0")
+		require.ErrorContains(t, err, "invalid aggregate prefix")
+	})
+}
+
+func (suite *OvnClientTestSuite) testReconcileRoutersStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	lrNamePrefix := "test-reconcile-routers-routes-lr"
+
+	specs := make(map[string][]*ovnnb.LogicalRouterStaticRoute)
+	for i := range 10 {
+		lrName := fmt.Sprintf("%s-%d", lrNamePrefix, i)
+		err := nbClient.CreateLogicalRouter(lrName)
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, nil, "192.168.1.1")
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.1.0.0/24", nil, nil, "192.168.1.1")
+		require.NoError(t, err)
+
+		nexthop := fmt.Sprintf("192.168.0.%d", i+1)
+		specs[lrName] = []*ovnnb.LogicalRouterStaticRoute{
+			{Policy: &policy, IPPrefix: "10.0.0.0/24", Nexthop: nexthop},
+			{Policy: &policy, IPPrefix: "10.2.0.0/24", Nexthop: nexthop, ExternalIDs: map[string]string{ExternalIDVendor: util.CniTypeName}},
+			nil,
+		}
+	}
+	nonExistentLR := lrNamePrefix + "-non-existent"
+	specs[nonExistentLR] = []*ovnnb.LogicalRouterStaticRoute{{Policy: &policy, IPPrefix: "10.0.0.0/24", Nexthop: "192.168.0.1"}}
+
+	results, err := nbClient.ReconcileRoutersStaticRoutes(specs)
+	require.ErrorContains(t, err, nonExistentLR)
+	require.Len(t, results, len(specs))
+	require.Error(t, results[nonExistentLR])
+
+	for i := range 10 {
+		lrName := fmt.Sprintf("%s-%d", lrNamePrefix, i)
+		require.NoError(t, results[lrName])
+
+		nexthop := fmt.Sprintf("192.168.0.%d", i+1)
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "", nil)
+		require.NoError(t, err)
+		actual := make([]string, 0, len(routes))
+		for _, route := range routes {
+			actual = append(actual, route.IPPrefix+"-"+route.Nexthop)
+		}
+		require.ElementsMatch(t, []string{"10.0.0.0/24-" + nexthop, "10.1.0.0/24-192.168.1.1", "10.2.0.0/24-" + nexthop}, actual)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.2.0.0/24", nexthop, false)
+		require.NoError(t, err)
+		require.Equal(t, util.CniTypeName, route.ExternalIDs[ExternalIDVendor])
+	}
+
+	// reconciling again is a no-op
+	delete(specs, nonExistentLR)
+	results, err = nbClient.ReconcileRoutersStaticRoutes(specs)
+	require.NoError(t, err)
+	require.Len(t, results, 10)
+
+	results, err = nbClient.ReconcileRoutersStaticRoutes(nil)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func (suite *OvnClientTestSuite) testFindStaticRouteNatConflicts() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-find-route-nat-conflicts-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	tagKey := "lb-vip"
+	tagged := map[string]string{tagKey: "true"}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	conflicts, err := nbClient.FindStaticRouteNatConflicts(lrName, tagKey)
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, tagged, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.1.0.10", nil, tagged, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "172.16.0.0/24", nil, tagged, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.2.0.0/24", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+
+	err = nbClient.AddNat(lrName, ovnnb.NATTypeSNAT, "192.168.100.10", "10.0.0.0/16", "", "", nil)
+	require.NoError(t, err)
+	err = nbClient.AddNat(lrName, ovnnb.NATTypeDNATAndSNAT, "192.168.100.11", "10.1.0.10", "", "", nil)
+	require.NoError(t, err)
+	err = nbClient.AddNat(lrName, ovnnb.NATTypeSNAT, "192.168.100.12", "10.2.0.0/24", "", "", nil)
+	require.NoError(t, err)
+
+	conflicts, err = nbClient.FindStaticRouteNatConflicts(lrName, tagKey)
+	require.NoError(t, err)
+	actual := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		actual = append(actual, conflict.Route.IPPrefix+" "+conflict.Nat.Type+" "+conflict.Nat.LogicalIP)
+	}
+	require.Equal(t, []string{"10.0.0.0/24 snat 10.0.0.0/16", "10.1.0.10 dnat_and_snat 10.1.0.10"}, actual)
+
+	conflicts, err = nbClient.FindStaticRouteNatConflicts(lrName, "other-tag")
+	require.NoError(t, err)
+	require.Empty(t, conflicts)
+
+	_, err = nbClient.FindStaticRouteNatConflicts(lrName, "")
+	require.ErrorContains(t, err, "tag key is required")
+
+	_, err = nbClient.FindStaticRouteNatConflicts("test-find-route-nat-conflicts-non-existent-lr", tagKey)
+	require.Error(t, err)
+}
+
+func (suite *OvnClientTestSuite) testDeterministicStaticRouteUUIDs() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-deterministic-route-uuids-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	client := *nbClient
+	client.deterministicStaticRouteUUIDs = true
+
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	newRoute := func(prefix, nexthop string) *ovnnb.LogicalRouterStaticRoute {
+		route, err := client.newLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nexthop, nil, nil)
+		require.NoError(t, err)
+		require.NotNil(t, route)
+		return route
+	}
+	routes := []*ovnnb.LogicalRouterStaticRoute{
+		newRoute("10.0.0.0/24", "192.168.0.1"),
+		newRoute("10.0.0.0/24", "192.168.0.2"),
+		newRoute("10.0.1.0/24", "192.168.0.1"),
+	}
+	require.Equal(t, routes[0].UUID, newRoute("10.0.0.0/24", "192.168.0.1").UUID)
+	require.NotEqual(t, routes[0].UUID, routes[1].UUID)
+	require.Equal(t, staticRouteNamedUUID(routeTable, policy, "10.0.0.0/24", "192.168.0.1"), routes[0].UUID)
+
+	t.Run("duplicate routes in one create", func(t *testing.T) {
+		err := client.CreateLogicalRouterStaticRoutes(lrName, routes[0], newRoute("10.0.0.0/24", "192.168.0.1"))
+		require.NoError(t, err)
+
+		lr, err := client.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Len(t, lr.StaticRoutes, 1)
+	})
+
+	t.Run("retried create", func(t *testing.T) {
+		err := client.CreateLogicalRouterStaticRoutes(lrName, routes...)
+		require.NoError(t, err)
+		err = client.CreateLogicalRouterStaticRoutes(lrName, routes...)
+		require.NoError(t, err)
+
+		lr, err := client.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		require.Len(t, lr.StaticRoutes, len(routes))
+	})
+
+	t.Run("same routes of another router", func(t *testing.T) {
+		otherLrName := "test-deterministic-route-uuids-other-lr"
+		err := client.CreateLogicalRouter(otherLrName)
+		require.NoError(t, err)
+		// the routes of the router are not taken as the existing ones of the other router
+		route, err := client.newLogicalRouterStaticRoute(otherLrName, routeTable, policy, "10.0.0.0/24", "192.168.0.1", nil, nil)
+		require.NoError(t, err)
+		err = client.CreateLogicalRouterStaticRoutes(otherLrName, route)
+		require.NoError(t, err)
+
+		lr, err := client.GetLogicalRouter(otherLrName, false)
+		require.NoError(t, err)
+		require.Len(t, lr.StaticRoutes, 1)
+	})
+}
+
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutesByBFDState() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-routes-by-bfd-state-lr"
+	lrpName := "test-list-routes-by-bfd-state-lrp"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	statuses := map[string]ovnnb.BFDStatus{
+		"192.168.0.1": ovnnb.BFDStatusUp,
+		"192.168.0.2": ovnnb.BFDStatusDown,
+		"192.168.0.3": ovnnb.BFDStatusDown,
+		"192.168.0.4": ovnnb.BFDStatusAdminDown,
+	}
+	for nexthop, status := range statuses {
+		bfd, err := nbClient.CreateBFD(lrpName, nexthop, 100, 100, 3, nil)
+		require.NoError(t, err)
+		bfd.Status = &status
+		err = nbClient.UpdateBFD(bfd, &bfd.Status)
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", &bfd.UUID, nil, nexthop)
+		require.NoError(t, err)
+	}
+	// routes without bfd are excluded
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, nil, "192.168.0.2")
+	require.NoError(t, err)
+
+	nexthops := func(routes []*ovnnb.LogicalRouterStaticRoute) []string {
+		result := make([]string, 0, len(routes))
+		for _, route := range routes {
+			result = append(result, route.IPPrefix+"-"+route.Nexthop)
+		}
+		return result
+	}
+
+	routes, err := nbClient.ListLogicalRouterStaticRoutesByBFDState(lrName, "Down")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"10.0.0.0/24-192.168.0.2", "10.0.0.0/24-192.168.0.3"}, nexthops(routes))
+
+	routes, err = nbClient.ListLogicalRouterStaticRoutesByBFDState(lrName, "Up")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"10.0.0.0/24-192.168.0.1"}, nexthops(routes))
+
+	routes, err = nbClient.ListLogicalRouterStaticRoutesByBFDState(lrName, "AdminDown")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"10.0.0.0/24-192.168.0.4"}, nexthops(routes))
+
+	routes, err = nbClient.ListLogicalRouterStaticRoutesByBFDState(lrName, ovnnb.BFDStatusAdminDown)
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+
+	routes, err = nbClient.ListLogicalRouterStaticRoutesByBFDState(lrName, "init")
+	require.NoError(t, err)
+	require.Empty(t, routes)
+
+	_, err = nbClient.ListLogicalRouterStaticRoutesByBFDState(lrName, "unknown")
+	require.ErrorContains(t, err, "invalid bfd state")
+}
+
+func (suite *OvnClientTestSuite) testSingleNexthopBFDWithoutEcmp() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-single-nexthop-bfd-without-ecmp-lr"
+	lrpName := "test-single-nexthop-bfd-without-ecmp-lrp"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	client := *nbClient
+	client.singleNexthopBFDWithoutEcmp = true
+
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	bfdIDs := make(map[string]string)
+	for _, nexthop := range []string{"192.168.0.1", "192.168.0.2"} {
+		bfd, err := client.CreateBFD(lrpName, nexthop, 100, 100, 3, nil)
+		require.NoError(t, err)
+		bfdIDs[nexthop] = bfd.UUID
+	}
+	bfdID := bfdIDs["192.168.0.1"]
+
+	t.Run("single nexthop route", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", &bfdID, nil, "192.168.0.1")
+		require.NoError(t, err)
+		route, err := client.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", "192.168.0.1", false)
+		require.NoError(t, err)
+		require.Equal(t, bfdID, *route.BFD)
+		require.NotContains(t, route.Options, util.StaticRouteBfdEcmp)
+
+		routes, err := client.FindInconsistentBFDRoutes(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, routes)
+	})
+
+	t.Run("ecmp routes", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, "10.0.1.0/24", bfdIDs, nil)
+		require.NoError(t, err)
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "10.0.1.0/24", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		for _, route := range routes {
+			require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
+		}
+	})
+
+	t.Run("default behavior", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.2.0/24", &bfdID, nil, "192.168.0.1")
+		require.NoError(t, err)
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.2.0/24", "192.168.0.1", false)
+		require.NoError(t, err)
+		require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
+
+		// single nexthop bfd routes without the option are inconsistent by default
+		routes, err := nbClient.FindInconsistentBFDRoutes(lrName, false)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Equal(t, "10.0.0.0/24", routes[0].IPPrefix)
+	})
+}
+
+func (suite *OvnClientTestSuite) testEnableBFDForECMPGroup() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-enable-bfd-for-ecmp-group-lr"
+	lrpName := "test-enable-bfd-for-ecmp-group-lrp"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	prefix := "10.0.0.0/24"
+	nexthops := []string{"192.168.0.1", "192.168.0.2", "192.168.0.3"}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, nil, nexthops...)
+	require.NoError(t, err)
+	routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+	require.NoError(t, err)
+	require.Len(t, routes, len(nexthops))
+	uuids := make(map[string]string, len(routes))
+	for _, route := range routes {
+		uuids[route.Nexthop] = route.UUID
+	}
+
+	nexthopBFD := make(map[string]string, len(nexthops))
+	for _, nexthop := range nexthops {
+		bfd, err := nbClient.CreateBFD(lrpName, nexthop, 100, 100, 3, nil)
+		require.NoError(t, err)
+		nexthopBFD[nexthop] = bfd.UUID
+	}
+
+	t.Run("missing bfd of a nexthop", func(t *testing.T) {
+		err := nbClient.EnableBFDForECMPGroup(lrName, routeTable, policy, prefix, map[string]string{"192.168.0.1": nexthopBFD["192.168.0.1"]})
+		require.ErrorContains(t, err, "no bfd is specified for nexthop")
+	})
+
+	t.Run("nexthop not in the group", func(t *testing.T) {
+		bfds := maps.Clone(nexthopBFD)
+		bfds["192.168.0.4"] = nexthopBFD["192.168.0.1"]
+		err := nbClient.EnableBFDForECMPGroup(lrName, routeTable, policy, prefix, bfds)
+		require.ErrorContains(t, err, "is not in the ecmp group")
+	})
+
+	t.Run("non-existent group", func(t *testing.T) {
+		err := nbClient.EnableBFDForECMPGroup(lrName, routeTable, policy, "10.0.1.0/24", nexthopBFD)
+		require.ErrorContains(t, err, "no static route")
+	})
+
+	t.Run("enable bfd for all nexthops", func(t *testing.T) {
+		err := nbClient.EnableBFDForECMPGroup(lrName, routeTable, policy, prefix, nexthopBFD)
+		require.NoError(t, err)
+		// enabling again is a no-op
+		err = nbClient.EnableBFDForECMPGroup(lrName, routeTable, policy, prefix, nexthopBFD)
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+		require.NoError(t, err)
+		require.Len(t, routes, len(nexthops))
+		for _, route := range routes {
+			// the routes are updated in place
+			require.Equal(t, uuids[route.Nexthop], route.UUID)
+			require.NotNil(t, route.BFD)
+			require.Equal(t, nexthopBFD[route.Nexthop], *route.BFD)
+			require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
+		}
+
+		inconsistent, err := nbClient.FindInconsistentBFDRoutes(lrName, false)
+		require.NoError(t, err)
+		require.Empty(t, inconsistent)
+	})
+}
+
+func (suite *OvnClientTestSuite) testStaticRouteTransactHooks() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-transact-hooks-route-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	prefix := "10.0.0.0/24"
+	nexthops := []string{"192.168.0.1", "192.168.0.2"}
+
+	type hookCall struct {
+		method   string
+		nexthops []string
+	}
+	var preCalls, postCalls []hookCall
+	record := func(calls *[]hookCall) StaticRouteTransactHook {
+		return func(method string, routes []*ovnnb.LogicalRouterStaticRoute) {
+			call := hookCall{method: method}
+			for _, route := range routes {
+				require.Equal(t, prefix, route.IPPrefix)
+				call.nexthops = append(call.nexthops, route.Nexthop)
+			}
+			slices.Sort(call.nexthops)
+			*calls = append(*calls, call)
+		}
+	}
+	// uuids of the routes passed to the post hook
+	var postUUIDs []string
+	client := *nbClient
+	client.preTransact = record(&preCalls)
+	client.postTransact = func(method string, routes []*ovnnb.LogicalRouterStaticRoute) {
+		postUUIDs = nil
+		for _, route := range routes {
+			postUUIDs = append(postUUIDs, route.UUID)
+		}
+		record(&postCalls)(method, routes)
+	}
+
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("add static routes", func(t *testing.T) {
+		preCalls, postCalls = nil, nil
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, nil, nexthops...)
+		require.NoError(t, err)
+		expected := []hookCall{{method: "lr-routes-add", nexthops: nexthops}}
+		require.Equal(t, expected, preCalls)
+		require.Equal(t, expected, postCalls)
+
+		// the post hook is called with the committed routes, whose uuids are the ones of the inserted rows
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		require.ElementsMatch(t, []string{routes[0].UUID, routes[1].UUID}, postUUIDs)
+	})
+
+	t.Run("delete static route", func(t *testing.T) {
+		preCalls, postCalls = nil, nil
+		err := client.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, prefix, nexthops[0])
+		require.NoError(t, err)
+		expected := []hookCall{{method: "lr-route-del", nexthops: nexthops[:1]}}
+		require.Equal(t, expected, preCalls)
+		require.Equal(t, expected, postCalls)
+	})
+
+	t.Run("nil hooks", func(t *testing.T) {
+		preCalls, postCalls = nil, nil
+		lrName := "test-transact-nil-hooks-route-lr"
+		err := nbClient.CreateLogicalRouter(lrName)
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, nil, nexthops...)
+		require.NoError(t, err)
+		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, prefix, "")
+		require.NoError(t, err)
+		require.Empty(t, preCalls)
+		require.Empty(t, postCalls)
+	})
+}
+
+func (suite *OvnClientTestSuite) testNamedTableDefaultRouteCheck() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-named-table-default-route-lr"
+	routeTable := "custom"
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("reject default routes in named route table", func(t *testing.T) {
+		client := *nbClient
+		client.namedTableDefaultRouteCheck = NamedTableDefaultRouteReject
+
+		for _, prefix := range []string{"0.0.0.0/0", "::/0"} {
+			err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, nil, "192.168.0.1")
+			require.ErrorIs(t, err, ErrDefaultRouteInNamedTable)
+			routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+			require.NoError(t, err)
+			require.Empty(t, routes)
+		}
+
+		// default route in the main route table and other routes in the named route table are not affected
+		err := client.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, policy, "0.0.0.0/0", nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+		err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+	})
+
+	t.Run("warn default routes in named route table", func(t *testing.T) {
+		client := *nbClient
+		client.namedTableDefaultRouteCheck = NamedTableDefaultRouteWarn
+
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "0.0.0.0/0", nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "0.0.0.0/0", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+	})
+}
+
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutesByExternalIDsAndOptions() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-routes-by-external-ids-and-options-lr"
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	bfdEcmp := map[string]string{util.StaticRouteBfdEcmp: "true"}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	newRoute := func(ipPrefix, vendor string, options map[string]string) *ovnnb.LogicalRouterStaticRoute {
+		route := &ovnnb.LogicalRouterStaticRoute{
+			UUID:       ovsclient.NamedUUID(),
+			Policy:     &dstIP,
+			RouteTable: util.MainRouteTable,
+			IPPrefix:   ipPrefix,
+			Nexthop:    "192.168.0.1",
+			Options:    options,
+		}
+		if vendor != "" {
+			route.ExternalIDs = map[string]string{"vendor": vendor}
+		}
+		return route
+	}
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName,
+		newRoute("10.0.0.0/24", util.CniTypeName, bfdEcmp),
+		newRoute("10.0.1.0/24", util.CniTypeName, nil),
+		newRoute("10.0.2.0/24", "other", bfdEcmp),
+		newRoute("10.0.3.0/24", "", bfdEcmp),
+	)
+	require.NoError(t, err)
+
+	prefixes := func(externalIDs, options map[string]string) []string {
+		routes, err := nbClient.ListLogicalRouterStaticRoutesByExternalIDsAndOptions(lrName, externalIDs, options)
+		require.NoError(t, err)
+		result := make([]string, 0, len(routes))
+		for _, route := range routes {
+			result = append(result, route.IPPrefix)
+		}
+		return result
+	}
+
+	t.Run("match both external ids and options", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"10.0.0.0/24"}, prefixes(map[string]string{"vendor": util.CniTypeName}, bfdEcmp))
+	})
+
+	t.Run("empty value matches any value of the key", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"10.0.0.0/24", "10.0.2.0/24"}, prefixes(map[string]string{"vendor": ""}, bfdEcmp))
+	})
+
+	t.Run("nil filters match all", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"10.0.0.0/24", "10.0.1.0/24"}, prefixes(map[string]string{"vendor": util.CniTypeName}, nil))
+		require.ElementsMatch(t, []string{"10.0.0.0/24", "10.0.2.0/24", "10.0.3.0/24"}, prefixes(nil, bfdEcmp))
+		require.Len(t, prefixes(nil, nil), 4)
+	})
+
+	t.Run("non-existent logical router", func(t *testing.T) {
+		_, err := nbClient.ListLogicalRouterStaticRoutesByExternalIDsAndOptions("test-list-routes-by-external-ids-and-options-non-existent-lr", nil, nil)
+		require.Error(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutesOverlapping() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-routes-overlapping-lr"
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	prefixes := []string{"10.0.1.0/24", "10.0.0.0/8", "10.0.2.1", "10.1.0.0/16", "0.0.0.0/0", "fd00::/64", "fd00:1::/48", "invalid"}
+	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		routes = append(routes, &ovnnb.LogicalRouterStaticRoute{
+			UUID:       ovsclient.NamedUUID(),
+			Policy:     &dstIP,
+			RouteTable: util.MainRouteTable,
+			IPPrefix:   prefix,
+			Nexthop:    "192.168.0.1",
+		})
+	}
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, routes...)
+	require.NoError(t, err)
+
+	overlapping := func(cidr string) []string {
+		routes, err := nbClient.ListLogicalRouterStaticRoutesOverlapping(lrName, cidr)
+		require.NoError(t, err)
+		result := make([]string, 0, len(routes))
+		for _, route := range routes {
+			result = append(result, route.IPPrefix)
+		}
+		return result
+	}
+
+	t.Run("ipv4", func(t *testing.T) {
+		// more specific and less specific prefixes are both included
+		require.ElementsMatch(t, []string{"10.0.1.0/24", "10.0.0.0/8", "10.0.2.1", "0.0.0.0/0"}, overlapping("10.0.0.0/16"))
+		require.ElementsMatch(t, []string{"10.0.0.0/8", "0.0.0.0/0"}, overlapping("10.2.0.0/16"))
+		// the cidr is masked before matching
+		require.ElementsMatch(t, []string{"10.0.1.0/24", "10.0.0.0/8", "10.0.2.1", "0.0.0.0/0"}, overlapping("10.0.3.4/16"))
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"fd00::/64"}, overlapping("fd00::/56"))
+		require.Empty(t, overlapping("fd01::/16"))
+	})
+
+	t.Run("invalid cidr", func(t *testing.T) {
+		_, err := nbClient.ListLogicalRouterStaticRoutesOverlapping(lrName, "10.0.0.0")
+		require.Error(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testMaxStaticRoutesPerRouter() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-max-static-routes-per-router-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	client := *nbClient
+	client.maxStaticRoutesPerRouter = 3
+
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+
+	t.Run("reject adding routes beyond the limit", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, nil, "192.168.0.1", "192.168.0.2")
+		require.ErrorIs(t, err, ErrStaticRouteLimitExceeded)
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "10.0.1.0/24", nil)
+		require.NoError(t, err)
+		require.Empty(t, routes)
+
+		err = client.CreateLogicalRouterStaticRoutes(lrName,
+			&ovnnb.LogicalRouterStaticRoute{UUID: ovsclient.NamedUUID(), Policy: &policy, IPPrefix: "10.0.2.0/24", Nexthop: "192.168.0.1"},
+			&ovnnb.LogicalRouterStaticRoute{UUID: ovsclient.NamedUUID(), Policy: &policy, IPPrefix: "10.0.3.0/24", Nexthop: "192.168.0.1"},
+		)
+		require.ErrorIs(t, err, ErrStaticRouteLimitExceeded)
+	})
+
+	t.Run("add routes up to the limit", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 3)
+
+		// replacing the nexthop of a route does not increase the route count
+		err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, nil, "192.168.0.3")
+		require.NoError(t, err)
+	})
+
+	t.Run("reject applying desired routes beyond the limit", func(t *testing.T) {
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		desired := slices.Clone(routes)
+		desired = append(desired, &ovnnb.LogicalRouterStaticRoute{Policy: &policy, IPPrefix: "10.0.4.0/24", Nexthop: "192.168.0.1"})
+		err = client.ApplyDesiredStaticRoutes(lrName, desired)
+		require.ErrorIs(t, err, ErrStaticRouteLimitExceeded)
+		routes, err = client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "10.0.4.0/24", nil)
+		require.NoError(t, err)
+		require.Empty(t, routes)
+
+		// replacing a route keeps the route count
+		desired[0] = &ovnnb.LogicalRouterStaticRoute{Policy: &policy, IPPrefix: "10.0.4.0/24", Nexthop: "192.168.0.1"}
+		err = client.ApplyDesiredStaticRoutes(lrName, desired)
+		require.NoError(t, err)
+		routes, err = client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 3)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.2.0/24", nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testSetECMPHashMode() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-set-ecmp-hash-mode-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	prefix := "10.0.0.0/24"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+
+	listPrefixes := func(mode string) []string {
+		routes, err := nbClient.ListLogicalRouterStaticRoutesByECMPHashMode(lrName, mode)
+		require.NoError(t, err)
+		prefixes := make([]string, 0, len(routes))
+		for _, route := range routes {
+			prefixes = append(prefixes, route.IPPrefix)
+		}
+		return prefixes
+	}
+
+	t.Run("invalid mode", func(t *testing.T) {
+		err := nbClient.SetECMPHashMode(lrName, routeTable, policy, prefix, "l7")
+		require.ErrorContains(t, err, "invalid ecmp hash mode")
+		_, err = nbClient.ListLogicalRouterStaticRoutesByECMPHashMode(lrName, "l7")
+		require.ErrorContains(t, err, "invalid ecmp hash mode")
+	})
+
+	t.Run("non-existent group", func(t *testing.T) {
+		err := nbClient.SetECMPHashMode(lrName, routeTable, policy, "10.0.2.0/24", ECMPHashModeL4)
+		require.ErrorContains(t, err, "no static route")
+	})
+
+	t.Run("set and remove hash mode", func(t *testing.T) {
+		err := nbClient.SetECMPHashMode(lrName, routeTable, policy, prefix, ECMPHashModeL4)
+		require.NoError(t, err)
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		for _, route := range routes {
+			require.Equal(t, "ip_src,ip_dst,ip_proto,tp_src,tp_dst", route.Options[util.StaticRouteEcmpHashFields])
+		}
+		require.Equal(t, []string{prefix, prefix}, listPrefixes(ECMPHashModeL4))
+		require.Empty(t, listPrefixes(ECMPHashModeL3))
+
+		err = nbClient.SetECMPHashMode(lrName, routeTable, policy, "10.0.1.0/24", ECMPHashModeL3)
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.1.0/24", "10.0.1.0/24"}, listPrefixes(ECMPHashModeL3))
+
+		err = nbClient.SetECMPHashMode(lrName, routeTable, policy, prefix, "")
+		require.NoError(t, err)
+		routes, err = nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+		require.NoError(t, err)
+		for _, route := range routes {
+			require.NotContains(t, route.Options, util.StaticRouteEcmpHashFields)
+		}
+		require.Empty(t, listPrefixes(ECMPHashModeL4))
+	})
+}
+
+func (suite *OvnClientTestSuite) testReconcileStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-reconcile-static-routes-lr"
+	routeTable := "test-rtb"
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	owner := map[string]string{"owner": "test"}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	bfd, err := nbClient.CreateBFD("test-reconcile-static-routes-lrp", "192.168.0.3", 100, 100, 3, nil)
+	require.NoError(t, err)
+
+	newRoute := func(routeTable, ipPrefix, nexthop string, bfdID *string, externalIDs map[string]string) *ovnnb.LogicalRouterStaticRoute {
+		return &ovnnb.LogicalRouterStaticRoute{
+			UUID:        ovsclient.NamedUUID(),
+			Policy:      &dstIP,
+			RouteTable:  routeTable,
+			IPPrefix:    ipPrefix,
+			Nexthop:     nexthop,
+			BFD:         bfdID,
+			ExternalIDs: externalIDs,
+		}
+	}
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName,
+		newRoute(routeTable, "10.0.0.0/24", "192.168.0.1", nil, owner),
+		newRoute(routeTable, "10.0.1.0/24", "192.168.0.1", nil, owner),
+		newRoute(routeTable, "10.0.2.0/24", "192.168.0.3", &bfd.UUID, owner),
+		// out of the owner scope
+		newRoute(routeTable, "10.0.3.0/24", "192.168.0.1", nil, nil),
+		// out of the route table scope
+		newRoute(util.MainRouteTable, "10.0.4.0/24", "192.168.0.1", nil, owner),
+	)
+	require.NoError(t, err)
+
+	listRoutes := func() map[string]*ovnnb.LogicalRouterStaticRoute {
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		result := make(map[string]*ovnnb.LogicalRouterStaticRoute, len(routes))
+		for _, route := range routes {
+			result[route.RouteTable+"/"+route.IPPrefix+"/"+route.Nexthop] = route
+		}
+		return result
+	}
+
+	t.Run("desired route out of the route table", func(t *testing.T) {
+		err := nbClient.ReconcileStaticRoutes(lrName, []*ovnnb.LogicalRouterStaticRoute{newRoute(util.MainRouteTable, "10.0.5.0/24", "192.168.0.1", nil, nil)},
+			ReconcileOpts{RouteTable: &routeTable})
+		require.ErrorContains(t, err, "out of the reconciled route table")
+	})
+
+	t.Run("add, delete and protect bfd routes", func(t *testing.T) {
+		desired := []*ovnnb.LogicalRouterStaticRoute{
+			newRoute(routeTable, "10.0.0.0/24", "192.168.0.1", nil, nil),
+			newRoute(routeTable, "10.0.0.0/24", "192.168.0.2", nil, nil),
+			newRoute(routeTable, "10.0.5.0/24", "192.168.0.1", nil, map[string]string{"key": "value"}),
+		}
+		err := nbClient.ReconcileStaticRoutes(lrName, desired, ReconcileOpts{ProtectBFDRoutes: true, RouteTable: &routeTable, OwnerExternalIDs: owner})
+		require.NoError(t, err)
+
+		routes := listRoutes()
+		require.Len(t, routes, 6)
+		for _, key := range []string{
+			routeTable + "/10.0.0.0/24/192.168.0.1",
+			routeTable + "/10.0.0.0/24/192.168.0.2",
+			routeTable + "/10.0.2.0/24/192.168.0.3",
+			routeTable + "/10.0.3.0/24/192.168.0.1",
+			routeTable + "/10.0.5.0/24/192.168.0.1",
+			util.MainRouteTable + "/10.0.4.0/24/192.168.0.1",
+		} {
+			require.Contains(t, routes, key)
+		}
+		require.NotContains(t, routes, routeTable+"/10.0.1.0/24/192.168.0.1")
+		require.Equal(t, map[string]string{"key": "value", "owner": "test"}, routes[routeTable+"/10.0.5.0/24/192.168.0.1"].ExternalIDs)
+		require.Equal(t, "test", routes[routeTable+"/10.0.0.0/24/192.168.0.2"].ExternalIDs["owner"])
+
+		// reconciling again is a no-op
+		err = nbClient.ReconcileStaticRoutes(lrName, desired, ReconcileOpts{ProtectBFDRoutes: true, RouteTable: &routeTable, OwnerExternalIDs: owner})
+		require.NoError(t, err)
+		require.Len(t, listRoutes(), 6)
+	})
+
+	t.Run("delete unprotected bfd routes", func(t *testing.T) {
+		err := nbClient.ReconcileStaticRoutes(lrName, nil, ReconcileOpts{RouteTable: &routeTable, OwnerExternalIDs: map[string]string{"owner": ""}})
+		require.NoError(t, err)
+
+		routes := listRoutes()
+		require.Len(t, routes, 2)
+		require.Contains(t, routes, routeTable+"/10.0.3.0/24/192.168.0.1")
+		require.Contains(t, routes, util.MainRouteTable+"/10.0.4.0/24/192.168.0.1")
+	})
+}
+
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutesChangedSince() {
+	t := suite.T()
+	t.Parallel()
+
+	lrName := "test-list-routes-changed-since-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	// the event handler registered to the cache can't be removed, so the versions are monitored by a client
+	// of its own nb server rather than the shared one of the suite
+	nbClientDBModel, err := ovnnb.FullDatabaseModel()
+	require.NoError(t, err)
+	_, nbSock := newOVSDBServer(t, "nb-static-route-versions", nbClientDBModel, ovnnb.Schema())
+	client, err := newOvnNbClient(t, "unix:"+nbSock, 10)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	_, err = client.StaticRouteVersion()
+	require.ErrorContains(t, err, "not monitored")
+	_, _, err = client.ListLogicalRouterStaticRoutesChangedSince(lrName, 0)
+	require.ErrorContains(t, err, "not monitored")
+
+	client.MonitorStaticRouteVersions()
+	err = client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+	err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+
+	listChanged := func(version uint64) ([]string, uint64) {
+		routes, current, err := client.ListLogicalRouterStaticRoutesChangedSince(lrName, version)
+		require.NoError(t, err)
+		prefixes := make([]string, 0, len(routes))
+		for _, route := range routes {
+			prefixes = append(prefixes, route.IPPrefix)
+		}
+		slices.Sort(prefixes)
+		return prefixes, current
+	}
+
+	var version uint64
+	require.Eventually(t, func() bool {
+		var prefixes []string
+		prefixes, version = listChanged(0)
+		return slices.Equal(prefixes, []string{"10.0.0.0/24", "10.0.1.0/24"})
+	}, 2*time.Second, 10*time.Millisecond)
+	current, err := client.StaticRouteVersion()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, current, version)
+
+	prefixes, _ := listChanged(version)
+	require.Empty(t, prefixes)
+
+	// only the updated route is returned, and the deleted one is not
+	err = client.SetECMPHashMode(lrName, routeTable, policy, "10.0.0.0/24", ECMPHashModeL3)
+	require.NoError(t, err)
+	err = client.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, "10.0.1.0/24", "192.168.0.1")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		prefixes, _ := listChanged(version)
+		return slices.Equal(prefixes, []string{"10.0.0.0/24"})
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func (suite *OvnClientTestSuite) testMixedBFDECMPGroup() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-mixed-bfd-ecmp-group-lr"
+	lrpName := "test-mixed-bfd-ecmp-group-lrp"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	prefix := "10.0.0.0/24"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	bfd1, err := nbClient.CreateBFD(lrpName, "192.168.0.1", 100, 100, 3, nil)
+	require.NoError(t, err)
+	bfd2, err := nbClient.CreateBFD(lrpName, "192.168.0.2", 100, 100, 3, nil)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, prefix, map[string]string{"192.168.0.1": bfd1.UUID, "192.168.0.2": bfd2.UUID}, nil)
+	require.NoError(t, err)
+
+	listNexthops := func() []string {
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+		require.NoError(t, err)
+		nexthops := make([]string, 0, len(routes))
+		for _, route := range routes {
+			nexthops = append(nexthops, route.Nexthop)
+		}
+		slices.Sort(nexthops)
+		return nexthops
+	}
+
+	t.Run("add non-bfd nexthop to bfd group", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, prefix, nil, nil, "192.168.0.1", "192.168.0.2", "192.168.0.3")
+		require.ErrorIs(t, err, ErrMixedBFDECMPGroup)
+		require.ErrorContains(t, err, "non-bfd nexthops [192.168.0.3]")
+		require.Equal(t, []string{"192.168.0.1", "192.168.0.2"}, listNexthops())
+	})
+
+	t.Run("add bfd nexthop to non-bfd group", func(t *testing.T) {
+		otherPrefix := "10.0.1.0/24"
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, otherPrefix, nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRouteAdditive(lrName, routeTable, policy, otherPrefix, &bfd2.UUID, nil, "192.168.0.2")
+		require.ErrorIs(t, err, ErrMixedBFDECMPGroup)
+	})
+
+	t.Run("replace bfd group with non-bfd nexthops", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, nil, "192.168.0.3", "192.168.0.4")
+		require.NoError(t, err)
+		require.Equal(t, []string{"192.168.0.3", "192.168.0.4"}, listNexthops())
+	})
+}
+
+func (suite *OvnClientTestSuite) testLogicalRouterCache() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-logical-router-cache-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+
+	// the cache is not invalidated by the monitor, whose handlers may be blocked by the other tests
+	client := *nbClient
+	var gets int
+	client.logicalRouterCache = newLogicalRouterCache(time.Second, func(name string, ignoreNotFound bool) (*ovnnb.LogicalRouter, error) {
+		if name == lrName {
+			gets++
+		}
+		return nbClient.GetLogicalRouter(name, ignoreNotFound)
+	})
+	now := time.Now()
+	client.logicalRouterCache.now = func() time.Time { return now }
+
+	listPrefixes := func() []string {
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "", nil)
+		require.NoError(t, err)
+		prefixes := make([]string, 0, len(routes))
+		for _, route := range routes {
+			prefixes = append(prefixes, route.IPPrefix)
+		}
+		slices.Sort(prefixes)
+		return prefixes
+	}
+
+	t.Run("repeated operations within ttl", func(t *testing.T) {
+		for range 3 {
+			require.Equal(t, []string{"10.0.0.0/24"}, listPrefixes())
+		}
+		require.Equal(t, 1, gets)
+
+		// the cached router is a copy
+		lr, err := client.getCachedLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		lr.StaticRoutes = nil
+		require.Equal(t, []string{"10.0.0.0/24"}, listPrefixes())
+		require.Equal(t, 1, gets)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(time.Second)
+		require.Equal(t, []string{"10.0.0.0/24"}, listPrefixes())
+		require.Equal(t, 2, gets)
+	})
+
+	t.Run("invalidated on route mutations", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+		n := gets
+		require.Equal(t, []string{"10.0.0.0/24", "10.0.1.0/24"}, listPrefixes())
+		require.Greater(t, gets, n)
+	})
+
+	t.Run("invalidated on updates from the monitor", func(t *testing.T) {
+		// the clients of its own nb server, so that the monitor handler is neither blocked by nor left to the other tests
+		nbClientDBModel, err := ovnnb.FullDatabaseModel()
+		require.NoError(t, err)
+		_, nbSock := newOVSDBServer(t, "nb-logical-router-cache", nbClientDBModel, ovnnb.Schema())
+		monitored, err := newOvnNbClient(t, "unix:"+nbSock, 10)
+		require.NoError(t, err)
+		t.Cleanup(monitored.Close)
+		writer, err := newOvnNbClient(t, "unix:"+nbSock, 10)
+		require.NoError(t, err)
+		t.Cleanup(writer.Close)
+
+		// the ttl never expires in the test, so the changed router is only got after invalidated
+		monitored.EnableLogicalRouterCache(time.Hour)
+		err = writer.CreateLogicalRouter(lrName)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			lr, err := monitored.getCachedLogicalRouter(lrName, true)
+			require.NoError(t, err)
+			return lr != nil
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// the router is changed by the other client, whose update is only received from the monitor
+		lr, err := writer.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+		lr.ExternalIDs = map[string]string{"cache-test": "updated"}
+		err = writer.UpdateLogicalRouter(lr, &lr.ExternalIDs)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			lr, err := monitored.getCachedLogicalRouter(lrName, false)
+			require.NoError(t, err)
+			return lr.ExternalIDs["cache-test"] == "updated"
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("invalidated while getting", func(t *testing.T) {
+		for _, invalidate := range []func(){
+			func() { client.logicalRouterCache.Invalidate(lrName) },
+			func() { client.logicalRouterCache.InvalidateAll() },
+		} {
+			// the router got before the invalidation is stale, so it is not cached
+			lrCache := newLogicalRouterCache(time.Hour, func(name string, ignoreNotFound bool) (*ovnnb.LogicalRouter, error) {
+				gets++
+				lr, err := nbClient.GetLogicalRouter(name, ignoreNotFound)
+				invalidate()
+				return lr, err
+			})
+			client.logicalRouterCache, gets = lrCache, 0
+			_, err := client.getCachedLogicalRouter(lrName, false)
+			require.NoError(t, err)
+			require.Equal(t, 1, gets)
+			_, err = client.getCachedLogicalRouter(lrName, false)
+			require.NoError(t, err)
+			require.Equal(t, 2, gets)
+		}
+	})
+
+	t.Run("not found router", func(t *testing.T) {
+		lr, err := client.getCachedLogicalRouter("test-logical-router-cache-non-existent-lr", true)
+		require.NoError(t, err)
+		require.Nil(t, lr)
+		_, err = client.getCachedLogicalRouter("test-logical-router-cache-non-existent-lr", false)
+		require.ErrorContains(t, err, "not found logical router")
+	})
+}
+
+func (suite *OvnClientTestSuite) testDeleteLogicalRouterStaticRoutesWhere() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-del-lr-routes-where"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.0.0/24", nil, nil, "192.168.0.1", "192.168.0.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.1.0/24", nil, nil, "192.168.0.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.0.2.0/24", nil, nil, "192.168.0.3")
+	require.NoError(t, err)
+
+	client := *nbClient
+	var transactions int
+	client.preTransact = func(_ string, _ []*ovnnb.LogicalRouterStaticRoute) { transactions++ }
+
+	t.Run("refuse nil predicate", func(t *testing.T) {
+		err := client.DeleteLogicalRouterStaticRoutesWhere(lrName, nil)
+		require.ErrorContains(t, err, "nil predicate")
+
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 4)
+	})
+
+	t.Run("no matched route", func(t *testing.T) {
+		err := client.DeleteLogicalRouterStaticRoutesWhere(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+			return route.Nexthop == "192.168.0.4"
+		})
+		require.NoError(t, err)
+		require.Zero(t, transactions)
+
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 4)
+	})
+
+	t.Run("delete by custom predicate", func(t *testing.T) {
+		// delete the routes via 192.168.0.2 except the ecmp ones
+		err := client.DeleteLogicalRouterStaticRoutesWhere(lrName, func(route *ovnnb.LogicalRouterStaticRoute) bool {
+			return route.Nexthop == "192.168.0.2" && route.IPPrefix != "10.0.0.0/24"
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, transactions)
+
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		routeKeys := make([]string, 0, len(routes))
+		for _, route := range routes {
+			routeKeys = append(routeKeys, route.IPPrefix+"/"+route.Nexthop)
+		}
+		require.ElementsMatch(t, []string{"10.0.0.0/24/192.168.0.1", "10.0.0.0/24/192.168.0.2", "10.0.2.0/24/192.168.0.3"}, routeKeys)
+	})
+
+	t.Run("non-existent logical router", func(t *testing.T) {
+		err := client.DeleteLogicalRouterStaticRoutesWhere("test-del-lr-routes-where-non-existent", func(*ovnnb.LogicalRouterStaticRoute) bool { return true })
+		require.NoError(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testAddLogicalRouterConnectedRoute() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-add-connected-route-lr"
+	lrpName := "test-add-connected-route-lrp"
+	otherLrpName := "test-add-connected-route-other-lrp"
+	routeTable := util.MainRouteTable
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.CreateLogicalRouter(lrName + "-other")
+	require.NoError(t, err)
+	err = nbClient.CreateLogicalRouterPort(lrName, lrpName, "00:00:00:00:00:01", []string{"172.30.0.1/30", "fd00:172:30::1/126"})
+	require.NoError(t, err)
+	err = nbClient.CreateLogicalRouterPort(lrName+"-other", otherLrpName, "00:00:00:00:00:02", []string{"172.31.0.1/30"})
+	require.NoError(t, err)
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterConnectedRoute(lrName, routeTable, "10.0.0.0/24", "", "", nil)
+		require.ErrorContains(t, err, "no output port")
+		err = nbClient.AddLogicalRouterConnectedRoute(lrName, routeTable, "10.0.0.0/24", lrpName, "172.30.0.2", nil)
+		require.ErrorContains(t, err, "not an ipv6 link-local address")
+		err = nbClient.AddLogicalRouterConnectedRoute(lrName, routeTable, "10.0.0.0/24", "test-add-connected-route-non-existent-lrp", "", nil)
+		require.ErrorContains(t, err, "does not exist")
+		err = nbClient.AddLogicalRouterConnectedRoute(lrName, routeTable, "10.0.0.0/24", otherLrpName, "", nil)
+		require.ErrorContains(t, err, "is not a port of logical router")
+	})
+
+	t.Run("create and match connected routes", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterConnectedRoute(lrName, routeTable, "10.0.0.0/24", lrpName, "", map[string]string{"key": "value"})
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterConnectedRoute(lrName, routeTable, "fd00:10::/64", lrpName, "fe80::1", nil)
+		require.NoError(t, err)
+		// adding again is a no-op
+		err = nbClient.AddLogicalRouterConnectedRoute(lrName, routeTable, "10.0.0.0/24", lrpName, "", map[string]string{"key": "value"})
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, ovnnb.LogicalRouterStaticRoutePolicyDstIP, "10.0.1.0/24", nil, nil, "172.30.0.2")
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutesByOutputPort(lrName, lrpName)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		slices.SortFunc(routes, func(a, b *ovnnb.LogicalRouterStaticRoute) int { return strings.Compare(a.IPPrefix, b.IPPrefix) })
+		require.Equal(t, "10.0.0.0/24", routes[0].IPPrefix)
+		require.Empty(t, routes[0].Nexthop)
+		require.Equal(t, lrpName, *routes[0].OutputPort)
+		require.Equal(t, "value", routes[0].ExternalIDs["key"])
+		require.Equal(t, "fd00:10::/64", routes[1].IPPrefix)
+		require.Equal(t, "fe80::1", routes[1].Nexthop)
+	})
+
+	t.Run("connected route via another port", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterConnectedRoute(lrName, routeTable, "10.0.0.0/24", lrpName+"-2", "", nil)
+		require.ErrorContains(t, err, "exists via output port")
+	})
+}
+
+func (suite *OvnClientTestSuite) testGarbageCollectDanglingBFDRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-gc-dangling-bfd-routes-lr"
+	lrpName := "test-gc-dangling-bfd-routes-lrp"
+	routeTable := util.MainRouteTable
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	// the weak reference of the bfd column is cleared by the server once the bfd session is deleted, so the
+	// session deleted out-of-band before the route is updated is simulated by removing it from the client cache
+	deleteBFDOutOfBand := func(uuid string) {
+		require.NoError(t, nbClient.Cache().Table(ovnnb.BFDTable).Delete(uuid))
+	}
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	bfd, err := nbClient.CreateBFD(lrpName, "192.168.10.1", 100, 100, 3, nil)
+	require.NoError(t, err)
+	deleted, err := nbClient.CreateBFD(lrpName, "192.168.10.2", 100, 100, 3, nil)
+	require.NoError(t, err)
+
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.0.0.0/16", &bfd.UUID, nil, "192.168.10.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.1.0.0/16", &deleted.UUID, map[string]string{"vendor": util.CniTypeName}, "192.168.10.2")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.2.0.0/16", nil, nil, "192.168.10.3")
+	require.NoError(t, err)
+	deleteBFDOutOfBand(deleted.UUID)
+
+	t.Run("clear the bfd of dangling routes", func(t *testing.T) {
+		routes, err := nbClient.GarbageCollectDanglingBFDRoutes(lrName)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Equal(t, "10.1.0.0/16", routes[0].IPPrefix)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.1.0.0/16", "192.168.10.2", false)
+		require.NoError(t, err)
+		require.Nil(t, route.BFD)
+		require.NotContains(t, route.Options, util.StaticRouteBfdEcmp)
+		require.Equal(t, util.CniTypeName, route.ExternalIDs["vendor"])
+
+		route, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.0.0.0/16", "192.168.10.1", false)
+		require.NoError(t, err)
+		require.Equal(t, bfd.UUID, *route.BFD)
+
+		routes, err = nbClient.GarbageCollectDanglingBFDRoutes(lrName)
+		require.NoError(t, err)
+		require.Empty(t, routes)
+	})
+
+	t.Run("delete dangling routes", func(t *testing.T) {
+		client := *nbClient
+		client.deleteDanglingBFDRoutes = true
+
+		deleted, err := client.CreateBFD(lrpName, "192.168.10.4", 100, 100, 3, nil)
+		require.NoError(t, err)
+		err = client.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.4.0.0/16", &deleted.UUID, nil, "192.168.10.4")
+		require.NoError(t, err)
+		deleteBFDOutOfBand(deleted.UUID)
+
+		routes, err := client.GarbageCollectDanglingBFDRoutes(lrName)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Equal(t, "10.4.0.0/16", routes[0].IPPrefix)
+
+		route, err := client.GetLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.4.0.0/16", "192.168.10.4", true)
+		require.NoError(t, err)
+		require.Nil(t, route)
+
+		routes, err = client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 3)
+	})
+
+	t.Run("nonexistent logical router", func(t *testing.T) {
+		_, err := nbClient.GarbageCollectDanglingBFDRoutes("test-gc-dangling-bfd-routes-nonexistent-lr")
+		require.Error(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testTransactRoutersStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName, peerName := "test-transact-routers-routes-lr", "test-transact-routers-routes-peer-lr"
+	routeTable := util.MainRouteTable
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	newRoute := func(uuid, ipPrefix, nexthop string) *ovnnb.LogicalRouterStaticRoute {
+		return &ovnnb.LogicalRouterStaticRoute{
+			UUID:       uuid,
+			Policy:     &dstIP,
+			RouteTable: routeTable,
+			IPPrefix:   ipPrefix,
+			Nexthop:    nexthop,
+		}
+	}
+	listPrefixes := func(lrName string) []string {
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		prefixes := make([]string, 0, len(routes))
+		for _, route := range routes {
+			prefixes = append(prefixes, route.IPPrefix)
+		}
+		return prefixes
 	}
 
-	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, routes...)
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = nbClient.CreateLogicalRouter(peerName)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.9.0.0/16", nil, nil, "192.168.0.9")
+	require.NoError(t, err)
+	stale, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, dstIP, "10.9.0.0/16", "192.168.0.9", false)
 	require.NoError(t, err)
 
-	t.Run("filter by external IDs with multiple keys", func(t *testing.T) {
-		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", map[string]string{"key1": "value1", "key2": "value2"})
+	t.Run("route and symmetric return route", func(t *testing.T) {
+		// the same named uuid in both routers is replaced in one of them
+		uuid := ovsclient.NamedUUID()
+		err := nbClient.TransactRoutersStaticRoutes(map[string]RouterStaticRouteChanges{
+			lrName:   {Create: []*ovnnb.LogicalRouterStaticRoute{newRoute(uuid, "10.1.0.0/16", "192.168.0.2")}, Delete: []string{stale.UUID}},
+			peerName: {Create: []*ovnnb.LogicalRouterStaticRoute{newRoute(uuid, "10.0.0.0/16", "192.168.0.1")}},
+		})
 		require.NoError(t, err)
-		require.Len(t, result, 1)
-		require.Equal(t, "192.168.60.0/24", result[0].IPPrefix)
+		require.ElementsMatch(t, []string{"10.1.0.0/16"}, listPrefixes(lrName))
+		require.ElementsMatch(t, []string{"10.0.0.0/16"}, listPrefixes(peerName))
 	})
 
-	t.Run("filter by external IDs key existence only", func(t *testing.T) {
-		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", map[string]string{"key1": ""})
+	t.Run("failure on one router rolls back the other", func(t *testing.T) {
+		// the peer router references a route without uuid, which fails the transaction with a referential integrity violation
+		invalid := newRoute("", "10.3.0.0/16", "192.168.0.3")
+		err := nbClient.TransactRoutersStaticRoutes(map[string]RouterStaticRouteChanges{
+			lrName:   {Create: []*ovnnb.LogicalRouterStaticRoute{newRoute(ovsclient.NamedUUID(), "10.2.0.0/16", "192.168.0.2")}},
+			peerName: {Create: []*ovnnb.LogicalRouterStaticRoute{invalid}},
+		})
+		require.Error(t, err)
+		require.ElementsMatch(t, []string{"10.1.0.0/16"}, listPrefixes(lrName))
+		require.ElementsMatch(t, []string{"10.0.0.0/16"}, listPrefixes(peerName))
+	})
+
+	t.Run("nonexistent logical router", func(t *testing.T) {
+		err := nbClient.TransactRoutersStaticRoutes(map[string]RouterStaticRouteChanges{
+			lrName: {Create: []*ovnnb.LogicalRouterStaticRoute{newRoute(ovsclient.NamedUUID(), "10.4.0.0/16", "192.168.0.4")}},
+			"test-transact-routers-routes-nonexistent-lr": {Create: []*ovnnb.LogicalRouterStaticRoute{newRoute(ovsclient.NamedUUID(), "10.5.0.0/16", "192.168.0.5")}},
+		})
+		require.ErrorContains(t, err, "not found logical router")
+		require.ElementsMatch(t, []string{"10.1.0.0/16"}, listPrefixes(lrName))
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		err := nbClient.TransactRoutersStaticRoutes(map[string]RouterStaticRouteChanges{lrName: {}})
 		require.NoError(t, err)
-		require.Len(t, result, 2)
 	})
+}
 
-	t.Run("filter by route table and external IDs", func(t *testing.T) {
-		customTable := "custom"
-		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &customTable, nil, "", map[string]string{"key1": "value1"})
+func (suite *OvnClientTestSuite) testListAllStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrNames := []string{"test-list-all-static-routes-lr-b", "test-list-all-static-routes-lr-a"}
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	srcIP := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+
+	for _, lrName := range lrNames {
+		err := nbClient.CreateLogicalRouter(lrName)
 		require.NoError(t, err)
-		require.Empty(t, result)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, "rtb", dstIP, "10.0.0.0/16", nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, srcIP, "10.1.0.0/16", nil, nil, "192.168.0.1")
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, dstIP, "10.2.0.0/16", nil, nil, "192.168.0.2")
+		require.NoError(t, err)
+	}
+
+	// other tests create routers in parallel, so only the routes of the test routers are checked
+	isTestRouter := func(lrName string) bool {
+		return strings.HasPrefix(lrName, "test-list-all-static-routes-lr-")
+	}
+	expected := []string{
+		"test-list-all-static-routes-lr-a||dst-ip|10.2.0.0/16",
+		"test-list-all-static-routes-lr-a||src-ip|10.1.0.0/16",
+		"test-list-all-static-routes-lr-a|rtb|dst-ip|10.0.0.0/16",
+		"test-list-all-static-routes-lr-b||dst-ip|10.2.0.0/16",
+		"test-list-all-static-routes-lr-b||src-ip|10.1.0.0/16",
+		"test-list-all-static-routes-lr-b|rtb|dst-ip|10.0.0.0/16",
+	}
+
+	t.Run("list routes of all routers", func(t *testing.T) {
+		routes, err := nbClient.ListAllStaticRoutes()
+		require.NoError(t, err)
+		var keys []string
+		for _, route := range routes {
+			if isTestRouter(route.Router) {
+				require.Equal(t, route.Route.RouteTable, route.RouteTable)
+				keys = append(keys, strings.Join([]string{route.Router, route.RouteTable, *route.Route.Policy, route.Route.IPPrefix}, "|"))
+			}
+		}
+		require.Equal(t, expected, keys)
 	})
 
-	t.Run("filter with non-matching external IDs", func(t *testing.T) {
-		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", map[string]string{"nonexistent": "value"})
+	t.Run("stop walking on error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var walked []string
+		err := nbClient.WalkAllStaticRoutes(func(route RouterStaticRoute) error {
+			if !isTestRouter(route.Router) {
+				return nil
+			}
+			walked = append(walked, route.Router)
+			if len(walked) == 2 {
+				return errStop
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Len(t, walked, 2)
+	})
+}
+
+func (suite *OvnClientTestSuite) testReplaceGatewayNode() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-replace-gateway-node-lr"
+	lrpName := "test-replace-gateway-node-lrp"
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	oldIP, otherIP, newIP := "192.168.20.1", "192.168.20.2", "192.168.20.3"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	oldBFD, err := nbClient.CreateBFD(lrpName, oldIP, 100, 100, 3, nil)
+	require.NoError(t, err)
+	otherBFD, err := nbClient.CreateBFD(lrpName, otherIP, 100, 100, 3, nil)
+	require.NoError(t, err)
+	newBFD, err := nbClient.CreateBFD(lrpName, newIP, 100, 100, 3, nil)
+	require.NoError(t, err)
+
+	bfds := map[string]string{oldIP: oldBFD.UUID, otherIP: otherBFD.UUID}
+	for _, routeTable := range []string{util.MainRouteTable, "rtb"} {
+		for _, ipPrefix := range []string{"10.0.0.0/16", "10.1.0.0/16"} {
+			err = nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, dstIP, ipPrefix, bfds, nil)
+			require.NoError(t, err)
+		}
+	}
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, dstIP, "10.2.0.0/16", nil, nil, oldIP)
+	require.NoError(t, err)
+	oldRoute, err := nbClient.GetLogicalRouterStaticRoute(lrName, util.MainRouteTable, dstIP, "10.0.0.0/16", oldIP, false)
+	require.NoError(t, err)
+
+	t.Run("missing new bfd", func(t *testing.T) {
+		err := nbClient.ReplaceGatewayNode(lrName, oldIP, newIP, nil)
+		require.ErrorContains(t, err, "no new bfd is specified")
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
 		require.NoError(t, err)
-		require.Empty(t, result)
+		require.Len(t, routes, 9)
+		for _, route := range routes {
+			require.NotEqual(t, newIP, route.Nexthop)
+		}
 	})
 
-	t.Run("filter with policy and external IDs", func(t *testing.T) {
-		srcPolicy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
-		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, &srcPolicy, "", map[string]string{"key1": "value1"})
+	t.Run("ip family mismatch", func(t *testing.T) {
+		err := nbClient.ReplaceGatewayNode(lrName, oldIP, "fd00::3", map[string]string{oldBFD.UUID: newBFD.UUID})
+		require.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("replace bfd protected ecmp routes", func(t *testing.T) {
+		err := nbClient.ReplaceGatewayNode(lrName, oldIP, newIP, map[string]string{oldBFD.UUID: newBFD.UUID})
 		require.NoError(t, err)
-		require.Empty(t, result)
+
+		for _, routeTable := range []string{util.MainRouteTable, "rtb"} {
+			for _, ipPrefix := range []string{"10.0.0.0/16", "10.1.0.0/16"} {
+				routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &dstIP, ipPrefix, nil)
+				require.NoError(t, err)
+				nexthopBFD := make(map[string]string, len(routes))
+				for _, route := range routes {
+					require.NotNil(t, route.BFD)
+					require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
+					nexthopBFD[route.Nexthop] = *route.BFD
+				}
+				require.Equal(t, map[string]string{newIP: newBFD.UUID, otherIP: otherBFD.UUID}, nexthopBFD)
+			}
+		}
+
+		// the routes are moved in place
+		route, err := nbClient.GetLogicalRouterStaticRouteByUUID(oldRoute.UUID)
+		require.NoError(t, err)
+		require.Equal(t, newIP, route.Nexthop)
+		route, err = nbClient.GetLogicalRouterStaticRoute(lrName, util.MainRouteTable, dstIP, "10.2.0.0/16", newIP, false)
+		require.NoError(t, err)
+		require.Nil(t, route.BFD)
 	})
 
-	t.Run("filter with IP prefix and external IDs", func(t *testing.T) {
-		result, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "192.168.70.0/24", map[string]string{"key1": "value1"})
+	t.Run("prefix already routed via new ip", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, dstIP, "10.2.0.0/16", nil, nil, oldIP)
 		require.NoError(t, err)
-		require.Len(t, result, 1)
-		require.Equal(t, "192.168.70.0/24", result[0].IPPrefix)
+		err = nbClient.ReplaceGatewayNode(lrName, oldIP, newIP, nil)
+		require.NoError(t, err)
+
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, &dstIP, "10.2.0.0/16", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		require.Equal(t, newIP, routes[0].Nexthop)
 	})
 }
 
-func (suite *OvnClientTestSuite) testNewLogicalRouterStaticRoute() {
+func (suite *OvnClientTestSuite) testValidateStaticRoutesBatch() {
 	t := suite.T()
 	t.Parallel()
 
 	nbClient := suite.ovnNBClient
-	lrName := "test-new-route-lr"
-	routeTable := util.MainRouteTable
-	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-	ipPrefix := "192.168.30.0/24"
-	nexthop := "192.168.30.1"
+	lrName := "test-validate-static-routes-batch-lr"
+	lrpName := "test-validate-static-routes-batch-lrp"
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
 
 	err := nbClient.CreateLogicalRouter(lrName)
 	require.NoError(t, err)
+	bfd, err := nbClient.CreateBFD(lrpName, "192.168.30.1", 100, 100, 3, nil)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, dstIP, "10.9.0.0/16", &bfd.UUID, nil, "192.168.30.1")
+	require.NoError(t, err)
 
-	expect := &ovnnb.LogicalRouterStaticRoute{
-		Policy:   &policy,
-		IPPrefix: ipPrefix,
-		Nexthop:  nexthop,
-		BFD:      nil,
+	newRoute := func(policy, ipPrefix, nexthop string, bfdID *string) *ovnnb.LogicalRouterStaticRoute {
+		route := &ovnnb.LogicalRouterStaticRoute{
+			UUID:     ovsclient.NamedUUID(),
+			Policy:   &policy,
+			IPPrefix: ipPrefix,
+			Nexthop:  nexthop,
+			BFD:      bfdID,
+		}
+		if bfdID != nil {
+			route.Options = map[string]string{util.StaticRouteBfdEcmp: "true"}
+		}
+		return route
+	}
+	withoutBFDOption := newRoute(dstIP, "10.8.0.0/16", "192.168.30.2", nil)
+	withoutBFDOption.Options = map[string]string{util.StaticRouteBfdEcmp: "true"}
+	routes := []*ovnnb.LogicalRouterStaticRoute{
+		newRoute(dstIP, "10.0.0.0/16", "192.168.30.1", &bfd.UUID),
+		newRoute(dstIP, "10.1.0.0/33", "192.168.30.2", nil),
+		newRoute(dstIP, "10.2.0.0/16", "fd00::2", nil),
+		newRoute("via", "10.3.0.0/16", "192.168.30.2", nil),
+		newRoute(dstIP, "10.0.0.0/16", "192.168.30.1", &bfd.UUID),
+		newRoute(dstIP, "10.4.0.0/16", "192.168.30.2", ptr.To("00000000-0000-0000-0000-000000000000")),
+		newRoute(dstIP, "10.9.0.0/16", "192.168.30.2", nil),
+		withoutBFDOption,
+		newRoute(dstIP, "10.5.0.0/16", "192.168.30.2", &bfd.UUID),
+		nil,
+		newRoute(ovnnb.LogicalRouterStaticRoutePolicySrcIP, "10.6.0.0/16", "192.168.30.2", nil),
 	}
 
-	route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, nil)
+	report, err := nbClient.ValidateStaticRoutesBatch(lrName, routes)
 	require.NoError(t, err)
-	expect.UUID = route.UUID
-	require.Equal(t, expect, route)
+	require.Len(t, report, 9)
+	require.NotContains(t, report, 0)
+	require.ErrorContains(t, report[1], "invalid ip prefix")
+	require.ErrorContains(t, report[2], "does not match ip prefix")
+	require.ErrorContains(t, report[3], "invalid policy")
+	require.ErrorIs(t, report[4], ErrDuplicateStaticRoute)
+	require.ErrorContains(t, report[5], "failed to get bfd")
+	require.ErrorIs(t, report[6], ErrMixedBFDECMPGroup)
+	require.ErrorContains(t, report[7], "is set without bfd")
+	require.ErrorContains(t, report[8], "monitors 192.168.30.1 instead of nexthop 192.168.30.2")
+	require.ErrorContains(t, report[9], "route is nil")
+	require.NotContains(t, report, 10)
+
+	// nothing is changed
+	existing, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+	require.NoError(t, err)
+	require.Len(t, existing, 1)
 
-	t.Run("with custom options", func(t *testing.T) {
-		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-		ipPrefix := "192.168.100.0/24"
-		nexthop := "192.168.100.1"
+	_, err = nbClient.ValidateStaticRoutesBatch("test-validate-static-routes-batch-nonexistent-lr", routes)
+	require.Error(t, err)
+}
 
-		customOption := func(route *ovnnb.LogicalRouterStaticRoute) {
-			route.Options = map[string]string{
-				"ecmp_symmetric_reply": "true",
-				"method":               "redirect",
-			}
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutesInPrefixRange() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-routes-in-prefix-range-lr"
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	prefixes := []string{
+		"10.0.1.0/24", "10.0.2.1", "10.0.9.0/24", "10.0.10.0/24", "10.0.0.0/16", "10.0.8.0/21", "9.255.255.0/24",
+		"fd00::/64", "fd00:0:0:2::/64", "fd00:0:0:10::/64", "invalid",
+	}
+	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		nexthop := "192.168.0.1"
+		if strings.Contains(prefix, ":") {
+			nexthop = "fd00::1"
 		}
+		routes = append(routes, &ovnnb.LogicalRouterStaticRoute{
+			UUID:       ovsclient.NamedUUID(),
+			Policy:     &dstIP,
+			RouteTable: util.MainRouteTable,
+			IPPrefix:   prefix,
+			Nexthop:    nexthop,
+		})
+	}
+	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, routes...)
+	require.NoError(t, err)
 
-		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, nil, customOption)
+	inRange := func(start, end string) []string {
+		routes, err := nbClient.ListLogicalRouterStaticRoutesInPrefixRange(lrName, start, end)
 		require.NoError(t, err)
-		require.Equal(t, "true", route.Options["ecmp_symmetric_reply"])
-		require.Equal(t, "redirect", route.Options["method"])
+		result := make([]string, 0, len(routes))
+		for _, route := range routes {
+			result = append(result, route.IPPrefix)
+		}
+		return result
+	}
+
+	t.Run("ipv4", func(t *testing.T) {
+		// 10.0.10.0/24 is after 10.0.9.0/24 numerically but not as a string
+		require.ElementsMatch(t, []string{"10.0.1.0/24", "10.0.2.1", "10.0.9.0/24", "10.0.10.0/24", "10.0.8.0/21"}, inRange("10.0.1.0/24", "10.0.15.0/24"))
+		// prefixes partially out of the range are excluded
+		require.ElementsMatch(t, []string{"10.0.1.0/24", "10.0.2.1", "10.0.9.0/24"}, inRange("10.0.1.0/24", "10.0.9.0/24"))
+		require.ElementsMatch(t, []string{"10.0.2.1"}, inRange("10.0.2.1/32", "10.0.2.1/32"))
+		require.Empty(t, inRange("11.0.0.0/8", "12.0.0.0/8"))
 	})
 
-	t.Run("with multiple options and bfd", func(t *testing.T) {
-		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-		ipPrefix := "192.168.110.0/24"
-		nexthop := "192.168.110.1"
-		bfdID := "test-bfd"
+	t.Run("ipv6", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"fd00::/64", "fd00:0:0:2::/64"}, inRange("fd00::/64", "fd00:0:0:9::/64"))
+		require.ElementsMatch(t, []string{"fd00:0:0:10::/64"}, inRange("fd00:0:0:3::/64", "fd00:0:0:ff::/64"))
+	})
 
-		option1 := func(route *ovnnb.LogicalRouterStaticRoute) {
-			route.ExternalIDs = map[string]string{"key1": "value1"}
-		}
-		option2 := func(route *ovnnb.LogicalRouterStaticRoute) {
-			if route.Options == nil {
-				route.Options = make(map[string]string)
-			}
-			route.Options["test"] = "value"
-		}
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := nbClient.ListLogicalRouterStaticRoutesInPrefixRange(lrName, "10.0.0.0/24", "fd00::/64")
+		require.ErrorContains(t, err, "different address families")
+		_, err = nbClient.ListLogicalRouterStaticRoutesInPrefixRange(lrName, "10.0.2.0/24", "10.0.1.0/24")
+		require.ErrorContains(t, err, "is after")
+		_, err = nbClient.ListLogicalRouterStaticRoutesInPrefixRange(lrName, "10.0.0.0", "10.0.1.0/24")
+		require.Error(t, err)
+	})
+}
 
-		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, &bfdID, nil, option1, option2)
+func (suite *OvnClientTestSuite) testExportRouterRoutesDOT() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-export-routes-dot-lr"
+	lrpName := "test-export-routes-dot-lrp"
+	dstIP := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	srcIP := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	dot, err := nbClient.ExportRouterRoutesDOT(lrName)
+	require.NoError(t, err)
+	require.Equal(t, "digraph \"test-export-routes-dot-lr\" {\n  rankdir=LR;\n  \"router:test-export-routes-dot-lr\" [shape=box, label=\"test-export-routes-dot-lr\"];\n}\n", dot)
+
+	bfdIDs := make(map[string]string)
+	for _, nexthop := range []string{"192.168.0.1", "192.168.0.2"} {
+		bfd, err := nbClient.CreateBFD(lrpName, nexthop, 100, 100, 3, nil)
 		require.NoError(t, err)
-		require.Equal(t, "value1", route.ExternalIDs["key1"])
-		require.Equal(t, "value", route.Options["test"])
-		require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
-		require.Equal(t, &bfdID, route.BFD)
+		bfdIDs[nexthop] = bfd.UUID
+	}
+	err = nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, util.MainRouteTable, srcIP, "10.0.0.0/24", bfdIDs, nil)
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, dstIP, "0.0.0.0/0", nil, nil, "192.168.0.1")
+	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, "rtb", dstIP, "10.1.0.0/16", nil, nil, "192.168.0.3")
+	require.NoError(t, err)
+
+	dot, err = nbClient.ExportRouterRoutesDOT(lrName)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(dot, "digraph \"test-export-routes-dot-lr\" {\n"))
+	require.True(t, strings.HasSuffix(dot, "}\n"))
+
+	// prefixes of the route tables and policies
+	require.Contains(t, dot, `"router:test-export-routes-dot-lr" -> "prefix:-dst-ip-0.0.0.0/0";`)
+	require.Contains(t, dot, `"router:test-export-routes-dot-lr" -> "prefix:-src-ip-10.0.0.0/24";`)
+	require.Contains(t, dot, `"router:test-export-routes-dot-lr" -> "prefix:rtb-dst-ip-10.1.0.0/16";`)
+	require.Contains(t, dot, `"prefix:-src-ip-10.0.0.0/24" [shape=ellipse, label="10.0.0.0/24\nsrc-ip"];`)
+	require.Contains(t, dot, `"prefix:rtb-dst-ip-10.1.0.0/16" [shape=ellipse, label="10.1.0.0/16\ntable rtb"];`)
+
+	// the ecmp nexthops are grouped with bfd annotated
+	require.Contains(t, dot, `"ecmp:-src-ip-10.0.0.0/24" [shape=diamond, label="ecmp x2"];`)
+	require.Contains(t, dot, `"prefix:-src-ip-10.0.0.0/24" -> "ecmp:-src-ip-10.0.0.0/24";`)
+	require.Contains(t, dot, `"ecmp:-src-ip-10.0.0.0/24" -> "nexthop:192.168.0.1" [label="bfd", style=bold];`)
+	require.Contains(t, dot, `"ecmp:-src-ip-10.0.0.0/24" -> "nexthop:192.168.0.2" [label="bfd", style=bold];`)
+
+	// single nexthops are linked directly and shared by the prefixes
+	require.Contains(t, dot, `"prefix:-dst-ip-0.0.0.0/0" -> "nexthop:192.168.0.1";`)
+	require.Contains(t, dot, `"prefix:rtb-dst-ip-10.1.0.0/16" -> "nexthop:192.168.0.3";`)
+	require.Equal(t, 1, strings.Count(dot, `"nexthop:192.168.0.1" [shape=`))
+	require.NotContains(t, dot, `"ecmp:-dst-ip-0.0.0.0/0"`)
+
+	// the output is stable
+	again, err := nbClient.ExportRouterRoutesDOT(lrName)
+	require.NoError(t, err)
+	require.Equal(t, dot, again)
+
+	_, err = nbClient.ExportRouterRoutesDOT("test-export-routes-dot-non-existent-lr")
+	require.Error(t, err)
+}
+
+func (suite *OvnClientTestSuite) testAddLogicalRouterStaticRouteEmptyNexthops() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-add-route-empty-nexthops-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefix := "10.128.0.0/24"
+	otherPrefix := "10.128.1.0/24"
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	addRoutes := func() {
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, "192.168.128.1", "192.168.128.2")
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, otherPrefix, nil, nil, "192.168.128.1")
+		require.NoError(t, err)
+	}
+	countRoutes := func(prefix string) int {
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
+		require.NoError(t, err)
+		return len(routes)
+	}
+
+	t.Run("reject empty nexthops", func(t *testing.T) {
+		addRoutes()
+		client := *nbClient
+		client.rejectEmptyStaticRouteNexthops = true
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil)
+		require.ErrorIs(t, err, ErrNoStaticRouteNexthop)
+		require.Equal(t, 2, countRoutes(ipPrefix))
+
+		err = nbClient.AddLogicalRouterStaticRouteWithBFDs(lrName, routeTable, policy, ipPrefix, nil, nil)
+		require.ErrorIs(t, err, ErrNoStaticRouteNexthop)
 	})
 
-	t.Run("with empty policy and existing route", func(t *testing.T) {
-		ipPrefix := "192.168.120.0/24"
-		nexthop := "192.168.120.1"
+	t.Run("empty nexthops clear the prefix", func(t *testing.T) {
+		addRoutes()
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil)
+		require.NoError(t, err)
+		require.Zero(t, countRoutes(ipPrefix))
+		require.Equal(t, 1, countRoutes(otherPrefix))
+	})
 
-		route1, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, "", ipPrefix, nexthop, nil, nil)
+	t.Run("clear routes by prefix", func(t *testing.T) {
+		addRoutes()
+		err := nbClient.ClearLogicalRouterStaticRoutesByPrefix(lrName, routeTable, "", ipPrefix)
 		require.NoError(t, err)
-		err = nbClient.CreateLogicalRouterStaticRoutes(lrName, route1)
+		require.Zero(t, countRoutes(ipPrefix))
+		require.Equal(t, 1, countRoutes(otherPrefix))
+
+		// clearing a prefix without routes is a no-op
+		err = nbClient.ClearLogicalRouterStaticRoutesByPrefix(lrName, routeTable, policy, ipPrefix)
 		require.NoError(t, err)
 
-		route2, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, "", ipPrefix, nexthop, nil, nil)
-		require.Nil(t, route2)
+		err = nbClient.ClearLogicalRouterStaticRoutesByPrefix("test-add-route-empty-nexthops-non-existent-lr", routeTable, policy, ipPrefix)
+		require.Error(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testStaticRouteDescription() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-static-route-description-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("external ids", func(t *testing.T) {
+		externalIDs := map[string]string{"vendor": util.CniTypeName}
+		withDescription := StaticRouteDescriptionExternalIDs(externalIDs, "uplink")
+		require.Equal(t, map[string]string{"vendor": util.CniTypeName, ExternalIDDescription: "uplink"}, withDescription)
+		require.Equal(t, map[string]string{"vendor": util.CniTypeName}, externalIDs)
+		require.Equal(t, externalIDs, StaticRouteDescriptionExternalIDs(withDescription, ""))
+		require.Equal(t, map[string]string{ExternalIDDescription: "uplink"}, StaticRouteDescriptionExternalIDs(nil, "uplink"))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		externalIDs := map[string]string{"vendor": util.CniTypeName}
+		err := nbClient.CreateLogicalRouterStaticRoute(lrName, routeTable, policy, "10.129.0.0/24", "192.168.129.1", nil, externalIDs, WithStaticRouteDescription("to the uplink"))
+		require.NoError(t, err)
+		require.Len(t, externalIDs, 1)
+
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.129.1.0/24", nil, StaticRouteDescriptionExternalIDs(nil, "to the backup"), "192.168.129.2")
+		require.NoError(t, err)
+
+		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.129.0.0/24", "192.168.129.1", false)
+		require.NoError(t, err)
+		require.Equal(t, "to the uplink", route.ExternalIDs[ExternalIDDescription])
+		require.Equal(t, util.CniTypeName, route.ExternalIDs["vendor"])
+
+		lines, err := nbClient.FormatLogicalRouterStaticRoutes(lrName)
 		require.NoError(t, err)
+		require.Equal(t, []string{
+			"main dst-ip 10.129.0.0/24 via 192.168.129.1 # to the uplink",
+			"main dst-ip 10.129.1.0/24 via 192.168.129.2 # to the backup",
+		}, lines)
+
+		err = nbClient.CreateLogicalRouterStaticRoute("", routeTable, policy, "10.129.2.0/24", "192.168.129.3", nil, nil, WithStaticRouteDescription("no router"))
+		require.Error(t, err)
 	})
 
-	t.Run("with bfd but no options", func(t *testing.T) {
-		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-		ipPrefix := "192.168.130.0/24"
-		nexthop := "192.168.130.1"
-		bfdID := "test-bfd-2"
+	t.Run("description does not affect diffing", func(t *testing.T) {
+		before, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.129.0.0/24", "192.168.129.1", false)
+		require.NoError(t, err)
 
-		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, &bfdID, nil)
+		desired := []*ovnnb.LogicalRouterStaticRoute{
+			{Policy: &policy, RouteTable: routeTable, IPPrefix: "10.129.0.0/24", Nexthop: "192.168.129.1", ExternalIDs: StaticRouteDescriptionExternalIDs(nil, "renamed")},
+			{Policy: &policy, RouteTable: routeTable, IPPrefix: "10.129.1.0/24", Nexthop: "192.168.129.2"},
+		}
+		err = nbClient.ReconcileStaticRoutes(lrName, desired, ReconcileOpts{})
+		require.NoError(t, err)
+		_, err = nbClient.ReconcileRoutersStaticRoutes(map[string][]*ovnnb.LogicalRouterStaticRoute{lrName: desired})
 		require.NoError(t, err)
-		require.Equal(t, "true", route.Options[util.StaticRouteBfdEcmp])
-		require.Equal(t, &bfdID, route.BFD)
-	})
 
-	t.Run("with empty logical switch name", func(t *testing.T) {
-		policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-		ipPrefix := "192.168.130.0/24"
-		nexthop := "192.168.130.1"
-		bfdID := "test-bfd-2"
+		after, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.129.0.0/24", "192.168.129.1", false)
+		require.NoError(t, err)
+		require.Equal(t, before.UUID, after.UUID)
+		require.Equal(t, "to the uplink", after.ExternalIDs[ExternalIDDescription])
 
-		route, err := nbClient.newLogicalRouterStaticRoute("", routeTable, policy, ipPrefix, nexthop, &bfdID, nil)
-		require.ErrorContains(t, err, "the logical router name is required")
-		require.Nil(t, route)
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
 	})
 }
 
-func (suite *OvnClientTestSuite) testListLogicalRouterStaticRoutesByOption() {
+func (suite *OvnClientTestSuite) testReportUnreachableRouteTables() {
 	t := suite.T()
 	t.Parallel()
 
 	nbClient := suite.ovnNBClient
-	lrName := "test-list-routes-by-option-lr"
-	routeTable := util.MainRouteTable
+	failedNbClient := suite.failedOvnNBClient
+	lrName := "test-report-unreachable-route-tables-lr"
+	lrpName := "test-report-unreachable-route-tables-lrp"
 	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-	ipPrefixes := []string{"192.168.30.0/24", "192.168.40.0/24", "192.168.50.0/24"}
-	nexthops := []string{"192.168.30.1", "192.168.40.1", "192.168.50.1"}
-	routes := make([]*ovnnb.LogicalRouterStaticRoute, 0, 5)
 
 	err := nbClient.CreateLogicalRouter(lrName)
 	require.NoError(t, err)
+	err = nbClient.AddLogicalRouterPolicy(lrName, 31000, "ip4.src == 10.140.10.0/24", ovnnb.LogicalRouterPolicyActionReroute, []string{"192.168.140.254"}, nil, nil)
+	require.NoError(t, err)
+	policies, err := nbClient.GetLogicalRouterPolicy(lrName, 31000, "ip4.src == 10.140.10.0/24", false)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	lrPolicy := policies[0]
+	lrPolicy.Options = map[string]string{"route_table": "rtb1"}
+	err = nbClient.UpdateLogicalRouterPolicy(lrPolicy, &lrPolicy.Options)
+	require.NoError(t, err)
+	// the subnet of the port selects its route table by the route_table option of the port
+	err = nbClient.CreateLogicalRouterPort(lrName, lrpName, "00:11:22:37:af:80", []string{"192.168.140.1/24"})
+	require.NoError(t, err)
+	err = nbClient.UpdateLogicalRouterPortOptions(lrpName, map[string]string{"route_table": "rtb3"})
+	require.NoError(t, err)
 
-	for i, ipPrefix := range ipPrefixes {
-		var bfdID *string
-		if ipPrefixes[i] == "192.168.30.0/24" {
-			bfdIDValue := "bfd-id"
-			bfdID = &bfdIDValue
+	report, err := nbClient.ReportUnreachableRouteTables(lrName)
+	require.NoError(t, err)
+	require.Empty(t, report)
+
+	for routeTable, prefixes := range map[string][]string{
+		util.MainRouteTable: {"10.140.0.0/24"},
+		"rtb1":              {"10.140.1.0/24"},
+		"rtb2":              {"10.140.3.0/24", "10.140.2.0/24"},
+		"rtb3":              {"10.140.4.0/24"},
+		"rtb4":              {"10.140.6.0/24"},
+	} {
+		for _, prefix := range prefixes {
+			err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, prefix, nil, nil, "192.168.140.254")
+			require.NoError(t, err)
 		}
-		route, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[i], bfdID, nil)
-		require.NoError(t, err)
-
-		routes = append(routes, route)
 	}
+	// routes disabled on purpose are not reported
+	err = nbClient.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, policy, "10.140.5.0/24", nil, nil, "192.168.140.254")
+	require.NoError(t, err)
+	err = nbClient.DisableStaticRoute(lrName, util.MainRouteTable, policy, "10.140.5.0/24", "192.168.140.254")
+	require.NoError(t, err)
 
-	err = nbClient.CreateLogicalRouterStaticRoutes(lrName, append(routes, nil)...)
+	report, err = nbClient.ReportUnreachableRouteTables(lrName)
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+	require.Equal(t, "rtb2", report[0].RouteTable)
+	require.Len(t, report[0].Routes, 2)
+	require.Equal(t, "10.140.2.0/24", report[0].Routes[0].IPPrefix)
+	require.Equal(t, "10.140.3.0/24", report[0].Routes[1].IPPrefix)
+	require.Equal(t, "rtb4", report[1].RouteTable)
+	require.Len(t, report[1].Routes, 1)
+
+	// the route table is reachable once referenced by a policy
+	lrPolicy.Options = map[string]string{"route_table": "rtb2"}
+	err = nbClient.UpdateLogicalRouterPolicy(lrPolicy, &lrPolicy.Options)
 	require.NoError(t, err)
+	report, err = nbClient.ReportUnreachableRouteTables(lrName)
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+	require.Equal(t, []string{"rtb1", "rtb4"}, []string{report[0].RouteTable, report[1].RouteTable})
 
-	t.Run("match single route with option", func(t *testing.T) {
-		result, err := nbClient.ListLogicalRouterStaticRoutesByOption(lrName, "", util.StaticRouteBfdEcmp, "true")
-		require.NoError(t, err)
-		require.Len(t, result, 1)
-		require.Equal(t, "192.168.30.0/24", result[0].IPPrefix)
-	})
+	// or once referenced by a port
+	err = nbClient.UpdateLogicalRouterPortOptions(lrpName, map[string]string{"route_table": "rtb4"})
+	require.NoError(t, err)
+	report, err = nbClient.ReportUnreachableRouteTables(lrName)
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+	require.Equal(t, []string{"rtb1", "rtb3"}, []string{report[0].RouteTable, report[1].RouteTable})
+
+	_, err = nbClient.ReportUnreachableRouteTables("test-report-unreachable-route-tables-non-existent-lr")
+	require.Error(t, err)
+	_, err = failedNbClient.ReportUnreachableRouteTables(lrName)
+	require.Error(t, err)
 }
 
-func (suite *OvnClientTestSuite) testUpdateLogicalRouterStaticRoute() {
+func (suite *OvnClientTestSuite) testAddLogicalRouterStaticRouteNexthopHostname() {
 	t := suite.T()
 	t.Parallel()
 
-	nbClient := suite.ovnNBClient
-	lrName := "test-update-route-lr"
+	lrName := "test-add-route-nexthop-hostname-lr"
 	routeTable := util.MainRouteTable
 	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-	ipPrefix := "192.168.30.0/24"
-	nexthop := "192.168.30.1"
-
-	err := nbClient.CreateLogicalRouter(lrName)
+	ipPrefix := "10.130.0.0/24"
+	hostname := "upstream.example.com"
+
+	client := *suite.ovnNBClient
+	client.nexthopHostnameResolver = func(host string) ([]string, error) {
+		switch host {
+		case hostname:
+			return []string{"192.168.130.2", "192.168.130.1", "fd00:130::1"}, nil
+		case "v6.example.com":
+			return []string{"fd00:130::1"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	err := client.CreateLogicalRouter(lrName)
 	require.NoError(t, err)
 
-	t.Run("update nil route", func(t *testing.T) {
-		err := nbClient.UpdateLogicalRouterStaticRoute(nil)
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "route is nil")
-	})
-
-	t.Run("update route fields", func(t *testing.T) {
-		err := nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+	t.Run("hostname resolved to ecmp routes", func(t *testing.T) {
+		externalIDs := map[string]string{"vendor": util.CniTypeName}
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, externalIDs, hostname)
 		require.NoError(t, err)
+		require.Len(t, externalIDs, 1)
 
-		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, ipPrefix, nil)
 		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		nexthops := make([]string, 0, len(routes))
+		for _, route := range routes {
+			nexthops = append(nexthops, route.Nexthop)
+			require.Equal(t, hostname, route.ExternalIDs[ExternalIDNexthopHostname])
+			require.Equal(t, util.CniTypeName, route.ExternalIDs["vendor"])
+		}
+		require.ElementsMatch(t, []string{"192.168.130.1", "192.168.130.2"}, nexthops)
+	})
 
-		newPolicy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
-		route.Policy = &newPolicy
-		newNexthop := "192.168.30.254"
-		route.Nexthop = newNexthop
+	t.Run("resolution failure", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.130.1.0/24", nil, nil, "unknown.example.com")
+		require.ErrorIs(t, err, ErrNexthopHostnameUnresolved)
+		err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.130.1.0/24", nil, nil, "v6.example.com")
+		require.ErrorIs(t, err, ErrNexthopHostnameUnresolved)
 
-		err = nbClient.UpdateLogicalRouterStaticRoute(route, &route.Policy, &route.Nexthop)
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, "10.130.1.0/24", nil)
 		require.NoError(t, err)
+		require.Empty(t, routes)
+	})
 
-		updatedRoute, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, newPolicy, ipPrefix, newNexthop, false)
-		require.NoError(t, err)
-		require.Equal(t, newPolicy, *updatedRoute.Policy)
-		require.Equal(t, newNexthop, updatedRoute.Nexthop)
+	t.Run("hostname mixed with ip nexthops", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.130.2.0/24", nil, nil, hostname, "192.168.130.3")
+		require.Error(t, err)
 	})
 }
 
-func (suite *OvnClientTestSuite) testGetLogicalRouterStaticRouteEdgeCases() {
+func (suite *OvnClientTestSuite) testRefreshHostnameRoutes() {
 	t := suite.T()
 	t.Parallel()
 
-	nbClient := suite.ovnNBClient
-	lrName := "test-get-route-edge-lr"
+	lrName := "test-refresh-hostname-routes-lr"
 	routeTable := util.MainRouteTable
 	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-
-	err := nbClient.CreateLogicalRouter(lrName)
+	ipPrefix := "10.131.0.0/24"
+	hostname := "upstream.example.com"
+
+	var mutex sync.Mutex
+	records := map[string][]string{hostname: {"192.168.131.1", "192.168.131.2"}}
+	setRecords := func(host string, addrs ...string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		records[host] = addrs
+	}
+	client := *suite.ovnNBClient
+	client.nexthopHostnameResolver = func(host string) ([]string, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if addrs, ok := records[host]; ok {
+			return addrs, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, map[string]string{"vendor": util.CniTypeName}, hostname)
+	require.NoError(t, err)
+	err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.131.1.0/24", nil, nil, "192.168.131.9")
 	require.NoError(t, err)
 
-	t.Run("empty logical router name", func(t *testing.T) {
-		_, err := nbClient.GetLogicalRouterStaticRoute("", routeTable, policy, "192.168.1.0/24", "192.168.1.1", false)
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "the logical router name is required")
-	})
-
-	t.Run("duplicate routes", func(t *testing.T) {
-		ipPrefix := "192.168.2.0/24"
-		nexthop := "192.168.2.1"
-
-		route1, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, nil)
+	listNexthops := func(prefix string) map[string]*ovnnb.LogicalRouterStaticRoute {
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, &routeTable, &policy, prefix, nil)
 		require.NoError(t, err)
-		route2, err := nbClient.newLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, nil, nil)
+		nexthops := make(map[string]*ovnnb.LogicalRouterStaticRoute, len(routes))
+		for _, route := range routes {
+			nexthops[route.Nexthop] = route
+		}
+		return nexthops
+	}
+
+	t.Run("dns change adds and removes routes", func(t *testing.T) {
+		before := listNexthops(ipPrefix)
+		require.Len(t, before, 2)
+		route := *before["192.168.131.2"]
+		route.Options = map[string]string{"origin": "test"}
+		err := client.UpdateLogicalRouterStaticRoute(&route, &route.Options)
 		require.NoError(t, err)
 
-		err = nbClient.CreateLogicalRouterStaticRoutes(lrName, route1, route2)
+		setRecords(hostname, "192.168.131.2", "192.168.131.3")
+		err = client.RefreshHostnameRoutes(lrName, "")
 		require.NoError(t, err)
 
-		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "more than one static route")
+		after := listNexthops(ipPrefix)
+		require.Len(t, after, 2)
+		require.NotContains(t, after, "192.168.131.1")
+		require.Equal(t, before["192.168.131.2"].UUID, after["192.168.131.2"].UUID)
+		require.Equal(t, "test", after["192.168.131.2"].Options["origin"])
+		require.Equal(t, "test", after["192.168.131.3"].Options["origin"])
+		require.Equal(t, hostname, after["192.168.131.3"].ExternalIDs[ExternalIDNexthopHostname])
+		require.Equal(t, util.CniTypeName, after["192.168.131.3"].ExternalIDs["vendor"])
+		require.Len(t, listNexthops("10.131.1.0/24"), 1)
 	})
 
-	t.Run("ignore not found true", func(t *testing.T) {
-		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "192.168.3.0/24", "192.168.3.1", true)
+	t.Run("unchanged dns", func(t *testing.T) {
+		before := listNexthops(ipPrefix)
+		err := client.RefreshHostnameRoutes(lrName, ExternalIDNexthopHostname)
 		require.NoError(t, err)
-		require.Nil(t, route)
+		require.Equal(t, before, listNexthops(ipPrefix))
 	})
 
-	t.Run("empty route table", func(t *testing.T) {
-		ipPrefix := "192.168.4.0/24"
-		nexthop := "192.168.4.1"
+	t.Run("dns change of bfd protected routes", func(t *testing.T) {
+		lrpName := "test-refresh-hostname-routes-lrp"
+		bfdPrefix := "10.131.2.0/24"
+		bfdHostname := "bfd.example.com"
+		bfd, err := client.CreateBFD(lrpName, "192.168.131.11", 100, 200, 3, nil)
+		require.NoError(t, err)
+		err = client.CreateLogicalRouterStaticRoutes(lrName, &ovnnb.LogicalRouterStaticRoute{
+			UUID:        ovsclient.NamedUUID(),
+			Policy:      &policy,
+			IPPrefix:    bfdPrefix,
+			Nexthop:     "192.168.131.11",
+			BFD:         &bfd.UUID,
+			Options:     map[string]string{util.StaticRouteBfdEcmp: "true"},
+			ExternalIDs: map[string]string{ExternalIDNexthopHostname: bfdHostname},
+		})
+		require.NoError(t, err)
 
-		err := nbClient.AddLogicalRouterStaticRoute(lrName, "", policy, ipPrefix, nil, nil, nexthop)
+		setRecords(bfdHostname, "192.168.131.11", "192.168.131.12")
+		err = client.RefreshHostnameRoutes(lrName, "")
 		require.NoError(t, err)
 
-		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, "", policy, ipPrefix, nexthop, false)
+		after := listNexthops(bfdPrefix)
+		require.Len(t, after, 2)
+		require.Equal(t, bfd.UUID, *after["192.168.131.11"].BFD)
+		added := after["192.168.131.12"]
+		require.NotNil(t, added.BFD)
+		require.Equal(t, "true", added.Options[util.StaticRouteBfdEcmp])
+		bfdList, err := client.ListBFDs(lrpName, "192.168.131.12")
 		require.NoError(t, err)
-		require.Equal(t, "", route.RouteTable)
+		require.Len(t, bfdList, 1)
+		require.Equal(t, bfdList[0].UUID, *added.BFD)
+		require.Equal(t, bfd.MinRx, bfdList[0].MinRx)
+		require.Equal(t, bfd.MinTx, bfdList[0].MinTx)
+	})
+
+	t.Run("resolution failure keeps routes", func(t *testing.T) {
+		before := listNexthops(ipPrefix)
+		mutex.Lock()
+		delete(records, hostname)
+		mutex.Unlock()
+		err := client.RefreshHostnameRoutes(lrName, "")
+		require.ErrorIs(t, err, ErrNexthopHostnameUnresolved)
+		require.Equal(t, before, listNexthops(ipPrefix))
+	})
+
+	t.Run("no resolver", func(t *testing.T) {
+		err := suite.ovnNBClient.RefreshHostnameRoutes(lrName, "")
+		require.Error(t, err)
 	})
 }
 
-func (suite *OvnClientTestSuite) testBatchDeleteLogicalRouterStaticRoute() {
+func (suite *OvnClientTestSuite) testApplyDesiredStaticRoutes() {
 	t := suite.T()
 	t.Parallel()
 
-	nbClient := suite.ovnNBClient
-	lrName := "test-batch-del-route-lr"
+	lrName := "test-apply-desired-routes-lr"
 	routeTable := util.MainRouteTable
+	otherTable := "apply-desired-rtb"
 	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
-	ipPrefix := "192.168.30.0/24"
-	nexthop := "192.168.30.1"
-	staticRouter := &ovnnb.LogicalRouterStaticRoute{
-		Policy:     &policy,
-		IPPrefix:   ipPrefix,
-		Nexthop:    nexthop,
-		RouteTable: routeTable,
+	srcPolicy := ovnnb.LogicalRouterStaticRoutePolicySrcIP
+
+	var methods []string
+	client := *suite.ovnNBClient
+	client.postTransact = func(method string, _ []*ovnnb.LogicalRouterStaticRoute) {
+		methods = append(methods, method)
 	}
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
 
-	err := nbClient.CreateLogicalRouter(lrName)
+	err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.132.0.0/24", nil, nil, "192.168.132.1", "192.168.132.2")
+	require.NoError(t, err)
+	err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.132.1.0/24", nil, map[string]string{"vendor": util.CniTypeName}, "192.168.132.1")
+	require.NoError(t, err)
+	err = client.AddLogicalRouterStaticRoute(lrName, otherTable, srcPolicy, "10.132.2.0/24", nil, nil, "192.168.132.3")
+	require.NoError(t, err)
+	unchanged, err := client.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.132.0.0/24", "192.168.132.1", false)
+	require.NoError(t, err)
+	updated, err := client.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.132.1.0/24", "192.168.132.1", false)
 	require.NoError(t, err)
 
-	t.Run("normal static route", func(t *testing.T) {
-		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthop)
+	desired := []*ovnnb.LogicalRouterStaticRoute{
+		// unchanged
+		{Policy: &policy, RouteTable: routeTable, IPPrefix: "10.132.0.0/24", Nexthop: "192.168.132.1"},
+		// nexthop 192.168.132.2 is removed and 192.168.132.4 is added
+		{Policy: &policy, RouteTable: routeTable, IPPrefix: "10.132.0.0/24", Nexthop: "192.168.132.4"},
+		// external ids and options updated in place
+		{Policy: &policy, RouteTable: routeTable, IPPrefix: "10.132.1.0/24", Nexthop: "192.168.132.1", ExternalIDs: map[string]string{"vendor": "other"}, Options: map[string]string{"origin": "test"}},
+		// new route of another table, while the src-ip route of the table is removed
+		{Policy: &policy, RouteTable: otherTable, IPPrefix: "10.132.3.0/24", Nexthop: "192.168.132.5"},
+		// duplicate
+		{Policy: &policy, RouteTable: otherTable, IPPrefix: "10.132.3.0/24", Nexthop: "192.168.132.5"},
+		nil,
+	}
+
+	t.Run("mixed desired state converges in one transaction", func(t *testing.T) {
+		methods = nil
+		err := client.ApplyDesiredStaticRoutes(lrName, desired)
 		require.NoError(t, err)
+		require.Equal(t, []string{"lr-routes-reconcile"}, methods)
 
-		lr, err := nbClient.GetLogicalRouter(lrName, false)
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(routes))
+		for _, route := range routes {
+			ids = append(ids, fmt.Sprintf("%s %s %s %s", route.RouteTable, staticRoutePolicy(route), route.IPPrefix, route.Nexthop))
+		}
+		require.ElementsMatch(t, []string{
+			" dst-ip 10.132.0.0/24 192.168.132.1",
+			" dst-ip 10.132.0.0/24 192.168.132.4",
+			" dst-ip 10.132.1.0/24 192.168.132.1",
+			"apply-desired-rtb dst-ip 10.132.3.0/24 192.168.132.5",
+		}, ids)
+
+		route, err := client.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.132.0.0/24", "192.168.132.1", false)
+		require.NoError(t, err)
+		require.Equal(t, unchanged.UUID, route.UUID)
+		route, err = client.GetLogicalRouterStaticRoute(lrName, routeTable, policy, "10.132.1.0/24", "192.168.132.1", false)
 		require.NoError(t, err)
+		require.Equal(t, updated.UUID, route.UUID)
+		require.Equal(t, map[string]string{"vendor": "other"}, route.ExternalIDs)
+		require.Equal(t, map[string]string{"origin": "test"}, route.Options)
+	})
 
-		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
+	t.Run("converged state is not changed", func(t *testing.T) {
+		methods = nil
+		err := client.ApplyDesiredStaticRoutes(lrName, desired)
 		require.NoError(t, err)
-		require.Contains(t, lr.StaticRoutes, route.UUID)
+		require.Empty(t, methods)
+	})
 
-		err = nbClient.BatchDeleteLogicalRouterStaticRoute(lrName, []*ovnnb.LogicalRouterStaticRoute{staticRouter})
+	t.Run("empty desired state clears the router", func(t *testing.T) {
+		methods = nil
+		err := client.ApplyDesiredStaticRoutes(lrName, nil)
 		require.NoError(t, err)
+		require.Equal(t, []string{"lr-routes-reconcile"}, methods)
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
+		require.NoError(t, err)
+		require.Empty(t, routes)
+	})
+}
 
-		lr, err = nbClient.GetLogicalRouter(lrName, false)
+type fakeStaticRouteAuditSink struct {
+	mutex   sync.Mutex
+	records []StaticRouteAuditRecord
+}
+
+func (s *fakeStaticRouteAuditSink) RecordStaticRoutes(records []StaticRouteAuditRecord) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.records = append(s.records, records...)
+}
+
+// pop returns the records with the time cleared and resets the sink
+func (s *fakeStaticRouteAuditSink) pop() []StaticRouteAuditRecord {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	records := s.records
+	s.records = nil
+	for i := range records {
+		records[i].Time = time.Time{}
+	}
+	return records
+}
+
+func (suite *OvnClientTestSuite) testStaticRouteAuditSink() {
+	t := suite.T()
+	t.Parallel()
+
+	lrName := "test-static-route-audit-lr"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	ipPrefix := "10.133.0.0/24"
+	externalIDs := map[string]string{ExternalIDOwner: "test-owner"}
+
+	sink := &fakeStaticRouteAuditSink{}
+	client := *suite.ovnNBClient
+	client.staticRouteAuditSink = sink
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	// routeUUID returns the uuid of the route of the nexthop
+	routeUUID := func(nexthop string) string {
+		route, err := client.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
 		require.NoError(t, err)
-		require.Empty(t, lr.StaticRoutes)
+		return route.UUID
+	}
+	newRecord := func(operation, method, uuid, nexthop string) StaticRouteAuditRecord {
+		return StaticRouteAuditRecord{
+			Operation:  operation,
+			Method:     method,
+			Router:     lrName,
+			UUID:       uuid,
+			RouteTable: routeTable,
+			Policy:     policy,
+			IPPrefix:   ipPrefix,
+			Nexthop:    nexthop,
+			Owner:      "test-owner",
+		}
+	}
 
-		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
-		require.ErrorContains(t, err, "not found")
+	t.Run("add", func(t *testing.T) {
+		start := time.Now()
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, externalIDs, "192.168.133.1", "192.168.133.2")
+		require.NoError(t, err)
+
+		sink.mutex.Lock()
+		require.Len(t, sink.records, 2)
+		for _, record := range sink.records {
+			require.False(t, record.Time.Before(start))
+		}
+		sink.mutex.Unlock()
+		// the added routes are recorded with the uuids of the inserted rows
+		require.ElementsMatch(t, []StaticRouteAuditRecord{
+			newRecord(StaticRouteAuditAdd, "lr-routes-add", routeUUID("192.168.133.1"), "192.168.133.1"),
+			newRecord(StaticRouteAuditAdd, "lr-routes-add", routeUUID("192.168.133.2"), "192.168.133.2"),
+		}, sink.pop())
 	})
 
-	t.Run("delete non-exist static route", func(t *testing.T) {
-		staticRouter.IPPrefix = "192.168.40.0/24"
-		staticRouter.Nexthop = "192.168.40.1"
-		err = nbClient.BatchDeleteLogicalRouterStaticRoute(lrName, []*ovnnb.LogicalRouterStaticRoute{staticRouter})
+	t.Run("delete", func(t *testing.T) {
+		uuid := routeUUID("192.168.133.1")
+		err := client.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, "192.168.133.1")
 		require.NoError(t, err)
+		require.Equal(t, []StaticRouteAuditRecord{newRecord(StaticRouteAuditDelete, "lr-route-del", uuid, "192.168.133.1")}, sink.pop())
 	})
 
-	t.Run("delete ecmp policy route", func(t *testing.T) {
-		ipPrefix := "192.168.40.0/24"
-		nexthops := []string{"192.168.50.1", "192.168.60.1"}
-		staticRouter.IPPrefix = ipPrefix
+	t.Run("replace nexthop", func(t *testing.T) {
+		uuid := routeUUID("192.168.133.2")
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, externalIDs, "192.168.133.3")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []StaticRouteAuditRecord{
+			newRecord(StaticRouteAuditDelete, "lr-route-del", uuid, "192.168.133.2"),
+			newRecord(StaticRouteAuditAdd, "lr-routes-add", routeUUID("192.168.133.3"), "192.168.133.3"),
+		}, sink.pop())
+	})
 
-		err = nbClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, nil, nexthops...)
+	t.Run("update is not recorded", func(t *testing.T) {
+		route, err := client.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, "192.168.133.3", false)
 		require.NoError(t, err)
+		route.Options = map[string]string{"origin": "test"}
+		err = client.UpdateLogicalRouterStaticRoute(route, &route.Options)
+		require.NoError(t, err)
+		require.Empty(t, sink.pop())
+	})
 
-		lr, err := nbClient.GetLogicalRouter(lrName, false)
+	t.Run("clear", func(t *testing.T) {
+		uuid := routeUUID("192.168.133.3")
+		err := client.ClearLogicalRouterStaticRoute(lrName)
 		require.NoError(t, err)
+		require.Equal(t, []StaticRouteAuditRecord{newRecord(StaticRouteAuditDelete, "lr-route-clear", uuid, "192.168.133.3")}, sink.pop())
+	})
 
-		for _, nexthop := range nexthops {
-			route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthop, false)
-			require.NoError(t, err)
-			require.Contains(t, lr.StaticRoutes, route.UUID)
-		}
+	t.Run("no sink", func(t *testing.T) {
+		err := suite.ovnNBClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nil, externalIDs, "192.168.133.4")
+		require.NoError(t, err)
+		require.Empty(t, sink.pop())
+	})
+}
 
-		/* delete first route */
-		staticRouter.Nexthop = nexthops[0]
-		err = nbClient.BatchDeleteLogicalRouterStaticRoute(lrName, []*ovnnb.LogicalRouterStaticRoute{staticRouter})
+func (suite *OvnClientTestSuite) testRejectSelfNexthopStaticRoutes() {
+	t := suite.T()
+	t.Parallel()
+
+	lrName := "test-reject-self-nexthop-lr"
+	lrpName := "test-reject-self-nexthop-lrp"
+	routeTable := util.MainRouteTable
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	client := *suite.ovnNBClient
+	client.rejectSelfNexthopStaticRoutes = true
+	err := client.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+	err = client.CreateLogicalRouterPort(lrName, lrpName, "00:00:00:13:40:01", []string{"192.168.134.1/24", "fd00:134::1/64"})
+	require.NoError(t, err)
+
+	t.Run("nexthop of router port ip", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.134.0.0/24", nil, nil, "192.168.134.1")
+		require.ErrorIs(t, err, ErrStaticRouteSelfNexthop)
+		err = client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "fd00:135::/64", nil, nil, "fd00:134::1")
+		require.ErrorIs(t, err, ErrStaticRouteSelfNexthop)
+
+		routes, err := client.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
 		require.NoError(t, err)
+		require.Empty(t, routes)
+	})
 
-		lr, err = nbClient.GetLogicalRouter(lrName, false)
+	t.Run("nexthop of other ip", func(t *testing.T) {
+		err := client.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.134.0.0/24", nil, nil, "192.168.134.2")
 		require.NoError(t, err)
+		err = client.AddLogicalRouterBlackholeRoute(lrName, routeTable, "10.134.1.0/24", nil)
+		require.NoError(t, err)
+	})
 
-		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[0], false)
-		require.ErrorContains(t, err, `not found logical router test-batch-del-route-lr static route 'policy dst-ip ip_prefix 192.168.40.0/24 nexthop 192.168.50.1'`)
+	t.Run("validation disabled", func(t *testing.T) {
+		err := suite.ovnNBClient.AddLogicalRouterStaticRoute(lrName, routeTable, policy, "10.134.2.0/24", nil, nil, "192.168.134.1")
+		require.NoError(t, err)
+	})
+}
 
-		route, err := nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[1], false)
+func (suite *OvnClientTestSuite) testListLogicalRouterStaticRouteRefs() {
+	t := suite.T()
+	t.Parallel()
+
+	nbClient := suite.ovnNBClient
+	lrName := "test-list-route-refs-lr"
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+
+	err := nbClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("no routes", func(t *testing.T) {
+		refs, err := nbClient.ListLogicalRouterStaticRouteRefs(lrName)
 		require.NoError(t, err)
-		require.ElementsMatch(t, []string{route.UUID}, lr.StaticRoutes)
+		require.Empty(t, refs)
+	})
 
-		/* delete second route */
-		staticRouter.Nexthop = nexthops[1]
-		err = nbClient.DeleteLogicalRouterStaticRoute(lrName, &routeTable, &policy, ipPrefix, nexthops[1])
+	t.Run("refs match routes", func(t *testing.T) {
+		err := nbClient.AddLogicalRouterStaticRoute(lrName, util.MainRouteTable, policy, "10.136.0.0/24", nil, nil, "192.168.136.1", "192.168.136.2")
+		require.NoError(t, err)
+		err = nbClient.AddLogicalRouterStaticRoute(lrName, "refs-rtb", ovnnb.LogicalRouterStaticRoutePolicySrcIP, "10.136.1.0/24", nil, nil, "192.168.136.3")
 		require.NoError(t, err)
 
-		lr, err = nbClient.GetLogicalRouter(lrName, false)
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(lrName, nil, nil, "", nil)
 		require.NoError(t, err)
-		require.Empty(t, lr.StaticRoutes)
+		require.Len(t, routes, 3)
+		expected := make([]StaticRouteRef, 0, len(routes))
+		for _, route := range routes {
+			expected = append(expected, StaticRouteRef{
+				UUID:       route.UUID,
+				RouteTable: route.RouteTable,
+				Policy:     *route.Policy,
+				IPPrefix:   route.IPPrefix,
+				Nexthop:    route.Nexthop,
+			})
+		}
 
-		_, err = nbClient.GetLogicalRouterStaticRoute(lrName, routeTable, policy, ipPrefix, nexthops[1], false)
-		require.ErrorContains(t, err, `not found logical router test-batch-del-route-lr static route 'policy dst-ip ip_prefix 192.168.40.0/24 nexthop 192.168.60.1'`)
+		refs, err := nbClient.ListLogicalRouterStaticRouteRefs(lrName)
+		require.NoError(t, err)
+		require.ElementsMatch(t, expected, refs)
+		require.True(t, slices.IsSortedFunc(refs, func(a, b StaticRouteRef) int { return strings.Compare(a.UUID, b.UUID) }))
 	})
 
-	t.Run("delete static route for non-exist logical router", func(t *testing.T) {
-		err := nbClient.BatchDeleteLogicalRouterStaticRoute("non-exist-lrName", []*ovnnb.LogicalRouterStaticRoute{staticRouter})
-		require.NoError(t, err)
+	t.Run("nonexistent router", func(t *testing.T) {
+		_, err := nbClient.ListLogicalRouterStaticRouteRefs("test-list-route-refs-nonexistent-lr")
+		require.Error(t, err)
 	})
 }
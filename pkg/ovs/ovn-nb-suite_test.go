@@ -930,6 +930,146 @@ func (suite *OvnClientTestSuite) Test_AddLogicalRouterStaticRoute() {
 	suite.testAddLogicalRouterStaticRoute()
 }
 
+func (suite *OvnClientTestSuite) Test_AddLogicalRouterStaticRouteWithBFDs() {
+	suite.testAddLogicalRouterStaticRouteWithBFDs()
+}
+
+func (suite *OvnClientTestSuite) Test_FindConflictingStaticRoutes() {
+	suite.testFindConflictingStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ListAllLogicalRouterStaticRoutesSorted() {
+	suite.testListAllLogicalRouterStaticRoutesSorted()
+}
+
+func (suite *OvnClientTestSuite) Test_GetRouterRoutingSnapshot() {
+	suite.testGetRouterRoutingSnapshot()
+}
+
+func (suite *OvnClientTestSuite) Test_FindShadowedStaticRoutes() {
+	suite.testFindShadowedStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_FindInconsistentBFDRoutes() {
+	suite.testFindInconsistentBFDRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ListUnmanagedStaticRoutes() {
+	suite.testListUnmanagedStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ChunkLogicalRouterStaticRoutes() {
+	suite.testChunkLogicalRouterStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_DisableEnableStaticRoute() {
+	suite.testDisableEnableStaticRoute()
+}
+
+func (suite *OvnClientTestSuite) Test_SummarizeStaticRoutes() {
+	suite.testSummarizeStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ReconcileRoutersStaticRoutes() {
+	suite.testReconcileRoutersStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_FindStaticRouteNatConflicts() {
+	suite.testFindStaticRouteNatConflicts()
+}
+
+func (suite *OvnClientTestSuite) Test_DeterministicStaticRouteUUIDs() {
+	suite.testDeterministicStaticRouteUUIDs()
+}
+
+func (suite *OvnClientTestSuite) Test_ListLogicalRouterStaticRoutesByBFDState() {
+	suite.testListLogicalRouterStaticRoutesByBFDState()
+}
+
+func (suite *OvnClientTestSuite) Test_SingleNexthopBFDWithoutEcmp() {
+	suite.testSingleNexthopBFDWithoutEcmp()
+}
+
+func (suite *OvnClientTestSuite) Test_EnableBFDForECMPGroup() {
+	suite.testEnableBFDForECMPGroup()
+}
+
+func (suite *OvnClientTestSuite) Test_StaticRouteTransactHooks() {
+	suite.testStaticRouteTransactHooks()
+}
+
+func (suite *OvnClientTestSuite) Test_NamedTableDefaultRouteCheck() {
+	suite.testNamedTableDefaultRouteCheck()
+}
+
+func (suite *OvnClientTestSuite) Test_ListLogicalRouterStaticRoutesByExternalIDsAndOptions() {
+	suite.testListLogicalRouterStaticRoutesByExternalIDsAndOptions()
+}
+
+func (suite *OvnClientTestSuite) Test_ListLogicalRouterStaticRoutesOverlapping() {
+	suite.testListLogicalRouterStaticRoutesOverlapping()
+}
+
+func (suite *OvnClientTestSuite) Test_MaxStaticRoutesPerRouter() {
+	suite.testMaxStaticRoutesPerRouter()
+}
+
+func (suite *OvnClientTestSuite) Test_SetECMPHashMode() {
+	suite.testSetECMPHashMode()
+}
+
+func (suite *OvnClientTestSuite) Test_ReconcileStaticRoutes() {
+	suite.testReconcileStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ListLogicalRouterStaticRoutesChangedSince() {
+	suite.testListLogicalRouterStaticRoutesChangedSince()
+}
+
+func (suite *OvnClientTestSuite) Test_MixedBFDECMPGroup() {
+	suite.testMixedBFDECMPGroup()
+}
+
+func (suite *OvnClientTestSuite) Test_LogicalRouterCache() {
+	suite.testLogicalRouterCache()
+}
+
+func (suite *OvnClientTestSuite) Test_DeleteLogicalRouterStaticRoutesWhere() {
+	suite.testDeleteLogicalRouterStaticRoutesWhere()
+}
+
+func (suite *OvnClientTestSuite) Test_AddLogicalRouterConnectedRoute() {
+	suite.testAddLogicalRouterConnectedRoute()
+}
+
+func (suite *OvnClientTestSuite) Test_GetECMPWidths() {
+	suite.testGetECMPWidths()
+}
+
+func (suite *OvnClientTestSuite) Test_GetLogicalRouterStaticRoutesByNexthop() {
+	suite.testGetLogicalRouterStaticRoutesByNexthop()
+}
+
+func (suite *OvnClientTestSuite) Test_ImportLogicalRouterStaticRoutes() {
+	suite.testImportLogicalRouterStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_GetLogicalRouterStaticRoutesByUUIDs() {
+	suite.testGetLogicalRouterStaticRoutesByUUIDs()
+}
+
+func (suite *OvnClientTestSuite) Test_AddLogicalRouterBlackholeRoute() {
+	suite.testAddLogicalRouterBlackholeRoute()
+}
+
+func (suite *OvnClientTestSuite) Test_EnsureGatewayNatRoute() {
+	suite.testEnsureGatewayNatRoute()
+}
+
+func (suite *OvnClientTestSuite) Test_CheckRouteTableReachable() {
+	suite.testCheckRouteTableReachable()
+}
+
 func (suite *OvnClientTestSuite) TestDeleteLogicalRouterStaticRouteByUUID() {
 	suite.testDeleteLogicalRouterStaticRouteByUUID()
 }
@@ -938,6 +1078,18 @@ func (suite *OvnClientTestSuite) TestDeleteLogicalRouterStaticRouteByExternalIDs
 	suite.testDeleteLogicalRouterStaticRouteByExternalIDs()
 }
 
+func (suite *OvnClientTestSuite) Test_DeleteLogicalRouterStaticRoutesByOptions() {
+	suite.testDeleteLogicalRouterStaticRoutesByOptions()
+}
+
+func (suite *OvnClientTestSuite) Test_PruneStaticRoutesByGeneration() {
+	suite.testPruneStaticRoutesByGeneration()
+}
+
+func (suite *OvnClientTestSuite) Test_SweepExpiredStaticRoutes() {
+	suite.testSweepExpiredStaticRoutes()
+}
+
 func (suite *OvnClientTestSuite) Test_DeleteLogicalRouterStaticRoute() {
 	suite.testDeleteLogicalRouterStaticRoute()
 }
@@ -954,6 +1106,10 @@ func (suite *OvnClientTestSuite) Test_ListLogicalRouterStaticRoutes() {
 	suite.testListLogicalRouterStaticRoutes()
 }
 
+func (suite *OvnClientTestSuite) Test_ListLogicalRouterStaticRoutesWithoutPolicy() {
+	suite.testListLogicalRouterStaticRoutesWithoutPolicy()
+}
+
 func (suite *OvnClientTestSuite) Test_newLogicalRouterStaticRoute() {
 	suite.testNewLogicalRouterStaticRoute()
 }
@@ -1183,6 +1339,10 @@ func (suite *OvnClientTestSuite) Test_OvsExec() {
 	suite.testOvsExec()
 }
 
+func (suite *OvnClientTestSuite) Test_OvsExecTimeout() {
+	suite.testOvsExecTimeout()
+}
+
 func (suite *OvnClientTestSuite) Test_OvsCreate() {
 	suite.testOvsCreate()
 }
@@ -1463,3 +1623,67 @@ func newSbClient(addr string, timeout int) (client.Client, error) {
 
 	return c, nil
 }
+
+func (suite *OvnClientTestSuite) Test_GarbageCollectDanglingBFDRoutes() {
+	suite.testGarbageCollectDanglingBFDRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_TransactRoutersStaticRoutes() {
+	suite.testTransactRoutersStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ListAllStaticRoutes() {
+	suite.testListAllStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ReplaceGatewayNode() {
+	suite.testReplaceGatewayNode()
+}
+
+func (suite *OvnClientTestSuite) Test_ValidateStaticRoutesBatch() {
+	suite.testValidateStaticRoutesBatch()
+}
+
+func (suite *OvnClientTestSuite) Test_ListLogicalRouterStaticRoutesInPrefixRange() {
+	suite.testListLogicalRouterStaticRoutesInPrefixRange()
+}
+
+func (suite *OvnClientTestSuite) Test_ExportRouterRoutesDOT() {
+	suite.testExportRouterRoutesDOT()
+}
+
+func (suite *OvnClientTestSuite) Test_AddLogicalRouterStaticRouteEmptyNexthops() {
+	suite.testAddLogicalRouterStaticRouteEmptyNexthops()
+}
+
+func (suite *OvnClientTestSuite) Test_StaticRouteDescription() {
+	suite.testStaticRouteDescription()
+}
+
+func (suite *OvnClientTestSuite) Test_ReportUnreachableRouteTables() {
+	suite.testReportUnreachableRouteTables()
+}
+
+func (suite *OvnClientTestSuite) Test_AddLogicalRouterStaticRouteNexthopHostname() {
+	suite.testAddLogicalRouterStaticRouteNexthopHostname()
+}
+
+func (suite *OvnClientTestSuite) Test_RefreshHostnameRoutes() {
+	suite.testRefreshHostnameRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ApplyDesiredStaticRoutes() {
+	suite.testApplyDesiredStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_StaticRouteAuditSink() {
+	suite.testStaticRouteAuditSink()
+}
+
+func (suite *OvnClientTestSuite) Test_RejectSelfNexthopStaticRoutes() {
+	suite.testRejectSelfNexthopStaticRoutes()
+}
+
+func (suite *OvnClientTestSuite) Test_ListLogicalRouterStaticRouteRefs() {
+	suite.testListLogicalRouterStaticRouteRefs()
+}
@@ -26,8 +26,45 @@ type LegacyClient struct {
 type OVNNbClient struct {
 	ovsDbClient
 	ClusterRouter string
+	// routeTableValidator is optional, static routes are not validated against route tables if it is nil
+	routeTableValidator *RouteTableValidator
+	// StaticRouteCountWarner is optional, the static route count of logical routers is not checked if it is nil
+	StaticRouteCountWarner *StaticRouteCountWarner
+	// MaxStaticRoutesPerTransaction is optional, static routes are created or deleted in one transaction if it is not positive
+	MaxStaticRoutesPerTransaction int
+	// deterministicStaticRouteUUIDs skips creating the routes already present, concurrent creations are not deduplicated
+	deterministicStaticRouteUUIDs bool
+	// singleNexthopBFDWithoutEcmp omits ecmp_symmetric_reply from the bfd routes of single nexthop prefixes
+	singleNexthopBFDWithoutEcmp bool
+	// preTransact and postTransact are optional hooks called around each static route transaction
+	preTransact  StaticRouteTransactHook
+	postTransact StaticRouteTransactHook
+	// namedTableDefaultRouteCheck is optional, default routes in named route tables are allowed by default
+	namedTableDefaultRouteCheck NamedTableDefaultRouteCheck
+	// maxStaticRoutesPerRouter is optional, the static routes of a logical router are unlimited if it is not positive
+	maxStaticRoutesPerRouter int
+	// deleteDanglingBFDRoutes makes GarbageCollectDanglingBFDRoutes delete instead of clear dangling bfd routes
+	deleteDanglingBFDRoutes bool
+	// rejectEmptyStaticRouteNexthops makes AddLogicalRouterStaticRoute return ErrNoStaticRouteNexthop without nexthops
+	rejectEmptyStaticRouteNexthops bool
+	// nexthopHostnameResolver is optional, hostname nexthops are resolved to ecmp nexthops if it is set
+	nexthopHostnameResolver NexthopHostnameResolver
+	// rejectSelfNexthopStaticRoutes rejects static routes whose nexthops are addresses of the router ports
+	rejectSelfNexthopStaticRoutes bool
+	// staticRouteAuditSink is optional, added and deleted static routes are recorded to it after each transaction
+	staticRouteAuditSink StaticRouteAuditSink
+	// logicalRouterCache is set by EnableLogicalRouterCache
+	logicalRouterCache *LogicalRouterCache
+	// staticRouteVersions is set by MonitorStaticRouteVersions to record the versions of the changed static routes
+	staticRouteVersions *staticRouteVersions
 }
 
+// NexthopHostnameResolver resolves the hostname of a static route nexthop to ip addresses
+type NexthopHostnameResolver func(hostname string) ([]string, error)
+
+// StaticRouteTransactHook is called around the transactions of static routes
+type StaticRouteTransactHook func(method string, routes []*ovnnb.LogicalRouterStaticRoute)
+
 type OVNSbClient struct {
 	ovsDbClient
 }
@@ -46,8 +83,14 @@ const (
 
 	OVSDBWaitTimeout = 0
 
-	ExternalIDVendor           = "vendor"
-	ExternalIDVpcEgressGateway = "vpc-egress-gateway"
+	ExternalIDVendor             = "vendor"
+	ExternalIDVpcEgressGateway   = "vpc-egress-gateway"
+	ExternalIDOwner              = "owner"
+	ExternalIDDisabledRouteTable = "disabled-route-table"
+	ExternalIDDescription        = "description"
+	ExternalIDNexthopHostname    = "nexthop-hostname"
+
+	GatewayNatRouteOwner = "gateway-nat"
 )
 
 // NewLegacyClient init a legacy ovn client
@@ -188,9 +231,15 @@ func ConstructWaitForUniqueOperation(table, column string, value interface{}) ov
 }
 
 func (c *ovsDbClient) Transact(method string, operations []ovsdb.Operation) error {
+	_, err := c.transact(method, operations)
+	return err
+}
+
+// transact commits the operations like Transact and returns the results of the operations
+func (c *ovsDbClient) transact(method string, operations []ovsdb.Operation) ([]ovsdb.OperationResult, error) {
 	if len(operations) == 0 {
 		klog.V(6).Info("operations should not be empty")
-		return nil
+		return nil, nil
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
@@ -216,7 +265,7 @@ func (c *ovsDbClient) Transact(method string, operations []ovsdb.Operation) erro
 	if err != nil {
 		code = "1"
 		klog.Errorf("error occurred in transact with %s operations: %+v in %vms", dbType, operations, elapsed)
-		return err
+		return nil, err
 	}
 
 	if elapsed > 500 {
@@ -226,10 +275,10 @@ func (c *ovsDbClient) Transact(method string, operations []ovsdb.Operation) erro
 	errors, err := ovsdb.CheckOperationResults(results, operations)
 	if err != nil {
 		klog.Errorf("error occurred in transact with operations %+v with operation errors %+v: %v", operations, errors, err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return results, nil
 }
 
 // GetEntityInfo get entity info by column which is the index,
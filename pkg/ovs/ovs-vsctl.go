@@ -34,7 +34,23 @@ func UpdateOVSVsctlLimiter(c int32) {
 var podNetNsRegexp = regexp.MustCompile(`pod_netns="([^"]+)"`)
 
 func Exec(args ...string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	return ExecContext(context.Background(), args...)
+}
+
+// ExecWithTimeout runs ovs-vsctl and kills it if it does not finish within the timeout
+func ExecWithTimeout(timeout time.Duration, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return ExecContext(ctx, args...)
+}
+
+// ExecContext runs ovs-vsctl and kills it if the context is done before it finishes
+func ExecContext(ctx context.Context, args ...string) (string, error) {
+	return execContext(ctx, OvsVsCtl, append([]string{"--timeout=30"}, args...)...)
+}
+
+func execContext(ctx context.Context, command string, args ...string) (string, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
 	var (
@@ -45,18 +61,17 @@ func Exec(args ...string) (string, error) {
 		err          error
 	)
 
-	if err = limiter.Wait(ctx); err != nil {
-		klog.V(4).Infof("command %s %s waiting for execution timeout by concurrency limit of %d", OvsVsCtl, strings.Join(args, " "), limiter.Limit())
+	if err = limiter.Wait(waitCtx); err != nil {
+		klog.V(4).Infof("command %s %s waiting for execution timeout by concurrency limit of %d", command, strings.Join(args, " "), limiter.Limit())
 		return "", err
 	}
 	defer limiter.Done()
-	klog.V(4).Infof("command %s %s waiting for execution concurrency %d/%d", OvsVsCtl, strings.Join(args, " "), limiter.Current(), limiter.Limit())
+	klog.V(4).Infof("command %s %s waiting for execution concurrency %d/%d", command, strings.Join(args, " "), limiter.Current(), limiter.Limit())
 
 	start = time.Now()
-	args = append([]string{"--timeout=30"}, args...)
-	output, err = exec.Command(OvsVsCtl, args...).CombinedOutput()
+	output, err = exec.CommandContext(ctx, command, args...).CombinedOutput()
 	elapsed = float64((time.Since(start)) / time.Millisecond)
-	klog.V(4).Infof("command %s %s in %vms", OvsVsCtl, strings.Join(args, " "), elapsed)
+	klog.V(4).Infof("command %s %s in %vms", command, strings.Join(args, " "), elapsed)
 
 	for _, arg := range args {
 		if !strings.HasPrefix(arg, "--") {
@@ -72,10 +87,13 @@ func Exec(args ...string) (string, error) {
 
 	if err != nil {
 		code = "1"
-		klog.Warningf("ovs-vsctl command error: %s %s in %vms", OvsVsCtl, strings.Join(args, " "), elapsed)
-		return "", fmt.Errorf("failed to run '%s %s': %w\n  %q", OvsVsCtl, strings.Join(args, " "), err, output)
+		klog.Warningf("ovs-vsctl command error: %s %s in %vms", command, strings.Join(args, " "), elapsed)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return "", fmt.Errorf("failed to run '%s %s': %w\n  %q", command, strings.Join(args, " "), err, output)
 	} else if elapsed > 500 {
-		klog.Warningf("ovs-vsctl command took too long: %s %s in %vms", OvsVsCtl, strings.Join(args, " "), elapsed)
+		klog.Warningf("ovs-vsctl command took too long: %s %s in %vms", command, strings.Join(args, " "), elapsed)
 	}
 	return trimCommandOutput(output), nil
 }
@@ -104,10 +122,15 @@ func ovsAdd(table, record, column string, values ...string) error {
 
 // Returns the given column of records that match the condition
 func ovsFind(table, column string, conditions ...string) ([]string, error) {
+	return ovsFindContext(context.Background(), table, column, conditions...)
+}
+
+// ovsFindContext is ovsFind with ovs-vsctl killed if the context is done before it finishes
+func ovsFindContext(ctx context.Context, table, column string, conditions ...string) ([]string, error) {
 	args := make([]string, len(conditions)+4)
 	args[0], args[1], args[2], args[3] = "--no-heading", "--columns="+column, "find", table
 	copy(args[4:], conditions)
-	output, err := Exec(args...)
+	output, err := ExecContext(ctx, args...)
 	if err != nil {
 		klog.Error(err)
 		return nil, err
@@ -154,12 +177,22 @@ func ovsGet(table, record, column, key string) (string, error) {
 
 // Bridges returns bridges created by Kube-OVN
 func Bridges() ([]string, error) {
-	return ovsFind("bridge", "name", fmt.Sprintf("external-ids:vendor=%s", util.CniTypeName))
+	return BridgesContext(context.Background())
+}
+
+// BridgesContext is Bridges with ovs-vsctl killed if the context is done before it finishes
+func BridgesContext(ctx context.Context) ([]string, error) {
+	return ovsFindContext(ctx, "bridge", "name", fmt.Sprintf("external-ids:vendor=%s", util.CniTypeName))
 }
 
 // BridgeExists checks whether the bridge already exists
 func BridgeExists(name string) (bool, error) {
-	bridges, err := Bridges()
+	return BridgeExistsContext(context.Background(), name)
+}
+
+// BridgeExistsContext is BridgeExists with ovs-vsctl killed if the context is done before it finishes
+func BridgeExistsContext(ctx context.Context, name string) (bool, error) {
+	bridges, err := BridgesContext(ctx)
 	if err != nil {
 		klog.Error(err)
 		return false, err
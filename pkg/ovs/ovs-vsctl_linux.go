@@ -1,6 +1,7 @@
 package ovs
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -382,7 +383,12 @@ func deleteNetemQosByID(qosID, iface, podName, podNamespace string) error {
 }
 
 func IsUserspaceDataPath() (is bool, err error) {
-	dp, err := ovsFind("bridge", "datapath_type", "name=br-int")
+	return IsUserspaceDataPathContext(context.Background())
+}
+
+// IsUserspaceDataPathContext is IsUserspaceDataPath with ovs-vsctl killed if the context is done before it finishes
+func IsUserspaceDataPathContext(ctx context.Context) (is bool, err error) {
+	dp, err := ovsFindContext(ctx, "bridge", "datapath_type", "name=br-int")
 	if err != nil {
 		klog.Error(err)
 		return false, err
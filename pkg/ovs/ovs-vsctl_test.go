@@ -1,7 +1,9 @@
 package ovs
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -23,6 +25,25 @@ func (suite *OvnClientTestSuite) testOvsExec() {
 	require.Empty(t, ret)
 }
 
+func (suite *OvnClientTestSuite) testOvsExecTimeout() {
+	t := suite.T()
+	t.Parallel()
+
+	// simulate a hung command
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	ret, err := execContext(ctx, "sleep", "30")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Empty(t, ret)
+	require.Less(t, time.Since(start), 10*time.Second)
+
+	ret, err = ExecWithTimeout(100*time.Millisecond, suite.ovsSocket, "show")
+	// ovs-vsctl cmd is not available in the test environment
+	require.Error(t, err)
+	require.Empty(t, ret)
+}
+
 func (suite *OvnClientTestSuite) testOvsCreate() {
 	t := suite.T()
 	t.Parallel()
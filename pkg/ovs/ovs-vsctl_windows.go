@@ -1,5 +1,7 @@
 package ovs
 
+import "context"
+
 // SetInterfaceBandwidth set ingress/egress qos for given pod, annotation values are for node/pod
 // but ingress/egress parameters here are from the point of ovs port/interface view, so reverse input parameters when call func SetInterfaceBandwidth
 func SetInterfaceBandwidth(podName, podNamespace, iface, ingress, egress string) error {
@@ -37,3 +39,7 @@ func SetNetemQos(podName, podNamespace, iface, latency, limit, loss, jitter stri
 func IsUserspaceDataPath() (is bool, err error) {
 	return false, nil
 }
+
+func IsUserspaceDataPathContext(_ context.Context) (is bool, err error) {
+	return false, nil
+}
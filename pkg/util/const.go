@@ -55,6 +55,8 @@ const (
 	PortVipAnnotationTemplate       = "%s.kubernetes.io/port_vips"
 	PortSecurityAnnotation          = "ovn.kubernetes.io/port_security"
 	NorthGatewayAnnotation          = "ovn.kubernetes.io/north_gateway"
+	NatPreserveDSCPAnnotation       = "ovn.kubernetes.io/nat_preserve_dscp"
+	U2ONoNatAnnotation              = "ovn.kubernetes.io/u2o_no_nat"
 
 	AllocatedAnnotationSuffix       = ".kubernetes.io/allocated"
 	AllocatedAnnotationTemplate     = "%s.kubernetes.io/allocated"
@@ -200,6 +202,11 @@ const (
 	NormalRouteType    = "normal"
 	EcmpRouteType      = "ecmp"
 	StaticRouteBfdEcmp = "ecmp_symmetric_reply"
+	// StaticRouteEcmpHashFields is the option of static routes specifying the packet fields of ecmp hashing
+	StaticRouteEcmpHashFields = "ecmp_hash_fields"
+
+	StaticRouteDiscardNexthop     = "discard"
+	StaticRouteDisabledRouteTable = "kube-ovn-disabled"
 
 	Vip = "vip"
 
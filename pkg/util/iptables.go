@@ -3,12 +3,31 @@
 
 package util
 
-// IPTableRule wraps iptables rule
+import "slices"
+
+// IPTableRule wraps iptables rule, the rule is marked with the comment if it's not empty
 type IPTableRule struct {
-	Table string
-	Chain string
-	Pos   string
-	Rule  []string
+	Table   string
+	Chain   string
+	Pos     string
+	Rule    []string
+	Comment string
+}
+
+// RuleSpec returns the rule spec with the comment match inserted before the target,
+// which is the same order as the one listed by iptables
+func (r IPTableRule) RuleSpec() []string {
+	if r.Comment == "" {
+		return r.Rule
+	}
+
+	comment := []string{"-m", "comment", "--comment", r.Comment}
+	for i, s := range r.Rule {
+		if s == "-j" || s == "-g" {
+			return slices.Concat(r.Rule[:i], comment, r.Rule[i:])
+		}
+	}
+	return slices.Concat(r.Rule, comment)
 }
 
 type GwIPtableCounters struct {
@@ -0,0 +1,48 @@
+//go:build !windows
+// +build !windows
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPTableRuleSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		rule IPTableRule
+		spec string
+	}{
+		{
+			name: "without comment",
+			rule: IPTableRule{Rule: strings.Fields(`-m set --match-set ovn40subnets src -j ACCEPT`)},
+			spec: `-m set --match-set ovn40subnets src -j ACCEPT`,
+		},
+		{
+			name: "comment before target",
+			rule: IPTableRule{Rule: strings.Fields(`-s 10.16.0.0/16 -j SNAT --to-source 172.18.0.2`), Comment: "kube-ovn"},
+			spec: `-s 10.16.0.0/16 -m comment --comment kube-ovn -j SNAT --to-source 172.18.0.2`,
+		},
+		{
+			name: "comment before goto",
+			rule: IPTableRule{Rule: strings.Fields(`-p tcp -g OVN-MASQUERADE`), Comment: "kube-ovn"},
+			spec: `-p tcp -m comment --comment kube-ovn -g OVN-MASQUERADE`,
+		},
+		{
+			name: "without target",
+			rule: IPTableRule{Rule: strings.Fields(`-d 10.16.0.0/16`), Comment: "kube-ovn"},
+			spec: `-d 10.16.0.0/16 -m comment --comment kube-ovn`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, strings.Fields(tt.spec), tt.rule.RuleSpec())
+		})
+	}
+
+	rule := IPTableRule{Rule: strings.Fields(`-j ACCEPT`), Comment: "kube-ovn input rules"}
+	require.Equal(t, []string{"-m", "comment", "--comment", "kube-ovn input rules", "-j", "ACCEPT"}, rule.RuleSpec())
+}
@@ -176,6 +176,31 @@ func ValidateSubnet(subnet kubeovnv1.Subnet) error {
 		}
 	}
 
+	if snatIP := subnet.Spec.NatOutgoingSnatIP; snatIP != "" {
+		// v6 ip address can not use upper case
+		if ContainsUppercase(snatIP) {
+			err := fmt.Errorf("subnet %s nat outgoing snat ip %s v6 ip address can not contain upper case", subnet.Name, snatIP)
+			klog.Error(err)
+			return err
+		}
+		ips := strings.Split(snatIP, ",")
+		if len(ips) > 2 {
+			return errors.New("invalid nat outgoing snat ip configuration")
+		}
+		for _, ip := range ips {
+			if net.ParseIP(ip) == nil {
+				return fmt.Errorf("IP %s in natOutgoingSnatIP is not a valid address", ip)
+			}
+		}
+		snatProtocol, cidrProtocol := CheckProtocol(snatIP), CheckProtocol(subnet.Spec.CIDRBlock)
+		if len(ips) == 2 && snatProtocol != kubeovnv1.ProtocolDual {
+			return errors.New("invalid nat outgoing snat ip configuration: two addresses of the same family")
+		}
+		if snatProtocol != cidrProtocol && cidrProtocol != kubeovnv1.ProtocolDual {
+			return errors.New("invalid nat outgoing snat ip configuration: address family is conflict with CIDR")
+		}
+	}
+
 	if subnet.Spec.U2OInterconnectionIP != "" {
 		// v6 ip address can not use upper case
 		if ContainsUppercase(subnet.Spec.U2OInterconnectionIP) {
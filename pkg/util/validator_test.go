@@ -755,6 +755,98 @@ func TestValidateSubnet(t *testing.T) {
 			},
 			err: "vip 10.17.2.1 conflicts with subnet utest-exgatewayerr cidr 10.16.0.0/16",
 		},
+		{
+			name: "NatOutgoingSnatIPErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest-snatiperr",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Default:           true,
+					Vpc:               "ovn-cluster",
+					Protocol:          kubeovnv1.ProtocolIPv4,
+					Namespaces:        nil,
+					CIDRBlock:         "10.16.0.0/16",
+					Gateway:           "10.16.0.1",
+					Provider:          OvnProvider,
+					GatewayType:       kubeovnv1.GWDistributedType,
+					NatOutgoing:       true,
+					NatOutgoingSnatIP: "172.18.0..2",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "IP 172.18.0..2 in natOutgoingSnatIP is not a valid address",
+		},
+		{
+			name: "NatOutgoingSnatIPErr2",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest-snatiperr",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Default:           true,
+					Vpc:               "ovn-cluster",
+					Protocol:          kubeovnv1.ProtocolDual,
+					Namespaces:        nil,
+					CIDRBlock:         "10.16.0.0/16,fd00:10:16::/64",
+					Gateway:           "10.16.0.1,fd00:10:16::1",
+					Provider:          OvnProvider,
+					GatewayType:       kubeovnv1.GWDistributedType,
+					NatOutgoing:       true,
+					NatOutgoingSnatIP: "172.18.0.2,172.18.0.3",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "invalid nat outgoing snat ip configuration: two addresses of the same family",
+		},
+		{
+			name: "NatOutgoingSnatIPErr3",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest-snatiperr",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Default:           true,
+					Vpc:               "ovn-cluster",
+					Protocol:          kubeovnv1.ProtocolIPv4,
+					Namespaces:        nil,
+					CIDRBlock:         "10.16.0.0/16",
+					Gateway:           "10.16.0.1",
+					Provider:          OvnProvider,
+					GatewayType:       kubeovnv1.GWDistributedType,
+					NatOutgoing:       true,
+					NatOutgoingSnatIP: "fc00:f853:ccd:e793::2",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "invalid nat outgoing snat ip configuration: address family is conflict with CIDR",
+		},
+		{
+			name: "NatOutgoingSnatIPCorrect",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest-snatiperr",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Default:           true,
+					Vpc:               "ovn-cluster",
+					Protocol:          kubeovnv1.ProtocolDual,
+					Namespaces:        nil,
+					CIDRBlock:         "10.16.0.0/16,fd00:10:16::/64",
+					Gateway:           "10.16.0.1,fd00:10:16::1",
+					Provider:          OvnProvider,
+					GatewayType:       kubeovnv1.GWDistributedType,
+					NatOutgoing:       true,
+					NatOutgoingSnatIP: "172.18.0.2,fc00:f853:ccd:e793::2",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "",
+		},
 		{
 			name: "CIDRformErr",
 			asubnet: kubeovnv1.Subnet{